@@ -1,17 +1,27 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
-	ApiHost string
-	Port    string
-	Sqlite  struct {
+	ApiHost  string
+	Port     string
+	Database struct {
+		Driver string
+	}
+	Sqlite struct {
 		DbDir   string
 		MigrDir string
 	}
+	Postgres struct {
+		DSN     string
+		MigrDir string
+	}
 	TLS struct {
 		CertDir string
 		KeyDir  string
@@ -29,6 +39,30 @@ type Config struct {
 		ClientID     string
 	}
 	PostImagesDir string
+	Moderation    struct {
+		PostsApproval bool
+	}
+	Comments struct {
+		MaxDepth int
+	}
+	Sessions struct {
+		Store    string
+		Lifetime time.Duration
+	}
+	Mailer struct {
+		Driver string
+		From   string
+		File   string
+		SMTP   struct {
+			Host     string
+			Port     string
+			Username string
+			Password string
+		}
+	}
+	Digests struct {
+		Interval time.Duration
+	}
 }
 
 func newConfig() *Config {
@@ -42,13 +76,48 @@ func newConfig() *Config {
 		apiHost = fmt.Sprintf("http://localhost:%s", apiPort)
 	}
 
-	return &Config{
+	commentsMaxDepth, err := strconv.Atoi(os.Getenv("COMMENTS_MAX_DEPTH"))
+	if err != nil || commentsMaxDepth < 0 {
+		commentsMaxDepth = 3
+	}
+
+	dbDriver := os.Getenv("DB_DRIVER")
+	if len(dbDriver) == 0 {
+		dbDriver = "sqlite"
+	}
+
+	sessionStore := os.Getenv("SESSION_STORE")
+	if len(sessionStore) == 0 {
+		sessionStore = dbDriver
+	}
+
+	mailerDriver := os.Getenv("MAILER")
+	if len(mailerDriver) == 0 {
+		mailerDriver = "stdout"
+	}
+
+	mailFrom := os.Getenv("MAIL_FROM")
+	if len(mailFrom) == 0 {
+		mailFrom = "forum@localhost"
+	}
+
+	smtpPort := os.Getenv("SMTP_PORT")
+	if len(smtpPort) == 0 {
+		smtpPort = "587"
+	}
+
+	digestsInterval, err := time.ParseDuration(os.Getenv("DIGESTS_INTERVAL"))
+	if err != nil || digestsInterval <= 0 {
+		digestsInterval = time.Hour
+	}
+
+	config := &Config{
 		ApiHost: apiHost,
 		Port:    apiPort,
 		Sqlite: struct {
 			DbDir   string
 			MigrDir string
-		}{DbDir: "./storage/storage.db?parseTime=true", MigrDir: "./migrations/sqlite/00001_initial.up.sql"},
+		}{DbDir: "./storage/storage.db?parseTime=true&_foreign_keys=on", MigrDir: "./migrations/sqlite/"},
 		TLS: struct {
 			CertDir string
 			KeyDir  string
@@ -66,5 +135,51 @@ func newConfig() *Config {
 			ClientID     string
 		}{ClientSecret: os.Getenv("GOOGLE_CLIENT_SECRET"), ClientID: os.Getenv("GOOGLE_CLIENT_ID")},
 		PostImagesDir: "./post_images/",
+		Moderation: struct {
+			PostsApproval bool
+		}{PostsApproval: os.Getenv("POSTS_APPROVAL") == "true"},
+		Comments: struct {
+			MaxDepth int
+		}{MaxDepth: commentsMaxDepth},
+		Sessions: struct {
+			Store    string
+			Lifetime time.Duration
+		}{Store: sessionStore, Lifetime: 24 * time.Hour},
 	}
+
+	config.Database.Driver = dbDriver
+	config.Postgres.DSN = os.Getenv("DATABASE_URL")
+	config.Postgres.MigrDir = "./migrations/postgres/"
+	config.Mailer.Driver = mailerDriver
+	config.Mailer.From = mailFrom
+	config.Mailer.File = "./storage/mail.log"
+	config.Mailer.SMTP.Host = os.Getenv("SMTP_HOST")
+	config.Mailer.SMTP.Port = smtpPort
+	config.Mailer.SMTP.Username = os.Getenv("SMTP_USERNAME")
+	config.Mailer.SMTP.Password = os.Getenv("SMTP_PASSWORD")
+	config.Digests.Interval = digestsInterval
+
+	return config
+}
+
+var (
+	ErrUnsupportedDriver = errors.New("CONFIG: unsupported database driver")
+	ErrMissingDSN        = errors.New("CONFIG: postgres requires DATABASE_URL")
+)
+
+// checkDatabaseDriver fails for backends other than SQLite and PostgreSQL,
+// and for PostgreSQL without a connection string.
+func checkDatabaseDriver(driver, dsn string) error {
+	switch driver {
+	case "sqlite":
+		return nil
+	case "postgres":
+		if len(dsn) == 0 {
+			return ErrMissingDSN
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w %q, set DB_DRIVER=sqlite or DB_DRIVER=postgres", ErrUnsupportedDriver, driver)
 }
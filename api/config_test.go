@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDatabaseDriver(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		dsn     string
+		wantErr error
+	}{
+		{"default", "", "", nil},
+		{"sqlite", "sqlite", "", nil},
+		{"postgres", "postgres", "postgres://forum@localhost/forum", nil},
+		{"postgres without dsn", "postgres", "", ErrMissingDSN},
+		{"mysql", "mysql", "", ErrUnsupportedDriver},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DB_DRIVER", tt.env)
+			t.Setenv("DATABASE_URL", tt.dsn)
+
+			conf := newConfig()
+			if err := checkDatabaseDriver(conf.Database.Driver, conf.Postgres.DSN); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
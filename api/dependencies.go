@@ -2,10 +2,15 @@ package main
 
 import (
 	"database/sql"
+	"errors"
+	"os"
+	"time"
 
+	"github.com/itelman/forum/pkg/mailer"
 	"github.com/itelman/forum/pkg/oauth"
 	"github.com/itelman/forum/pkg/oauth/github"
 	"github.com/itelman/forum/pkg/oauth/google"
+	"github.com/itelman/forum/pkg/postgres"
 	"github.com/itelman/forum/pkg/sesm"
 	"github.com/itelman/forum/pkg/sqlite"
 	"github.com/itelman/forum/pkg/templates"
@@ -13,16 +18,31 @@ import (
 
 type Dependencies struct {
 	sqlite        *sql.DB
+	postgres      *sql.DB
 	githubAuth    oauth.AuthApi
 	googleAuth    oauth.AuthApi
 	sesManager    sesm.SessionManager
 	templateCache templates.TemplateCache
+	mailer        mailer.Mailer
+	mailFile      *os.File
 }
 
 func (d *Dependencies) Close() {
+	if closer, ok := d.sesManager.(interface{ Close() }); ok {
+		closer.Close()
+	}
+
+	if d.mailFile != nil {
+		d.mailFile.Close()
+	}
+
 	if d.sqlite != nil {
 		d.sqlite.Close()
 	}
+
+	if d.postgres != nil {
+		d.postgres.Close()
+	}
 }
 
 func NewDependencies(opts ...Option) (deps *Dependencies, err error) {
@@ -33,7 +53,9 @@ func NewDependencies(opts ...Option) (deps *Dependencies, err error) {
 		}
 	}
 
-	deps.sesManager = sesm.NewSessionManager()
+	if deps.sesManager == nil {
+		deps.sesManager = sesm.NewSessionManager()
+	}
 
 	return deps, nil
 }
@@ -47,6 +69,11 @@ func WithSqlite(dbDir, migrDir string) Option {
 			return err
 		}
 
+		if err := sqlite.CheckFeatures(db); err != nil {
+			db.Close()
+			return err
+		}
+
 		if err := sqlite.Migrate(db, migrDir); err != nil {
 			return err
 		}
@@ -56,6 +83,47 @@ func WithSqlite(dbDir, migrDir string) Option {
 	}
 }
 
+func WithSqliteSessions(lifetime time.Duration) Option {
+	return func(d *Dependencies) error {
+		if d.sqlite == nil {
+			return errors.New("DEPENDENCIES: sqlite sessions require a sqlite connection")
+		}
+
+		d.sesManager = sesm.NewSqliteSessionManager(d.sqlite, lifetime)
+		return nil
+	}
+}
+
+// WithPostgres connects to PostgreSQL instead of SQLite. The migrations in
+// migrDir are applied by the same migrator.
+func WithPostgres(dsn, migrDir string) Option {
+	return func(d *Dependencies) error {
+		db, err := postgres.NewPostgres(dsn)
+		if err != nil {
+			return err
+		}
+
+		if err := sqlite.Migrate(db, migrDir); err != nil {
+			db.Close()
+			return err
+		}
+
+		d.postgres = db
+		return nil
+	}
+}
+
+func WithPostgresSessions(lifetime time.Duration) Option {
+	return func(d *Dependencies) error {
+		if d.postgres == nil {
+			return errors.New("DEPENDENCIES: postgres sessions require a postgres connection")
+		}
+
+		d.sesManager = sesm.NewPostgresSessionManager(d.postgres, lifetime)
+		return nil
+	}
+}
+
 func WithGithubAuth(clientSecret, clientId, apiHost string) Option {
 	return func(d *Dependencies) error {
 		d.githubAuth = github.NewOAuth(clientSecret, clientId, apiHost)
@@ -81,3 +149,35 @@ func WithTemplateCache(dir string) Option {
 		return nil
 	}
 }
+
+func WithSMTPMailer(host, port, username, password, from string) Option {
+	return func(d *Dependencies) error {
+		if len(host) == 0 {
+			return errors.New("DEPENDENCIES: smtp mailer requires SMTP_HOST")
+		}
+
+		d.mailer = mailer.NewSMTPMailer(host, port, username, password, from)
+		return nil
+	}
+}
+
+// WithFileMailer appends outgoing mail to a file instead of sending it.
+func WithFileMailer(path, from string) Option {
+	return func(d *Dependencies) error {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+		if err != nil {
+			return err
+		}
+
+		d.mailFile = f
+		d.mailer = mailer.NewWriterMailer(f, from)
+		return nil
+	}
+}
+
+func WithStdoutMailer(from string) Option {
+	return func(d *Dependencies) error {
+		d.mailer = mailer.NewWriterMailer(os.Stdout, from)
+		return nil
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/itelman/forum/internal/service/digests"
+)
+
+// runDigests sends the email digests that are due, once at startup and then
+// every interval.
+func runDigests(svc digests.Service, interval time.Duration, errorLog *log.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := svc.SendDueDigests(); err != nil {
+			errorLog.Printf("Digests: %v", err)
+		}
+
+		<-ticker.C
+	}
+}
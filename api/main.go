@@ -3,7 +3,6 @@ package main
 import (
 	"errors"
 	"fmt"
-	authMiddleware "github.com/itelman/forum/internal/handler/users/middleware"
 	"log"
 	"net/http"
 	"os"
@@ -11,32 +10,7 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/itelman/forum/internal/exception"
-	"github.com/itelman/forum/internal/handler"
-	activityHandlers "github.com/itelman/forum/internal/handler/activity"
-	commentsHandlers "github.com/itelman/forum/internal/handler/comments"
-	"github.com/itelman/forum/internal/handler/home"
-	notificationsHandlers "github.com/itelman/forum/internal/handler/notifications"
-	"github.com/itelman/forum/internal/handler/oauth/github"
-	"github.com/itelman/forum/internal/handler/oauth/google"
-	postsHandlers "github.com/itelman/forum/internal/handler/posts"
-	commentReactionsHandlers "github.com/itelman/forum/internal/handler/reactions/comment_reactions"
-	postReactionsHandlers "github.com/itelman/forum/internal/handler/reactions/post_reactions"
-	usersHandlers "github.com/itelman/forum/internal/handler/users"
-	"github.com/itelman/forum/internal/middleware/dynamic"
-	"github.com/itelman/forum/internal/middleware/standard"
-	"github.com/itelman/forum/internal/service/activity"
-	"github.com/itelman/forum/internal/service/categories"
-	"github.com/itelman/forum/internal/service/comment_reactions"
-	"github.com/itelman/forum/internal/service/comments"
-	"github.com/itelman/forum/internal/service/filters"
-	"github.com/itelman/forum/internal/service/notifications"
-	"github.com/itelman/forum/internal/service/oauth"
-	"github.com/itelman/forum/internal/service/post_reactions"
-	"github.com/itelman/forum/internal/service/posts"
-	"github.com/itelman/forum/internal/service/users"
-	"github.com/itelman/forum/pkg/templates"
-
+	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
 )
 
@@ -51,85 +25,62 @@ func main() {
 	defer f.Close()
 
 	conf := newConfig()
-	deps, err := NewDependencies(
-		WithSqlite(conf.Sqlite.DbDir, conf.Sqlite.MigrDir),
-		WithGithubAuth(conf.Github.ClientSecret, conf.Github.ClientID, conf.ApiHost),
-		WithGoogleAuth(conf.Google.ClientSecret, conf.Google.ClientID, conf.ApiHost),
-		WithTemplateCache(conf.UI.TmplDir),
-	)
-	if err != nil {
+	if err := checkDatabaseDriver(conf.Database.Driver, conf.Postgres.DSN); err != nil {
 		errorLog.Fatal(err)
 	}
-	defer deps.Close()
-
-	tmplRender := templates.NewTemplateRender(deps.templateCache, deps.sesManager)
-	exceptionHandlers := exception.NewExceptions(errorLog, tmplRender)
-
-	usersSvc := users.NewService(
-		users.WithSqlite(deps.sqlite),
-	)
-
-	authMid := authMiddleware.NewMiddleware(usersSvc, deps.sesManager, exceptionHandlers)
-	dynamicMiddleware := dynamic.NewMiddleware(authMid, deps.sesManager, exceptionHandlers)
-	defaultHandlers := handler.NewHandlers(dynamicMiddleware, deps.sesManager, exceptionHandlers, tmplRender)
-
-	postsSvc := posts.NewService(
-		posts.WithSqlite(deps.sqlite),
-	)
-
-	commentsSvc := comments.NewService(
-		comments.WithSqlite(deps.sqlite),
-	)
-
-	postReactionsSvc := post_reactions.NewService(
-		post_reactions.WithSqlite(deps.sqlite),
-	)
-
-	commentReactionsSvc := comment_reactions.NewService(
-		comment_reactions.WithSqlite(deps.sqlite),
-	)
-
-	categoriesSvc := categories.NewService(
-		categories.WithSqlite(deps.sqlite),
-	)
 
-	filtersSvc := filters.NewService(
-		filters.WithSqlite(deps.sqlite),
-	)
+	dataSource, migrDir := conf.Sqlite.DbDir, conf.Sqlite.MigrDir
+	if conf.Database.Driver == "postgres" {
+		dataSource, migrDir = conf.Postgres.DSN, conf.Postgres.MigrDir
+	}
 
-	oauthSvc := oauth.NewService(
-		oauth.WithSqlite(deps.sqlite),
-	)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:], conf.Database.Driver, dataSource, migrDir, os.Stdout); err != nil {
+			errorLog.Fatal(err)
+		}
+		return
+	}
 
-	notificationsSvc := notifications.NewService(
-		notifications.WithSqlite(deps.sqlite),
-	)
+	depsOpts := []Option{
+		WithGithubAuth(conf.Github.ClientSecret, conf.Github.ClientID, conf.ApiHost),
+		WithGoogleAuth(conf.Google.ClientSecret, conf.Google.ClientID, conf.ApiHost),
+		WithTemplateCache(conf.UI.TmplDir),
+	}
+	if conf.Database.Driver == "postgres" {
+		depsOpts = append(depsOpts, WithPostgres(dataSource, migrDir))
+	} else {
+		depsOpts = append(depsOpts, WithSqlite(dataSource, migrDir))
+	}
 
-	activitySvc := activity.NewService(
-		activity.WithSqlite(deps.sqlite),
-	)
+	switch conf.Sessions.Store {
+	case "sqlite":
+		depsOpts = append(depsOpts, WithSqliteSessions(conf.Sessions.Lifetime))
+	case "postgres":
+		depsOpts = append(depsOpts, WithPostgresSessions(conf.Sessions.Lifetime))
+	}
 
-	mux := http.NewServeMux()
+	switch conf.Mailer.Driver {
+	case "smtp":
+		depsOpts = append(depsOpts, WithSMTPMailer(conf.Mailer.SMTP.Host, conf.Mailer.SMTP.Port, conf.Mailer.SMTP.Username, conf.Mailer.SMTP.Password, conf.Mailer.From))
+	case "file":
+		depsOpts = append(depsOpts, WithFileMailer(conf.Mailer.File, conf.Mailer.From))
+	default:
+		depsOpts = append(depsOpts, WithStdoutMailer(conf.Mailer.From))
+	}
 
-	home.NewHandlers(defaultHandlers, postsSvc, categoriesSvc, filtersSvc).RegisterMux(mux)
-	usersHandlers.NewHandlers(defaultHandlers, usersSvc).RegisterMux(mux)
-	postsHandlers.NewHandlers(defaultHandlers, postsSvc, categoriesSvc, conf.PostImagesDir).RegisterMux(mux)
-	commentsHandlers.NewHandlers(defaultHandlers, commentsSvc).RegisterMux(mux)
-	postReactionsHandlers.NewHandlers(defaultHandlers, postReactionsSvc).RegisterMux(mux)
-	commentReactionsHandlers.NewHandlers(defaultHandlers, commentReactionsSvc).RegisterMux(mux)
-	github.NewHandlers(defaultHandlers, oauthSvc, deps.githubAuth).RegisterMux(mux)
-	google.NewHandlers(defaultHandlers, oauthSvc, deps.googleAuth).RegisterMux(mux)
-	notificationsHandlers.NewHandlers(defaultHandlers, notificationsSvc).RegisterMux(mux)
-	activityHandlers.NewHandlers(defaultHandlers, activitySvc).RegisterMux(mux)
+	deps, err := NewDependencies(depsOpts...)
+	if err != nil {
+		errorLog.Fatal(err)
+	}
+	defer deps.Close()
 
-	fileServer := http.FileServer(http.Dir(conf.UI.CSSDir))
-	mux.Handle("/static/", http.StripPrefix("/static/", fileServer))
-	mux.Handle("/images/", http.StripPrefix("/images/", http.FileServer(http.Dir(conf.PostImagesDir))))
+	handler, digestsSvc := newRouter(conf, deps, infoLog, errorLog)
+	go runDigests(digestsSvc, conf.Digests.Interval, errorLog)
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", conf.Port),
 		ErrorLog:     errorLog,
-		Handler:      standard.NewMiddleware(exceptionHandlers, infoLog).Chain(mux),
+		Handler:      handler,
 		IdleTimeout:  time.Minute,
 		ReadTimeout:  5 * time.Second,
 		WriteTimeout: 10 * time.Second,
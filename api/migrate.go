@@ -0,0 +1,100 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/itelman/forum/pkg/postgres"
+	"github.com/itelman/forum/pkg/sqlite"
+)
+
+const migrateUsage = "usage: forum migrate up|down|status|to <version>"
+
+// runMigrate implements the "migrate" subcommand. The server applies pending
+// migrations on start; the subcommand is for inspecting and rolling back.
+func runMigrate(args []string, driver, dataSource, migrDir string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	db, err := openDatabase(driver, dataSource)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	m, err := sqlite.NewMigrator(db, migrDir)
+	if err != nil {
+		return err
+	}
+
+	var result *sqlite.MigrationResult
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		result, err = m.Up()
+	case args[0] == "down" && len(args) == 1:
+		result, err = m.Down()
+	case args[0] == "to" && len(args) == 2:
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil || version < 0 {
+			return errors.New(migrateUsage)
+		}
+		result, err = m.To(version)
+	case args[0] == "status" && len(args) == 1:
+		return printMigrationStatus(m, out)
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	if result == nil {
+		return err
+	}
+
+	for _, version := range result.RolledBack {
+		fmt.Fprintf(out, "rolled back %d\n", version)
+	}
+
+	for _, version := range result.Applied {
+		fmt.Fprintf(out, "applied %d\n", version)
+	}
+
+	if err == nil && len(result.Applied)+len(result.RolledBack) == 0 {
+		fmt.Fprintln(out, "nothing to migrate")
+	}
+
+	return err
+}
+
+func openDatabase(driver, dataSource string) (*sql.DB, error) {
+	if driver == "postgres" {
+		return postgres.NewPostgres(dataSource)
+	}
+
+	return sqlite.NewSqlite(dataSource)
+}
+
+func printMigrationStatus(m *sqlite.Migrator, out io.Writer) error {
+	statuses, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		state := "applied " + status.Applied.Format("2006-01-02 15:04:05")
+		switch {
+		case status.Pending:
+			state = "pending"
+		case status.Missing:
+			state += " (file missing)"
+		case status.Modified:
+			state += " (file modified)"
+		}
+
+		fmt.Fprintf(out, "%05d_%s\t%s\n", status.Version, status.Name, state)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestRunMigrate(t *testing.T) {
+	migrDir := t.TempDir()
+	for name, content := range map[string]string{
+		"00001_users.up.sql":   "CREATE TABLE users (id INTEGER PRIMARY KEY);",
+		"00001_users.down.sql": "DROP TABLE users;",
+		"00002_posts.up.sql":   "CREATE TABLE posts (id INTEGER PRIMARY KEY);",
+		"00002_posts.down.sql": "DROP TABLE posts;",
+	} {
+		if err := os.WriteFile(filepath.Join(migrDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dbDir := filepath.Join(t.TempDir(), "forum.db")
+
+	tests := []struct {
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{[]string{"status"}, `^00001_users\tpending\n00002_posts\tpending\n$`, false},
+		{[]string{"to", "1"}, `^applied 1\n$`, false},
+		{[]string{"up"}, `^applied 2\n$`, false},
+		{[]string{"up"}, `^nothing to migrate\n$`, false},
+		{[]string{"status"}, `^00001_users\tapplied [\d-]+ [\d:]+\n00002_posts\tapplied [\d-]+ [\d:]+\n$`, false},
+		{[]string{"down"}, `^rolled back 2\n$`, false},
+		{[]string{"to", "0"}, `^rolled back 1\n$`, false},
+		{[]string{"to", "3"}, `^$`, true},
+		{[]string{"to", "-1"}, `^$`, true},
+		{[]string{"sideways"}, `^$`, true},
+		{nil, `^$`, true},
+	}
+
+	for _, tt := range tests {
+		out := new(bytes.Buffer)
+		err := runMigrate(tt.args, "sqlite", dbDir, migrDir, out)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("migrate %v: err = %v, want error %t", tt.args, err, tt.wantErr)
+		}
+
+		if !regexp.MustCompile(tt.want).MatchString(out.String()) {
+			t.Fatalf("migrate %v: output %q does not match %q", tt.args, out.String(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"database/sql"
+	authMiddleware "github.com/itelman/forum/internal/handler/users/middleware"
+	"log"
+	"net/http"
+
+	"github.com/itelman/forum/internal/exception"
+	"github.com/itelman/forum/internal/handler"
+	activityHandlers "github.com/itelman/forum/internal/handler/activity"
+	"github.com/itelman/forum/internal/handler/api"
+	categoriesHandlers "github.com/itelman/forum/internal/handler/categories"
+	commentsHandlers "github.com/itelman/forum/internal/handler/comments"
+	digestsHandlers "github.com/itelman/forum/internal/handler/digests"
+	eventsHandlers "github.com/itelman/forum/internal/handler/events"
+	"github.com/itelman/forum/internal/handler/home"
+	moderationHandlers "github.com/itelman/forum/internal/handler/moderation"
+	notificationsHandlers "github.com/itelman/forum/internal/handler/notifications"
+	"github.com/itelman/forum/internal/handler/oauth/github"
+	"github.com/itelman/forum/internal/handler/oauth/google"
+	postsHandlers "github.com/itelman/forum/internal/handler/posts"
+	profilesHandlers "github.com/itelman/forum/internal/handler/profiles"
+	commentReactionsHandlers "github.com/itelman/forum/internal/handler/reactions/comment_reactions"
+	postReactionsHandlers "github.com/itelman/forum/internal/handler/reactions/post_reactions"
+	reportsHandlers "github.com/itelman/forum/internal/handler/reports"
+	searchHandlers "github.com/itelman/forum/internal/handler/search"
+	tagsHandlers "github.com/itelman/forum/internal/handler/tags"
+	tokensHandlers "github.com/itelman/forum/internal/handler/tokens"
+	usersHandlers "github.com/itelman/forum/internal/handler/users"
+	"github.com/itelman/forum/internal/middleware/dynamic"
+	"github.com/itelman/forum/internal/middleware/standard"
+	"github.com/itelman/forum/internal/service/activity"
+	"github.com/itelman/forum/internal/service/categories"
+	"github.com/itelman/forum/internal/service/comment_reactions"
+	"github.com/itelman/forum/internal/service/comments"
+	"github.com/itelman/forum/internal/service/digests"
+	"github.com/itelman/forum/internal/service/filters"
+	"github.com/itelman/forum/internal/service/moderation"
+	"github.com/itelman/forum/internal/service/notifications"
+	"github.com/itelman/forum/internal/service/oauth"
+	"github.com/itelman/forum/internal/service/post_reactions"
+	"github.com/itelman/forum/internal/service/posts"
+	"github.com/itelman/forum/internal/service/profiles"
+	"github.com/itelman/forum/internal/service/reports"
+	"github.com/itelman/forum/internal/service/search"
+	"github.com/itelman/forum/internal/service/tags"
+	"github.com/itelman/forum/internal/service/tokens"
+	"github.com/itelman/forum/internal/service/users"
+	"github.com/itelman/forum/pkg/events"
+	"github.com/itelman/forum/pkg/templates"
+)
+
+// newRouter wires every service and handler package against deps and returns
+// the fully middleware-wrapped mux, along with the digests service so the
+// caller decides whether to schedule it.
+func newRouter(conf *Config, deps *Dependencies, infoLog, errorLog *log.Logger) (http.Handler, digests.Service) {
+	hub := events.NewHub()
+
+	notificationsSvc := notifications.NewService(
+		storage(deps, notifications.WithSqlite, notifications.WithPostgres),
+		notifications.WithEvents(hub),
+	)
+
+	tmplRender := templates.NewTemplateRender(deps.templateCache, deps.sesManager, templates.WithUnreadCount(func(userID int) (int, error) {
+		resp, err := notificationsSvc.CountUnreadNotifications(&notifications.CountUnreadNotificationsInput{AuthUserID: userID})
+		if err != nil {
+			return 0, err
+		}
+
+		return resp.Count, nil
+	}))
+	exceptionHandlers := exception.NewExceptions(errorLog, tmplRender)
+
+	usersSvc := users.NewService(
+		storage(deps, users.WithSqlite, users.WithPostgres),
+	)
+
+	// The HTML and JSON routes share one budget per session.
+	limiter := authMiddleware.NewRateLimiter()
+	authMid := authMiddleware.NewMiddleware(usersSvc, deps.sesManager, exceptionHandlers, authMiddleware.WithRateLimiter(limiter))
+	dynamicMiddleware := dynamic.NewMiddleware(authMid, deps.sesManager, exceptionHandlers)
+	defaultHandlers := handler.NewHandlers(dynamicMiddleware, deps.sesManager, exceptionHandlers, tmplRender)
+
+	postsSvc := posts.NewService(
+		storage(deps, posts.WithSqlite, posts.WithPostgres),
+		posts.WithApproval(conf.Moderation.PostsApproval),
+		posts.WithEvents(hub),
+	)
+
+	commentsSvc := comments.NewService(
+		storage(deps, comments.WithSqlite, comments.WithPostgres),
+		comments.WithMaxDepth(conf.Comments.MaxDepth),
+		comments.WithEvents(hub),
+	)
+
+	postReactionsSvc := post_reactions.NewService(
+		storage(deps, post_reactions.WithSqlite, post_reactions.WithPostgres),
+		post_reactions.WithEvents(hub),
+	)
+
+	commentReactionsSvc := comment_reactions.NewService(
+		storage(deps, comment_reactions.WithSqlite, comment_reactions.WithPostgres),
+		comment_reactions.WithEvents(hub),
+	)
+
+	categoriesSvc := categories.NewService(
+		storage(deps, categories.WithSqlite, categories.WithPostgres),
+	)
+
+	filtersSvc := filters.NewService(
+		storage(deps, filters.WithSqlite, filters.WithPostgres),
+	)
+
+	oauthSvc := oauth.NewService(
+		storage(deps, oauth.WithSqlite, oauth.WithPostgres),
+	)
+
+	activitySvc := activity.NewService(
+		storage(deps, activity.WithSqlite, activity.WithPostgres),
+	)
+
+	moderationSvc := moderation.NewService(
+		storage(deps, moderation.WithSqlite, moderation.WithPostgres),
+	)
+
+	reportsSvc := reports.NewService(
+		storage(deps, reports.WithSqlite, reports.WithPostgres),
+	)
+
+	tokensSvc := tokens.NewService(
+		storage(deps, tokens.WithSqlite, tokens.WithPostgres),
+	)
+
+	searchSvc := search.NewService(
+		storage(deps, search.WithSqlite, search.WithPostgres),
+	)
+
+	tagsSvc := tags.NewService(
+		storage(deps, tags.WithSqlite, tags.WithPostgres),
+	)
+
+	profilesSvc := profiles.NewService(
+		storage(deps, profiles.WithSqlite, profiles.WithPostgres),
+	)
+
+	digestsSvc := digests.NewService(
+		storage(deps, digests.WithSqlite, digests.WithPostgres),
+		digests.WithMailer(deps.mailer, tmplRender, conf.ApiHost),
+	)
+
+	mux := http.NewServeMux()
+
+	home.NewHandlers(defaultHandlers, postsSvc, categoriesSvc, filtersSvc, tagsSvc).RegisterMux(mux)
+	usersHandlers.NewHandlers(defaultHandlers, usersSvc).RegisterMux(mux)
+	postsHandlers.NewHandlers(defaultHandlers, postsSvc, categoriesSvc, conf.PostImagesDir).RegisterMux(mux)
+	commentsHandlers.NewHandlers(defaultHandlers, commentsSvc).RegisterMux(mux)
+	postReactionsHandlers.NewHandlers(defaultHandlers, postReactionsSvc).RegisterMux(mux)
+	commentReactionsHandlers.NewHandlers(defaultHandlers, commentReactionsSvc).RegisterMux(mux)
+	github.NewHandlers(defaultHandlers, oauthSvc, deps.githubAuth).RegisterMux(mux)
+	google.NewHandlers(defaultHandlers, oauthSvc, deps.googleAuth).RegisterMux(mux)
+	notificationsHandlers.NewHandlers(defaultHandlers, notificationsSvc).RegisterMux(mux)
+	eventsHandlers.NewHandlers(defaultHandlers, hub, postsSvc, notificationsSvc).RegisterMux(mux)
+	activityHandlers.NewHandlers(defaultHandlers, activitySvc).RegisterMux(mux)
+	moderationHandlers.NewHandlers(defaultHandlers, moderationSvc).RegisterMux(mux)
+	reportsHandlers.NewHandlers(defaultHandlers, reportsSvc, postsSvc).RegisterMux(mux)
+	categoriesHandlers.NewHandlers(defaultHandlers, categoriesSvc).RegisterMux(mux)
+	tokensHandlers.NewHandlers(defaultHandlers, tokensSvc).RegisterMux(mux)
+	searchHandlers.NewHandlers(defaultHandlers, searchSvc).RegisterMux(mux)
+	tagsHandlers.NewHandlers(defaultHandlers, tagsSvc).RegisterMux(mux)
+	profilesHandlers.NewHandlers(defaultHandlers, profilesSvc).RegisterMux(mux)
+	digestsHandlers.NewHandlers(defaultHandlers, digestsSvc).RegisterMux(mux)
+
+	apiExceptions := exception.NewJSONExceptions(errorLog)
+	apiAuthMid := authMiddleware.NewMiddleware(usersSvc, deps.sesManager, apiExceptions, authMiddleware.WithRateLimiter(limiter), authMiddleware.WithTokens(tokensSvc))
+	apiMiddleware := dynamic.NewMiddleware(apiAuthMid, deps.sesManager, apiExceptions)
+	apiHandlers := handler.NewHandlers(apiMiddleware, deps.sesManager, apiExceptions, tmplRender)
+
+	api.NewHandlers(
+		apiHandlers,
+		apiExceptions,
+		postsSvc,
+		commentsSvc,
+		postReactionsSvc,
+		commentReactionsSvc,
+		categoriesSvc,
+		filtersSvc,
+		notificationsSvc,
+		activitySvc,
+		searchSvc,
+		tagsSvc,
+		conf.PostImagesDir,
+	).RegisterMux(mux)
+
+	fileServer := http.FileServer(http.Dir(conf.UI.CSSDir))
+	mux.Handle("/static/", http.StripPrefix("/static/", fileServer))
+	mux.Handle("/images/", http.StripPrefix("/images/", http.FileServer(http.Dir(conf.PostImagesDir))))
+
+	return standard.NewMiddleware(exceptionHandlers, infoLog).Chain(mux), digestsSvc
+}
+
+// storage picks the option backing a service with the database deps is
+// connected to.
+func storage[O any](deps *Dependencies, withSqlite, withPostgres func(*sql.DB) O) O {
+	if deps.postgres != nil {
+		return withPostgres(deps.postgres)
+	}
+
+	return withSqlite(deps.sqlite)
+}
@@ -0,0 +1,1128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itelman/forum/pkg/mailer"
+	"github.com/itelman/forum/pkg/sesm"
+	"github.com/itelman/forum/pkg/sqlite"
+)
+
+const testPassword = "secret1"
+
+type testServer struct {
+	*httptest.Server
+	db     *sql.DB
+	client *http.Client
+	users  map[string]*testUser
+}
+
+type testUser struct {
+	id       int
+	username string
+	session  *http.Cookie
+	requests int
+}
+
+type testResponse struct {
+	status int
+	header http.Header
+	body   string
+}
+
+// newTestServer serves the same handler graph as main against a temporary
+// database and image directory, using the real migrations and templates.
+func newTestServer(t *testing.T, opts ...func(*Config)) *testServer {
+	t.Helper()
+
+	conf := &Config{ApiHost: "http://localhost"}
+	conf.Sqlite.DbDir = filepath.Join(t.TempDir(), "forum.db") + "?parseTime=true&_foreign_keys=on"
+	conf.Sqlite.MigrDir = "../migrations/sqlite/"
+	conf.UI.TmplDir = "../ui/html/"
+	conf.UI.CSSDir = "../ui/static/"
+	conf.PostImagesDir = t.TempDir()
+	conf.Comments.MaxDepth = 3
+	conf.Sessions.Lifetime = time.Hour
+	for _, opt := range opts {
+		opt(conf)
+	}
+
+	deps, err := NewDependencies(
+		WithSqlite(conf.Sqlite.DbDir, conf.Sqlite.MigrDir),
+		WithGithubAuth("secret", "github-client", conf.ApiHost),
+		WithGoogleAuth("secret", "google-client", conf.ApiHost),
+		WithTemplateCache(conf.UI.TmplDir),
+		WithSqliteSessions(conf.Sessions.Lifetime),
+		func(d *Dependencies) error {
+			d.mailer = mailer.NewWriterMailer(io.Discard, "forum@localhost")
+			return nil
+		},
+	)
+	if errors.Is(err, sqlite.ErrNoFTS5) || errors.Is(err, sqlite.ErrNoMathFunctions) {
+		t.Skipf("%v; run make test", err)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(deps.Close)
+
+	discard := log.New(io.Discard, "", 0)
+	handler, _ := newRouter(conf, deps, discard, discard)
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return &testServer{
+		Server: srv,
+		db:     deps.sqlite,
+		client: &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}},
+		users: make(map[string]*testUser),
+	}
+}
+
+func (ts *testServer) send(t *testing.T, req *http.Request, user *testUser) *testResponse {
+	t.Helper()
+
+	if user != nil {
+		req.AddCookie(user.session)
+		user.requests++
+	}
+
+	resp, err := ts.client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testResponse{resp.StatusCode, resp.Header, string(body)}
+}
+
+// do sends form url-encoded when it is not nil.
+func (ts *testServer) do(t *testing.T, method, path string, user *testUser, form url.Values) *testResponse {
+	t.Helper()
+
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequest(method, ts.URL+path, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	return ts.send(t, req, user)
+}
+
+// doMultipart attaches a tiny file as "image" when image is not empty.
+func (ts *testServer) doMultipart(t *testing.T, path string, user *testUser, fields url.Values, image string) *testResponse {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for key, values := range fields {
+		for _, value := range values {
+			if err := w.WriteField(key, value); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if len(image) != 0 {
+		part, err := w.CreateFormFile("image", image)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := part.Write([]byte("img")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL+path, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	return ts.send(t, req, user)
+}
+
+func (ts *testServer) signup(t *testing.T, username string) {
+	t.Helper()
+
+	resp := ts.do(t, http.MethodPost, "/user/signup", nil, url.Values{
+		"username": {username},
+		"email":    {username + "@example.com"},
+		"password": {testPassword},
+	})
+	resp.assert(t, http.StatusSeeOther, "/user/login")
+}
+
+// as returns a logged in session for username, shared between calls. The auth
+// middleware blocks a session that makes more than a few requests in a burst,
+// so a new one is opened before that happens.
+func (ts *testServer) as(t *testing.T, username string) *testUser {
+	t.Helper()
+
+	if user, ok := ts.users[username]; ok && user.requests < 8 {
+		return user
+	}
+
+	ts.users[username] = ts.login(t, username)
+	return ts.users[username]
+}
+
+// login opens a new session for username.
+func (ts *testServer) login(t *testing.T, username string) *testUser {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/user/login", strings.NewReader(url.Values{
+		"username": {username},
+		"password": {testPassword},
+	}.Encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := ts.client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	user := &testUser{username: username}
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == sesm.SessionId {
+			user.session = cookie
+		}
+	}
+	if resp.StatusCode != http.StatusSeeOther || user.session == nil {
+		t.Fatalf("login %s: got status %d and no session cookie", username, resp.StatusCode)
+	}
+
+	if err := ts.db.QueryRow("SELECT id FROM users WHERE username = ?", username).Scan(&user.id); err != nil {
+		t.Fatal(err)
+	}
+
+	return user
+}
+
+func (ts *testServer) setRole(t *testing.T, username, role string) {
+	t.Helper()
+
+	if _, err := ts.db.Exec("INSERT INTO user_roles (user_id, role_id) SELECT users.id, roles.id FROM users, roles WHERE users.username = ? AND roles.name = ?", username, role); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (ts *testServer) createPost(t *testing.T, user *testUser, title, tags string) int {
+	t.Helper()
+
+	resp := ts.doMultipart(t, "/user/posts/create", user, url.Values{
+		"title":         {title},
+		"content":       {"Content of " + title},
+		"categories_id": {"1"},
+		"tags":          {tags},
+	}, "")
+	if resp.status != http.StatusSeeOther {
+		t.Fatalf("create post: got status %d", resp.status)
+	}
+
+	postId, err := strconv.Atoi(strings.TrimPrefix(resp.header.Get("Location"), "/posts?id="))
+	if err != nil {
+		t.Fatalf("create post: got location %q", resp.header.Get("Location"))
+	}
+
+	return postId
+}
+
+func (ts *testServer) comment(t *testing.T, user *testUser, postId int, content string) int {
+	t.Helper()
+
+	resp := ts.do(t, http.MethodPost, "/user/posts/comments/create", user, url.Values{
+		"post_id": {strconv.Itoa(postId)},
+		"content": {content},
+	})
+	resp.assert(t, http.StatusSeeOther, fmt.Sprintf("/posts?id=%d", postId))
+
+	return ts.lastId(t, "comments")
+}
+
+// react likes (isLike 1) or dislikes (isLike 0) a post or, with
+// target "comment", a comment.
+func (ts *testServer) react(t *testing.T, user *testUser, target string, id, isLike int) {
+	t.Helper()
+
+	path := "/user/posts/react"
+	if target == "comment" {
+		path = "/user/posts/comments/react"
+	}
+
+	resp := ts.do(t, http.MethodPost, path, user, url.Values{
+		target + "_id": {strconv.Itoa(id)},
+		"is_like":      {strconv.Itoa(isLike)},
+	})
+	if resp.status != http.StatusSeeOther {
+		t.Fatalf("react to %s %d: got status %d", target, id, resp.status)
+	}
+}
+
+func (ts *testServer) lastId(t *testing.T, table string) int {
+	t.Helper()
+
+	var id int
+	if err := ts.db.QueryRow(fmt.Sprintf("SELECT MAX(id) FROM %s", table)).Scan(&id); err != nil {
+		t.Fatal(err)
+	}
+
+	return id
+}
+
+// assert checks the status and, for redirects, the target. Any further
+// arguments must appear in the body.
+func (r *testResponse) assert(t *testing.T, status int, location string, contains ...string) {
+	t.Helper()
+
+	if r.status != status {
+		t.Fatalf("got status %d, want %d", r.status, status)
+	}
+	if got := r.header.Get("Location"); got != location {
+		t.Errorf("got location %q, want %q", got, location)
+	}
+
+	for _, s := range contains {
+		if !strings.Contains(r.body, s) {
+			t.Errorf("body does not contain %q", s)
+		}
+	}
+}
+
+// seed creates alice, bobby, a moderator and an admin, and a post by alice
+// with a comment by bobby.
+func seed(t *testing.T, ts *testServer) (postId, commentId int) {
+	t.Helper()
+
+	for _, username := range []string{"alice", "bobby", "morgan", "adams"} {
+		ts.signup(t, username)
+	}
+	ts.setRole(t, "morgan", "moderator")
+	ts.setRole(t, "adams", "admin")
+
+	postId = ts.createPost(t, ts.as(t, "alice"), "Alice writes", "golang")
+	commentId = ts.comment(t, ts.as(t, "bobby"), postId, "Bobby answers")
+
+	return postId, commentId
+}
+
+func TestRoutes(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t)
+	postId, commentId := seed(t, ts)
+
+	post := fmt.Sprintf("?id=%d", postId)
+	comment := fmt.Sprintf("?id=%d", commentId)
+	const login = "/user/login"
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		as           string
+		wantStatus   int
+		wantLocation string
+		wantBody     []string
+	}{
+		// home
+		{"home", "GET", "/", "", 200, "", []string{"Alice writes"}},
+		{"results", "GET", "/results?category_id=1", "", 200, "", []string{"Alice writes"}},
+		{"results by form", "POST", "/results?category_id=2", "", 200, "", nil},
+		{"results without filters", "GET", "/results", "", 303, "/", nil},
+		{"results created anonymously", "GET", "/results?created=1", "", 302, login, nil},
+		{"results created", "GET", "/results?created=1", "alice", 200, "", []string{"Alice writes"}},
+		{"results bad category", "GET", "/results?category_id=x", "", 400, "", nil},
+		{"health", "GET", "/health", "", 200, "", []string{`"status":"available"`}},
+
+		// users
+		{"signup page", "GET", "/user/signup", "", 200, "", nil},
+		{"signup logged in", "GET", "/user/signup", "alice", 403, "", nil},
+		{"signup taken", "POST", "/user/signup?username=alice", "", 200, "", nil},
+		{"login page", "GET", login, "", 200, "", nil},
+		{"login logged in", "GET", login, "alice", 403, "", nil},
+		{"logout anonymously", "POST", "/user/logout", "", 302, login, nil},
+		{"sessions anonymously", "GET", "/user/sessions", "", 302, login, nil},
+		{"sessions", "GET", "/user/sessions", "alice", 200, "", nil},
+		{"revoke session without key", "GET", "/user/sessions/revoke", "alice", 400, "", nil},
+		{"revoke unknown session", "GET", "/user/sessions/revoke?key=nope", "alice", 404, "", nil},
+		{"revoke all anonymously", "POST", "/user/sessions/revoke-all", "", 302, login, nil},
+
+		// oauth
+		{"github logged in", "GET", "/user/login/github", "alice", 403, "", nil},
+		{"github callback without code", "GET", "/user/login/github/callback", "", 302, login, nil},
+		{"google logged in", "GET", "/user/login/google", "alice", 403, "", nil},
+		{"google callback without code", "GET", "/user/login/google/callback", "", 302, login, nil},
+
+		// posts
+		{"post", "GET", "/posts" + post, "", 200, "", []string{"Alice writes", "Content of Alice writes", "Bobby answers"}},
+		{"post missing", "GET", "/posts?id=999", "", 404, "", nil},
+		{"post bad id", "GET", "/posts?id=x", "", 400, "", nil},
+		{"create post anonymously", "GET", "/user/posts/create", "", 302, login, nil},
+		{"create post page", "GET", "/user/posts/create", "alice", 200, "", nil},
+		{"edit post anonymously", "GET", "/user/posts/edit" + post, "", 302, login, nil},
+		{"edit post by owner", "GET", "/user/posts/edit" + post, "alice", 200, "", []string{"Alice writes"}},
+		{"edit post by other", "GET", "/user/posts/edit" + post, "bobby", 403, "", nil},
+		{"edit post by moderator", "GET", "/user/posts/edit" + post, "morgan", 403, "", nil},
+		{"edit missing post", "GET", "/user/posts/edit?id=999", "alice", 404, "", nil},
+		{"edit post bad id", "GET", "/user/posts/edit?id=x", "alice", 400, "", nil},
+		{"delete post by other", "GET", "/user/posts/delete" + post, "bobby", 403, "", nil},
+		{"delete missing post", "GET", "/user/posts/delete?id=999", "alice", 404, "", nil},
+		{"pending posts anonymously", "GET", "/user/moderator/posts/pending", "", 302, login, nil},
+		{"pending posts by user", "GET", "/user/moderator/posts/pending", "alice", 403, "", nil},
+		{"pending posts", "GET", "/user/moderator/posts/pending", "morgan", 200, "", nil},
+		{"pending posts by admin", "GET", "/user/moderator/posts/pending", "adams", 200, "", nil},
+		{"approve by user", "GET", "/user/moderator/posts/approve" + post, "alice", 403, "", nil},
+		{"approve missing", "GET", "/user/moderator/posts/approve?id=999", "morgan", 404, "", nil},
+		{"delete pending by user", "GET", "/user/moderator/posts/delete" + post, "alice", 403, "", nil},
+		{"delete missing pending", "GET", "/user/moderator/posts/delete?id=999", "morgan", 404, "", nil},
+
+		// comments
+		{"comment anonymously", "POST", "/user/posts/comments/create", "", 302, login, nil},
+		{"comment bad post", "POST", "/user/posts/comments/create?post_id=x", "alice", 400, "", nil},
+		{"edit comment by owner", "GET", "/user/posts/comments/edit" + comment, "bobby", 200, "", []string{"Bobby answers"}},
+		{"edit comment by other", "GET", "/user/posts/comments/edit" + comment, "alice", 403, "", nil},
+		{"edit missing comment", "GET", "/user/posts/comments/edit?id=999", "bobby", 404, "", nil},
+		{"edit comment bad id", "GET", "/user/posts/comments/edit?id=x", "bobby", 400, "", nil},
+		{"delete comment by other", "GET", "/user/posts/comments/delete" + comment, "alice", 403, "", nil},
+		{"delete comment anonymously", "GET", "/user/posts/comments/delete" + comment, "", 302, login, nil},
+
+		// reactions
+		{"react to post anonymously", "POST", "/user/posts/react", "", 302, login, nil},
+		{"react to post badly", "POST", "/user/posts/react?post_id=1&is_like=2", "alice", 400, "", nil},
+		{"react to comment anonymously", "POST", "/user/posts/comments/react", "", 302, login, nil},
+		{"react to comment badly", "POST", "/user/posts/comments/react?comment_id=x&is_like=1", "alice", 400, "", nil},
+
+		// notifications
+		{"notifications anonymously", "GET", "/user/notifications", "", 302, login, nil},
+		{"notifications", "GET", "/user/notifications", "alice", 301, "/user/notifications/comments", nil},
+		{"comment notifications", "GET", "/user/notifications/comments", "alice", 200, "", []string{"bobby", "left a comment on your post."}},
+		{"reaction notifications", "GET", "/user/notifications/reactions", "alice", 200, "", nil},
+		{"open missing notification", "GET", "/user/notifications/open?id=999", "alice", 404, "", nil},
+		{"open notification bad id", "GET", "/user/notifications/open?id=x", "alice", 400, "", nil},
+		{"read missing notification", "POST", "/user/notifications/read?id=999", "alice", 404, "", nil},
+		{"read all notifications", "POST", "/user/notifications/read-all", "bobby", 303, "/user/notifications/comments", nil},
+		{"delete missing notification", "POST", "/user/notifications/delete?id=999", "alice", 404, "", nil},
+		{"notification preferences", "GET", "/user/notifications/preferences", "alice", 200, "", nil},
+
+		// events
+		{"events anonymously", "GET", "/user/events", "", 302, login, nil},
+		{"events for missing post", "GET", "/user/events?post_id=999", "alice", 404, "", nil},
+		{"events for bad post", "GET", "/user/events?post_id=x", "alice", 400, "", nil},
+
+		// activity
+		{"created anonymously", "GET", "/user/activity/created", "", 302, login, nil},
+		{"created", "GET", "/user/activity/created", "alice", 200, "", []string{"Alice writes"}},
+		{"reacted", "GET", "/user/activity/reacted", "alice", 200, "", nil},
+		{"commented", "GET", "/user/activity/commented", "bobby", 200, "", []string{"Alice writes", "Bobby answers"}},
+
+		// moderation
+		{"request moderator anonymously", "POST", "/user/request-mod", "", 302, login, nil},
+		{"moderator requests anonymously", "GET", "/user/admin/moderators", "", 302, login, nil},
+		{"moderator requests by moderator", "GET", "/user/admin/moderators", "morgan", 403, "", nil},
+		{"moderator requests", "GET", "/user/admin/moderators", "adams", 200, "", nil},
+		{"approve missing request", "GET", "/user/admin/moderators/approve?id=999", "adams", 404, "", nil},
+		{"approve request by user", "GET", "/user/admin/moderators/approve?id=1", "alice", 403, "", nil},
+		{"decline request bad id", "GET", "/user/admin/moderators/decline?id=x", "adams", 400, "", nil},
+		{"decline request by moderator", "GET", "/user/admin/moderators/decline?id=1", "morgan", 403, "", nil},
+
+		// reports
+		{"moderator reports by user", "GET", "/user/moderator/reports", "alice", 403, "", nil},
+		{"moderator reports", "GET", "/user/moderator/reports", "morgan", 200, "", nil},
+		{"report page", "GET", "/user/moderator/posts/report" + post, "morgan", 200, "", []string{"Alice writes"}},
+		{"report page by admin", "GET", "/user/moderator/posts/report" + post, "adams", 403, "", nil},
+		{"report missing post", "GET", "/user/moderator/posts/report?id=999", "morgan", 404, "", nil},
+		{"admin reports by moderator", "GET", "/user/admin/reports", "morgan", 403, "", nil},
+		{"admin reports", "GET", "/user/admin/reports", "adams", 200, "", nil},
+		{"reply to report bad id", "POST", "/user/admin/reports/reply?report_id=x", "adams", 400, "", nil},
+		{"reply to report by moderator", "POST", "/user/admin/reports/reply", "morgan", 403, "", nil},
+
+		// categories
+		{"categories anonymously", "GET", "/user/admin/categories", "", 302, login, nil},
+		{"categories by moderator", "GET", "/user/admin/categories", "morgan", 403, "", nil},
+		{"categories", "GET", "/user/admin/categories", "adams", 200, "", []string{"Music", "Programming"}},
+		{"add category by user", "POST", "/user/admin/categories/add", "alice", 403, "", nil},
+		{"delete category by user", "GET", "/user/admin/categories/delete?id=1", "alice", 403, "", nil},
+		{"delete missing category", "GET", "/user/admin/categories/delete?id=999", "adams", 404, "", nil},
+		{"delete category bad id", "GET", "/user/admin/categories/delete?id=x", "adams", 400, "", nil},
+
+		// tokens
+		{"tokens anonymously", "GET", "/user/tokens", "", 302, login, nil},
+		{"tokens", "GET", "/user/tokens", "alice", 200, "", nil},
+		{"create token without name", "POST", "/user/tokens/create", "alice", 200, "", nil},
+		{"revoke missing token", "GET", "/user/tokens/revoke?id=999", "alice", 404, "", nil},
+		{"revoke token bad id", "GET", "/user/tokens/revoke?id=x", "alice", 400, "", nil},
+
+		// search, tags, profiles
+		{"search", "GET", "/search?q=Alice", "", 200, "", []string{"Post by alice"}},
+		{"search without query", "GET", "/search", "", 200, "", nil},
+		{"tag", "GET", "/tags?name=golang", "", 200, "", []string{"Alice writes"}},
+		{"missing tag", "GET", "/tags?name=rust", "", 404, "", nil},
+		{"profile", "GET", "/users?name=alice", "", 200, "", []string{"alice", "Alice writes"}},
+		{"missing profile", "GET", "/users?name=nobody", "", 404, "", nil},
+
+		// digests
+		{"digest anonymously", "GET", "/user/digest", "", 302, login, nil},
+		{"digest", "GET", "/user/digest", "alice", 200, "", nil},
+		{"digest bad frequency", "POST", "/user/digest?frequency=hourly", "alice", 200, "", nil},
+
+		// files
+		{"static", "GET", "/static/css/error.css", "", 200, "", nil},
+		{"missing image", "GET", "/images/999/missing.png", "", 404, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var user *testUser
+			if len(tt.as) != 0 {
+				user = ts.as(t, tt.as)
+			}
+
+			// POST requests send their query as the form body too.
+			var form url.Values
+			if tt.method == http.MethodPost {
+				_, query, _ := strings.Cut(tt.path, "?")
+				form, _ = url.ParseQuery(query)
+			}
+
+			ts.do(t, tt.method, tt.path, user, form).assert(t, tt.wantStatus, tt.wantLocation, tt.wantBody...)
+		})
+	}
+}
+
+func TestOAuthLogin(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/user/login/github", "https://github.com/login/oauth/authorize?client_id=github-client&"},
+		{"/user/login/google", "https://accounts.google.com/o/oauth2/v2/auth?client_id=google-client&"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp := ts.do(t, http.MethodGet, tt.path, nil, nil)
+			if got := resp.header.Get("Location"); resp.status != http.StatusFound || !strings.HasPrefix(got, tt.want) {
+				t.Errorf("got status %d and location %q, want a redirect to %s...", resp.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t)
+
+	tests := []struct {
+		method    string
+		path      string
+		wantAllow string
+	}{
+		{"POST", "/", "GET"},
+		{"PUT", "/results", "GET"},
+		{"POST", "/health", "GET"},
+		{"DELETE", "/user/signup", "GET"},
+		{"PUT", "/user/login", "GET"},
+		{"GET", "/user/logout", "POST"},
+		{"POST", "/user/sessions", "GET"},
+		{"POST", "/user/sessions/revoke", "GET"},
+		{"GET", "/user/sessions/revoke-all", "POST"},
+		{"POST", "/user/login/github", "GET"},
+		{"POST", "/user/login/github/callback", "GET"},
+		{"POST", "/user/login/google", "GET"},
+		{"POST", "/user/login/google/callback", "GET"},
+		{"POST", "/posts", "GET"},
+		{"PUT", "/user/posts/create", "GET"},
+		{"PUT", "/user/posts/edit", "GET"},
+		{"POST", "/user/posts/delete", "GET"},
+		{"POST", "/user/moderator/posts/pending", "GET"},
+		{"POST", "/user/moderator/posts/approve", "GET"},
+		{"POST", "/user/moderator/posts/delete", "GET"},
+		{"GET", "/user/posts/comments/create", "POST"},
+		{"PUT", "/user/posts/comments/edit", "GET"},
+		{"POST", "/user/posts/comments/delete", "GET"},
+		{"GET", "/user/posts/react", "POST"},
+		{"GET", "/user/posts/comments/react", "POST"},
+		{"PUT", "/user/notifications", "GET"},
+		{"POST", "/user/notifications/comments", "GET"},
+		{"POST", "/user/notifications/reactions", "GET"},
+		{"POST", "/user/notifications/open", "GET"},
+		{"GET", "/user/notifications/read", "POST"},
+		{"GET", "/user/notifications/read-all", "POST"},
+		{"GET", "/user/notifications/delete", "POST"},
+		{"PUT", "/user/notifications/preferences", "GET"},
+		{"POST", "/user/events", "GET"},
+		{"POST", "/user/activity/created", "GET"},
+		{"POST", "/user/activity/reacted", "GET"},
+		{"POST", "/user/activity/commented", "GET"},
+		{"GET", "/user/request-mod", "POST"},
+		{"POST", "/user/admin/moderators", "GET"},
+		{"POST", "/user/admin/moderators/approve", "GET"},
+		{"POST", "/user/admin/moderators/decline", "GET"},
+		{"POST", "/user/moderator/reports", "GET"},
+		{"PUT", "/user/moderator/posts/report", "GET"},
+		{"POST", "/user/admin/reports", "GET"},
+		{"GET", "/user/admin/reports/reply", "POST"},
+		{"POST", "/user/admin/categories", "GET"},
+		{"GET", "/user/admin/categories/add", "POST"},
+		{"POST", "/user/admin/categories/delete", "GET"},
+		{"POST", "/user/tokens", "GET"},
+		{"GET", "/user/tokens/create", "POST"},
+		{"POST", "/user/tokens/revoke", "GET"},
+		{"POST", "/search", "GET"},
+		{"POST", "/tags", "GET"},
+		{"POST", "/users", "GET"},
+		{"PUT", "/user/digest", "GET"},
+		{"POST", "/api/v1/posts", "GET"},
+		{"POST", "/api/v1/posts/show", "GET"},
+		{"PUT", "/api/v1/posts/filter", "GET"},
+		{"POST", "/api/v1/categories", "GET"},
+		{"POST", "/api/v1/search", "GET"},
+		{"POST", "/api/v1/tags", "GET"},
+		{"POST", "/api/v1/tags/autocomplete", "GET"},
+		{"POST", "/api/v1/tags/trending", "GET"},
+		{"GET", "/api/v1/posts/create", "POST"},
+		{"GET", "/api/v1/posts/react", "POST"},
+		{"GET", "/api/v1/posts/edit", "POST"},
+		{"GET", "/api/v1/posts/delete", "POST"},
+		{"GET", "/api/v1/comments/create", "POST"},
+		{"GET", "/api/v1/comments/react", "POST"},
+		{"GET", "/api/v1/comments/edit", "POST"},
+		{"GET", "/api/v1/comments/delete", "POST"},
+		{"POST", "/api/v1/notifications", "GET"},
+		{"POST", "/api/v1/notifications/comments", "GET"},
+		{"POST", "/api/v1/notifications/reactions", "GET"},
+		{"GET", "/api/v1/notifications/read", "POST"},
+		{"GET", "/api/v1/notifications/read-all", "POST"},
+		{"GET", "/api/v1/notifications/delete", "POST"},
+		{"PUT", "/api/v1/notifications/preferences", "GET"},
+		{"POST", "/api/v1/activity/created", "GET"},
+		{"POST", "/api/v1/activity/reacted", "GET"},
+		{"POST", "/api/v1/activity/commented", "GET"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			resp := ts.do(t, tt.method, tt.path, nil, nil)
+
+			resp.assert(t, http.StatusMethodNotAllowed, "")
+			if got := resp.header.Get("Allow"); got != tt.wantAllow {
+				t.Errorf("got Allow %q, want %q", got, tt.wantAllow)
+			}
+		})
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t)
+
+	tests := []struct {
+		path     string
+		wantBody string
+	}{
+		{"/nowhere", "Error 404"},
+		{"/posts/", "Error 404"},
+		{"/user/posts/create/", "Error 404"},
+		{"/api/v1/nowhere", `"error"`},
+		{"/api/v1/posts/", `"error"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			ts.do(t, http.MethodGet, tt.path, nil, nil).assert(t, http.StatusNotFound, "", tt.wantBody)
+		})
+	}
+}
+
+func TestPostLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t)
+	ts.signup(t, "alice")
+	ts.signup(t, "bobby")
+
+	alice := ts.login(t, "alice")
+	resp := ts.doMultipart(t, "/user/posts/create", alice, url.Values{
+		"title":         {"With a picture"},
+		"content":       {"Look at this"},
+		"categories_id": {"1", "5"},
+		"tags":          {"photo"},
+	}, "picture.png")
+	resp.assert(t, http.StatusSeeOther, "/posts?id=1")
+
+	image := "/images/1/picture.png"
+	ts.do(t, http.MethodGet, "/posts?id=1", nil, nil).assert(t, http.StatusOK, "", "With a picture", "Look at this", image, "Music", "Programming", "photo")
+	ts.do(t, http.MethodGet, image, nil, nil).assert(t, http.StatusOK, "", "img")
+
+	ts.do(t, http.MethodPost, "/user/posts/create", alice, url.Values{"title": {"No"}}).assert(t, http.StatusBadRequest, "")
+	ts.doMultipart(t, "/user/posts/create", alice, url.Values{"title": {"No"}}, "").assert(t, http.StatusOK, "", "create")
+
+	ts.do(t, http.MethodPost, "/user/posts/edit?id=1", alice, url.Values{"title": {"Edited title"}, "content": {"Edited content"}}).assert(t, http.StatusSeeOther, "/posts?id=1")
+	ts.do(t, http.MethodPost, "/user/posts/edit?id=1", ts.as(t, "bobby"), url.Values{"title": {"Hijacked"}, "content": {"Hijacked"}}).assert(t, http.StatusForbidden, "")
+	ts.do(t, http.MethodGet, "/posts?id=1", nil, nil).assert(t, http.StatusOK, "", "Edited title", "Edited content", "photo")
+
+	bobby := ts.login(t, "bobby")
+	ts.react(t, bobby, "post", 1, 1)
+	commentId := ts.comment(t, bobby, 1, "First!")
+	ts.do(t, http.MethodPost, "/user/posts/comments/create", bobby, url.Values{"post_id": {"1"}, "content": {" "}}).assert(t, http.StatusSeeOther, "/posts?id=1")
+	ts.do(t, http.MethodPost, "/user/posts/comments/create", bobby, url.Values{"post_id": {"999"}, "content": {"Lost"}}).assert(t, http.StatusSeeOther, "/posts?id=999")
+
+	alice = ts.login(t, "alice")
+	ts.do(t, http.MethodPost, "/user/posts/comments/create", alice, url.Values{
+		"post_id":   {"1"},
+		"parent_id": {strconv.Itoa(commentId)},
+		"content":   {"Thanks @bobby"},
+	}).assert(t, http.StatusSeeOther, "/posts?id=1")
+	ts.react(t, alice, "comment", commentId, 0)
+	ts.do(t, http.MethodGet, "/posts?id=1", alice, nil).assert(t, http.StatusOK, "", "First!", "Thanks", `href="/users?name=bobby"`)
+	ts.do(t, http.MethodGet, "/user/notifications/reactions", alice, nil).assert(t, http.StatusOK, "", "liked your post.")
+	ts.do(t, http.MethodGet, "/user/activity/reacted", bobby, nil).assert(t, http.StatusOK, "", "Edited title")
+
+	bobby = ts.login(t, "bobby")
+	ts.do(t, http.MethodGet, "/user/notifications/comments", bobby, nil).assert(t, http.StatusOK, "", "replied to your comment.")
+	ts.do(t, http.MethodGet, "/user/notifications/reactions", bobby, nil).assert(t, http.StatusOK, "", "disliked your comment.")
+
+	path := fmt.Sprintf("/user/posts/comments/edit?id=%d", commentId)
+	ts.do(t, http.MethodPost, path, alice, url.Values{"content": {"Hijacked"}}).assert(t, http.StatusForbidden, "")
+	ts.do(t, http.MethodPost, path, bobby, url.Values{"content": {"Edited comment"}}).assert(t, http.StatusSeeOther, "/posts?id=1")
+	ts.do(t, http.MethodGet, "/posts?id=1", nil, nil).assert(t, http.StatusOK, "", "Edited comment")
+
+	path = fmt.Sprintf("/user/posts/comments/delete?id=%d", commentId)
+	ts.do(t, http.MethodGet, path, bobby, nil).assert(t, http.StatusSeeOther, "/posts?id=1")
+	ts.do(t, http.MethodGet, path, bobby, nil).assert(t, http.StatusNotFound, "")
+
+	alice = ts.login(t, "alice")
+	ts.do(t, http.MethodGet, "/user/posts/delete?id=1", alice, nil).assert(t, http.StatusSeeOther, "/")
+	ts.do(t, http.MethodGet, "/posts?id=1", nil, nil).assert(t, http.StatusNotFound, "")
+	ts.do(t, http.MethodGet, image, nil, nil).assert(t, http.StatusNotFound, "")
+	ts.do(t, http.MethodGet, "/", alice, nil).assert(t, http.StatusOK, "", "Post successfully removed!")
+}
+
+func TestPostApproval(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t, func(conf *Config) {
+		conf.Moderation.PostsApproval = true
+	})
+	for _, username := range []string{"alice", "bobby", "morgan", "adams"} {
+		ts.signup(t, username)
+	}
+	ts.setRole(t, "morgan", "moderator")
+	ts.setRole(t, "adams", "admin")
+
+	alice := ts.login(t, "alice")
+	first := ts.createPost(t, alice, "Waiting for approval", "")
+	second := ts.createPost(t, alice, "Never approved", "")
+	third := ts.createPost(t, alice, "Approved by an admin", "")
+	ts.do(t, http.MethodGet, fmt.Sprintf("/posts?id=%d", first), alice, nil).assert(t, http.StatusOK, "", "will be published once a moderator approves it")
+	ts.do(t, http.MethodGet, fmt.Sprintf("/posts?id=%d", first), nil, nil).assert(t, http.StatusNotFound, "")
+	ts.do(t, http.MethodGet, "/", nil, nil).assert(t, http.StatusOK, "")
+
+	morgan := ts.login(t, "morgan")
+	ts.do(t, http.MethodGet, "/user/moderator/posts/pending", morgan, nil).assert(t, http.StatusOK, "", "Waiting for approval", "Never approved")
+	ts.do(t, http.MethodGet, fmt.Sprintf("/user/moderator/posts/approve?id=%d", first), morgan, nil).assert(t, http.StatusSeeOther, "/user/moderator/posts/pending")
+	ts.do(t, http.MethodGet, fmt.Sprintf("/user/moderator/posts/delete?id=%d", second), morgan, nil).assert(t, http.StatusSeeOther, "/user/moderator/posts/pending")
+	ts.do(t, http.MethodGet, fmt.Sprintf("/user/moderator/posts/approve?id=%d", first), morgan, nil).assert(t, http.StatusNotFound, "")
+
+	ts.do(t, http.MethodGet, fmt.Sprintf("/posts?id=%d", first), nil, nil).assert(t, http.StatusOK, "", "Waiting for approval")
+	ts.do(t, http.MethodGet, fmt.Sprintf("/posts?id=%d", second), morgan, nil).assert(t, http.StatusNotFound, "")
+
+	bobby := ts.login(t, "bobby")
+	ts.do(t, http.MethodPost, "/user/posts/comments/create", bobby, url.Values{"post_id": {strconv.Itoa(third)}, "content": {"Sneaky"}}).assert(t, http.StatusNotFound, "")
+	ts.do(t, http.MethodPost, "/user/posts/react", bobby, url.Values{"post_id": {strconv.Itoa(third)}, "is_like": {"1"}}).assert(t, http.StatusNotFound, "")
+	ts.do(t, http.MethodGet, "/user/moderator/posts/pending", bobby, nil).assert(t, http.StatusForbidden, "")
+
+	adams := ts.login(t, "adams")
+	ts.do(t, http.MethodGet, "/user/moderator/posts/pending", adams, nil).assert(t, http.StatusOK, "", "Approved by an admin")
+	ts.do(t, http.MethodGet, fmt.Sprintf("/user/moderator/posts/approve?id=%d", third), adams, nil).assert(t, http.StatusSeeOther, "/user/moderator/posts/pending")
+	ts.do(t, http.MethodGet, fmt.Sprintf("/posts?id=%d", third), nil, nil).assert(t, http.StatusOK, "", "Approved by an admin")
+	ts.comment(t, bobby, third, "Now it is public")
+}
+
+func TestModeratorRequests(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t)
+	for _, username := range []string{"alice", "bobby", "adams"} {
+		ts.signup(t, username)
+	}
+	ts.setRole(t, "adams", "admin")
+
+	alice := ts.login(t, "alice")
+	ts.do(t, http.MethodPost, "/user/request-mod", alice, url.Values{}).assert(t, http.StatusSeeOther, "/")
+	ts.do(t, http.MethodGet, "/", alice, nil).assert(t, http.StatusOK, "", "Your request has been sent to the administrators.")
+	ts.do(t, http.MethodPost, "/user/request-mod", alice, url.Values{}).assert(t, http.StatusSeeOther, "/")
+	ts.do(t, http.MethodGet, "/", alice, nil).assert(t, http.StatusOK, "", "You have already applied for moderator.")
+	ts.do(t, http.MethodPost, "/user/request-mod", ts.as(t, "bobby"), url.Values{}).assert(t, http.StatusSeeOther, "/")
+
+	adams := ts.login(t, "adams")
+	ts.do(t, http.MethodPost, "/user/request-mod", adams, url.Values{}).assert(t, http.StatusSeeOther, "/")
+	ts.do(t, http.MethodGet, "/", adams, nil).assert(t, http.StatusOK, "", "You already have a role on this forum.")
+	ts.do(t, http.MethodGet, "/user/admin/moderators", adams, nil).assert(t, http.StatusOK, "", "alice", "bobby")
+	ts.do(t, http.MethodGet, "/user/admin/moderators/approve?id=1", adams, nil).assert(t, http.StatusSeeOther, "/user/admin/moderators")
+	ts.do(t, http.MethodGet, "/user/admin/moderators/decline?id=2", adams, nil).assert(t, http.StatusSeeOther, "/user/admin/moderators")
+	ts.do(t, http.MethodGet, "/user/admin/moderators/decline?id=2", adams, nil).assert(t, http.StatusNotFound, "")
+
+	ts.do(t, http.MethodGet, "/user/moderator/reports", ts.as(t, "alice"), nil).assert(t, http.StatusOK, "")
+	ts.do(t, http.MethodGet, "/user/moderator/reports", ts.as(t, "bobby"), nil).assert(t, http.StatusForbidden, "")
+}
+
+func TestReports(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t)
+	postId, _ := seed(t, ts)
+	report := fmt.Sprintf("/user/moderator/posts/report?id=%d", postId)
+
+	morgan := ts.login(t, "morgan")
+	ts.do(t, http.MethodPost, report, morgan, url.Values{"content": {""}}).assert(t, http.StatusOK, "", "Alice writes")
+	ts.do(t, http.MethodPost, report, morgan, url.Values{"content": {"Off topic"}}).assert(t, http.StatusSeeOther, "/user/moderator/reports")
+	ts.do(t, http.MethodGet, "/user/moderator/reports", morgan, nil).assert(t, http.StatusOK, "", "Report sent!", "Alice writes", "Off topic")
+	ts.do(t, http.MethodPost, report, morgan, url.Values{"content": {"Again"}}).assert(t, http.StatusSeeOther, fmt.Sprintf("/posts?id=%d", postId))
+
+	adams := ts.login(t, "adams")
+	ts.do(t, http.MethodGet, "/user/admin/reports", adams, nil).assert(t, http.StatusOK, "", "Off topic", "morgan")
+	ts.do(t, http.MethodPost, "/user/admin/reports/reply", adams, url.Values{"report_id": {"1"}, "admin_review": {"Agreed"}}).assert(t, http.StatusSeeOther, "/user/admin/reports")
+	ts.do(t, http.MethodGet, "/user/admin/reports", adams, nil).assert(t, http.StatusOK, "", "Review saved!", "Agreed")
+	ts.do(t, http.MethodPost, "/user/admin/reports/reply", adams, url.Values{"report_id": {"1"}, "admin_review": {"Changed my mind"}}).assert(t, http.StatusSeeOther, "/user/admin/reports")
+	ts.do(t, http.MethodGet, "/user/admin/reports", adams, nil).assert(t, http.StatusOK, "", "This report has already been reviewed.")
+	ts.do(t, http.MethodPost, "/user/admin/reports/reply", adams, url.Values{"report_id": {"999"}, "admin_review": {"Agreed"}}).assert(t, http.StatusNotFound, "")
+}
+
+func TestCategories(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t)
+	postId, _ := seed(t, ts)
+
+	adams := ts.login(t, "adams")
+	ts.do(t, http.MethodPost, "/user/admin/categories/add", adams, url.Values{"name": {"Movies"}}).assert(t, http.StatusSeeOther, "/user/admin/categories")
+	ts.do(t, http.MethodGet, "/user/admin/categories", adams, nil).assert(t, http.StatusOK, "", "Category successfully added!", "Movies")
+	ts.do(t, http.MethodPost, "/user/admin/categories/add", adams, url.Values{"name": {"Movies"}}).assert(t, http.StatusOK, "", "Movies")
+	ts.do(t, http.MethodGet, "/user/admin/categories/delete?id=6", adams, nil).assert(t, http.StatusSeeOther, "/user/admin/categories")
+
+	ts.do(t, http.MethodGet, "/user/admin/categories/delete?id=1&fallback_id=999", adams, nil).assert(t, http.StatusSeeOther, "/user/admin/categories")
+	ts.do(t, http.MethodGet, "/user/admin/categories", adams, nil).assert(t, http.StatusOK, "", "Please choose a different category to move the posts to.")
+	ts.do(t, http.MethodGet, "/user/admin/categories/delete?id=1&fallback_id=2", adams, nil).assert(t, http.StatusSeeOther, "/user/admin/categories")
+	ts.do(t, http.MethodGet, fmt.Sprintf("/posts?id=%d", postId), nil, nil).assert(t, http.StatusOK, "", "Books")
+}
+
+func TestSessions(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t)
+	ts.signup(t, "alice")
+
+	phone, laptop := ts.login(t, "alice"), ts.login(t, "alice")
+	ts.do(t, http.MethodGet, "/user/sessions", laptop, nil).assert(t, http.StatusOK, "", sesm.SessionKey(phone.session.Value), sesm.SessionKey(laptop.session.Value))
+
+	ts.do(t, http.MethodGet, "/user/sessions/revoke?key="+sesm.SessionKey(phone.session.Value), laptop, nil).assert(t, http.StatusSeeOther, "/user/sessions")
+	ts.do(t, http.MethodGet, "/user/sessions", laptop, nil).assert(t, http.StatusOK, "", "The device has been signed out.")
+	ts.do(t, http.MethodGet, "/user/sessions", phone, nil).assert(t, http.StatusFound, "/user/login")
+
+	ts.do(t, http.MethodGet, "/user/sessions/revoke?key="+sesm.SessionKey(laptop.session.Value), laptop, nil).assert(t, http.StatusSeeOther, "/user/login")
+	ts.do(t, http.MethodGet, "/user/sessions", laptop, nil).assert(t, http.StatusFound, "/user/login")
+
+	phone, laptop = ts.login(t, "alice"), ts.login(t, "alice")
+	ts.do(t, http.MethodPost, "/user/logout", phone, url.Values{}).assert(t, http.StatusSeeOther, "/user/login")
+	ts.do(t, http.MethodGet, "/user/sessions", phone, nil).assert(t, http.StatusFound, "/user/login")
+	ts.do(t, http.MethodGet, "/user/sessions", laptop, nil).assert(t, http.StatusOK, "")
+
+	tablet := ts.login(t, "alice")
+	ts.do(t, http.MethodPost, "/user/sessions/revoke-all", tablet, url.Values{}).assert(t, http.StatusSeeOther, "/user/login")
+	ts.do(t, http.MethodGet, "/user/sessions", laptop, nil).assert(t, http.StatusFound, "/user/login")
+	ts.do(t, http.MethodGet, "/user/sessions", tablet, nil).assert(t, http.StatusFound, "/user/login")
+}
+
+// A session has one request budget, whether it spends it on HTML pages or on
+// the JSON API.
+func TestRateLimitSharedWithAPI(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t)
+	ts.signup(t, "alice")
+	alice := ts.login(t, "alice")
+
+	limited := 0
+	for i := 0; i < 16; i++ {
+		path := "/user/notifications/comments"
+		if i%2 == 1 {
+			path = "/api/v1/notifications"
+		}
+
+		if ts.do(t, http.MethodGet, path, alice, nil).status == http.StatusTooManyRequests {
+			limited = i
+			break
+		}
+	}
+
+	if limited == 0 {
+		t.Fatal("16 requests alternating between HTML and API routes were never rate limited")
+	}
+
+	ts.do(t, http.MethodGet, "/user/notifications/comments", alice, nil).assert(t, http.StatusTooManyRequests, "")
+	ts.do(t, http.MethodGet, "/api/v1/notifications", alice, nil).assert(t, http.StatusTooManyRequests, "")
+}
+
+func TestNotifications(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t)
+	postId, commentId := seed(t, ts)
+	ts.react(t, ts.as(t, "bobby"), "post", postId, 0)
+
+	alice := ts.login(t, "alice")
+	ts.do(t, http.MethodGet, "/user/notifications/open?id=1", alice, nil).assert(t, http.StatusSeeOther, fmt.Sprintf("/posts?id=%d#comment-%d", postId, commentId))
+	ts.do(t, http.MethodGet, "/user/notifications/open?id=1", ts.as(t, "bobby"), nil).assert(t, http.StatusNotFound, "")
+	ts.do(t, http.MethodPost, "/user/notifications/read?id=2", alice, url.Values{"page": {"reactions"}}).assert(t, http.StatusSeeOther, "/user/notifications/reactions")
+	ts.do(t, http.MethodPost, "/user/notifications/delete?id=2", alice, url.Values{}).assert(t, http.StatusSeeOther, "/user/notifications/comments")
+	ts.do(t, http.MethodGet, "/user/notifications/reactions", alice, nil).assert(t, http.StatusOK, "")
+	ts.do(t, http.MethodPost, "/user/notifications/read-all", alice, url.Values{}).assert(t, http.StatusSeeOther, "/user/notifications/comments")
+
+	ts.do(t, http.MethodPost, "/user/notifications", alice, url.Values{"filter": {"2"}}).assert(t, http.StatusMovedPermanently, "/user/notifications/reactions")
+	ts.do(t, http.MethodPost, "/user/notifications/preferences", alice, url.Values{"in_app": {"reactions"}}).assert(t, http.StatusBadRequest, "")
+	ts.do(t, http.MethodPost, "/user/notifications/preferences", alice, url.Values{"in_app": {"comments"}}).assert(t, http.StatusSeeOther, "/user/notifications/preferences")
+	ts.do(t, http.MethodGet, "/user/notifications/preferences", alice, nil).assert(t, http.StatusOK, "", "Your notification preferences have been saved.")
+}
+
+func TestDigest(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t)
+	ts.signup(t, "alice")
+
+	alice := ts.login(t, "alice")
+	ts.do(t, http.MethodPost, "/user/digest", alice, url.Values{"frequency": {"weekly"}}).assert(t, http.StatusSeeOther, "/user/digest")
+	ts.do(t, http.MethodGet, "/user/digest", alice, nil).assert(t, http.StatusOK, "", "Your email digest settings have been saved.")
+}
+
+func TestEvents(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t)
+	postId, _ := seed(t, ts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/user/events?post_id=%d", ts.URL, postId), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.AddCookie(ts.as(t, "alice").session)
+
+	resp, err := ts.client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("got status %d and content type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	ts.comment(t, ts.as(t, "bobby"), postId, "Live comment")
+
+	buf := make([]byte, 4096)
+	var stream string
+	for !strings.Contains(stream, "Live comment") {
+		n, err := resp.Body.Read(buf)
+		if err != nil {
+			t.Fatalf("stream ended before the comment arrived: %v\n%s", err, stream)
+		}
+		stream += string(buf[:n])
+	}
+}
+
+func TestAPI(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t)
+	postId, commentId := seed(t, ts)
+
+	public := []struct {
+		path     string
+		wantBody string
+	}{
+		{"/api/v1/posts", "Alice writes"},
+		{fmt.Sprintf("/api/v1/posts/show?id=%d", postId), "Bobby answers"},
+		{"/api/v1/posts/filter?category_id=1", "Alice writes"},
+		{"/api/v1/categories", "Programming"},
+		{"/api/v1/search?q=Alice", "Alice writes"},
+		{"/api/v1/tags?name=golang", "Alice writes"},
+		{"/api/v1/tags/autocomplete?q=go", "golang"},
+		{"/api/v1/tags/trending", "golang"},
+	}
+
+	for _, tt := range public {
+		t.Run(tt.path, func(t *testing.T) {
+			ts.do(t, http.MethodGet, tt.path, nil, nil).assert(t, http.StatusOK, "", tt.wantBody)
+		})
+	}
+
+	private := []struct {
+		method string
+		path   string
+	}{
+		{"POST", "/api/v1/posts/create"},
+		{"POST", "/api/v1/posts/react"},
+		{"POST", "/api/v1/posts/edit"},
+		{"POST", "/api/v1/posts/delete"},
+		{"POST", "/api/v1/comments/create"},
+		{"POST", "/api/v1/comments/react"},
+		{"POST", "/api/v1/comments/edit"},
+		{"POST", "/api/v1/comments/delete"},
+		{"GET", "/api/v1/notifications"},
+		{"GET", "/api/v1/notifications/comments"},
+		{"GET", "/api/v1/notifications/reactions"},
+		{"POST", "/api/v1/notifications/read"},
+		{"POST", "/api/v1/notifications/read-all"},
+		{"POST", "/api/v1/notifications/delete"},
+		{"GET", "/api/v1/notifications/preferences"},
+		{"GET", "/api/v1/activity/created"},
+		{"GET", "/api/v1/activity/reacted"},
+		{"GET", "/api/v1/activity/commented"},
+	}
+
+	for _, tt := range private {
+		t.Run("anonymous "+tt.path, func(t *testing.T) {
+			ts.do(t, tt.method, tt.path, nil, nil).assert(t, http.StatusUnauthorized, "", `"error"`)
+		})
+	}
+
+	t.Run("posts", func(t *testing.T) {
+		alice := ts.login(t, "alice")
+		ts.doMultipart(t, "/api/v1/posts/create", alice, url.Values{
+			"title":         {"Posted by API"},
+			"content":       {"Hello"},
+			"categories_id": {"2"},
+		}, "").assert(t, http.StatusCreated, "", `"post_id":2`)
+		ts.doMultipart(t, "/api/v1/posts/create", alice, url.Values{"title": {"No"}}, "").assert(t, http.StatusUnprocessableEntity, "", "title")
+
+		edit := url.Values{"title": {"Edited by API"}, "content": {"Hello again"}}
+		ts.do(t, http.MethodPost, "/api/v1/posts/edit?id=2", ts.as(t, "bobby"), edit).assert(t, http.StatusForbidden, "")
+		ts.do(t, http.MethodPost, "/api/v1/posts/edit?id=999", alice, edit).assert(t, http.StatusNotFound, "")
+		ts.do(t, http.MethodPost, "/api/v1/posts/edit?id=2", alice, edit).assert(t, http.StatusNoContent, "")
+		ts.do(t, http.MethodGet, "/api/v1/posts/show?id=2", nil, nil).assert(t, http.StatusOK, "", "Edited by API")
+
+		ts.do(t, http.MethodPost, "/api/v1/posts/delete?id=2", ts.as(t, "bobby"), url.Values{}).assert(t, http.StatusForbidden, "")
+		ts.do(t, http.MethodPost, "/api/v1/posts/delete?id=2", alice, url.Values{}).assert(t, http.StatusNoContent, "")
+		ts.do(t, http.MethodGet, "/api/v1/posts/show?id=2", nil, nil).assert(t, http.StatusNotFound, "")
+	})
+
+	t.Run("comments", func(t *testing.T) {
+		bobby := ts.login(t, "bobby")
+		ts.do(t, http.MethodPost, "/api/v1/comments/create", bobby, url.Values{"post_id": {strconv.Itoa(postId)}, "content": {"Via API"}}).assert(t, http.StatusCreated, "", `"comment_id"`)
+		ts.do(t, http.MethodPost, "/api/v1/comments/react", bobby, url.Values{"comment_id": {strconv.Itoa(commentId)}, "is_like": {"1"}}).assert(t, http.StatusNoContent, "")
+		ts.do(t, http.MethodPost, "/api/v1/posts/react", bobby, url.Values{"post_id": {strconv.Itoa(postId)}, "is_like": {"1"}}).assert(t, http.StatusNoContent, "")
+
+		path := fmt.Sprintf("/api/v1/comments/edit?id=%d", commentId)
+		ts.do(t, http.MethodPost, path, ts.as(t, "alice"), url.Values{"content": {"Hijacked"}}).assert(t, http.StatusForbidden, "")
+		ts.do(t, http.MethodPost, path, bobby, url.Values{"content": {"Edited via API"}}).assert(t, http.StatusNoContent, "")
+
+		path = fmt.Sprintf("/api/v1/comments/delete?id=%d", commentId)
+		ts.do(t, http.MethodPost, path, ts.as(t, "alice"), url.Values{}).assert(t, http.StatusForbidden, "")
+		ts.do(t, http.MethodPost, path, bobby, url.Values{}).assert(t, http.StatusNoContent, "")
+		ts.do(t, http.MethodPost, path, bobby, url.Values{}).assert(t, http.StatusNotFound, "")
+	})
+
+	t.Run("notifications and activity", func(t *testing.T) {
+		alice := ts.login(t, "alice")
+		notification := fmt.Sprintf("?id=%d", ts.lastId(t, "notifications"))
+		ts.do(t, http.MethodGet, "/api/v1/notifications", alice, nil).assert(t, http.StatusOK, "", "bobby")
+		ts.do(t, http.MethodGet, "/api/v1/notifications/comments", alice, nil).assert(t, http.StatusOK, "", `"type":"comment"`)
+		ts.do(t, http.MethodGet, "/api/v1/notifications/reactions", alice, nil).assert(t, http.StatusOK, "", `"type":"post_like"`)
+		ts.do(t, http.MethodPost, "/api/v1/notifications/read"+notification, alice, url.Values{}).assert(t, http.StatusNoContent, "")
+		ts.do(t, http.MethodPost, "/api/v1/notifications/read-all", alice, url.Values{}).assert(t, http.StatusNoContent, "")
+		ts.do(t, http.MethodPost, "/api/v1/notifications/delete"+notification, alice, url.Values{}).assert(t, http.StatusNoContent, "")
+		ts.do(t, http.MethodPost, "/api/v1/notifications/delete"+notification, alice, url.Values{}).assert(t, http.StatusNotFound, "")
+		ts.do(t, http.MethodGet, "/api/v1/notifications/preferences", alice, nil).assert(t, http.StatusOK, "", "post_reactions")
+		ts.do(t, http.MethodGet, "/api/v1/activity/created", alice, nil).assert(t, http.StatusOK, "", "Alice writes")
+
+		bobby := ts.login(t, "bobby")
+		ts.do(t, http.MethodGet, "/api/v1/activity/reacted", bobby, nil).assert(t, http.StatusOK, "", "Alice writes")
+		ts.do(t, http.MethodGet, "/api/v1/activity/commented", bobby, nil).assert(t, http.StatusOK, "", "Alice writes")
+	})
+}
+
+func TestTokens(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t)
+	postId, _ := seed(t, ts)
+
+	alice := ts.login(t, "alice")
+	resp := ts.do(t, http.MethodPost, "/user/tokens/create", alice, url.Values{"name": {"script"}, "scope": {"read"}})
+	resp.assert(t, http.StatusOK, "", "Copy it now")
+
+	match := regexp.MustCompile(`<code>([^<]+)</code>`).FindStringSubmatch(resp.body)
+	if match == nil {
+		t.Fatal("token not shown")
+	}
+
+	bearer := func(method, path, token string) *testResponse {
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(url.Values{"post_id": {strconv.Itoa(postId)}, "is_like": {"1"}}.Encode()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		return ts.send(t, req, nil)
+	}
+
+	bearer(http.MethodGet, "/api/v1/activity/created", match[1]).assert(t, http.StatusOK, "", "Alice writes")
+	bearer(http.MethodPost, "/api/v1/posts/react", match[1]).assert(t, http.StatusForbidden, "")
+	bearer(http.MethodGet, "/api/v1/activity/created", "nope").assert(t, http.StatusUnauthorized, "")
+	ts.do(t, http.MethodGet, "/user/tokens", alice, nil).assert(t, http.StatusOK, "", "script")
+
+	ts.do(t, http.MethodGet, "/user/tokens/revoke?id=1", ts.as(t, "bobby"), nil).assert(t, http.StatusNotFound, "")
+	ts.do(t, http.MethodGet, "/user/tokens/revoke?id=1", alice, nil).assert(t, http.StatusSeeOther, "/user/tokens")
+	ts.do(t, http.MethodGet, "/user/tokens", alice, nil).assert(t, http.StatusOK, "", "Token revoked.")
+	bearer(http.MethodGet, "/api/v1/activity/created", match[1]).assert(t, http.StatusUnauthorized, "")
+}
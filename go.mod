@@ -4,6 +4,7 @@ go 1.21.0
 
 require (
 	github.com/gofrs/uuid/v5 v5.3.1
+	github.com/lib/pq v1.10.9
 	github.com/mattn/go-sqlite3 v1.14.24
 	golang.org/x/crypto v0.33.0
 )
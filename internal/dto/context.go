@@ -11,6 +11,16 @@ var (
 	ContextKeyRole = contextKey("role")
 )
 
+const (
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+)
+
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
 func GetAuthUser(r *http.Request) *User {
 	val := r.Context().Value(ContextKeyUser)
 
@@ -6,11 +6,30 @@ import (
 )
 
 var (
-	FlashSignupSuccessful = "Signup successful! Please log in."
-	FlashSessionExpired   = "Your session has expired. Please sign in again."
-	FlashPostRemoved      = "Post successfully removed!"
-	FlashCommentEnter     = "Please enter a valid comment."
-	FlashFilterSelect     = "Please select at least one filter."
+	FlashSignupSuccessful      = "Signup successful! Please log in."
+	FlashSessionExpired        = "Your session has expired. Please sign in again."
+	FlashPostRemoved           = "Post successfully removed!"
+	FlashCommentEnter          = "Please enter a valid comment."
+	FlashFilterSelect          = "Please select at least one filter."
+	FlashModRequestSent        = "Your request has been sent to the administrators."
+	FlashModRequestExists      = "You have already applied for moderator."
+	FlashModRequestDenied      = "You already have a role on this forum."
+	FlashRequestApproved       = "Request approved! The user is now a moderator."
+	FlashRequestDeclined       = "Request declined."
+	FlashReportSent            = "Report sent! The administrators will review it shortly."
+	FlashReportExists          = "This post has already been reported."
+	FlashReportReviewed        = "Review saved!"
+	FlashReviewEnter           = "Please enter a valid review for a pending report."
+	FlashReportAlreadyReviewed = "This report has already been reviewed."
+	FlashPostPending           = "Your post has been submitted and will be published once a moderator approves it."
+	FlashPostApproved          = "Post approved and published!"
+	FlashCategoryAdded         = "Category successfully added!"
+	FlashCategoryRemoved       = "Category successfully removed!"
+	FlashCategoryFallback      = "Please choose a different category to move the posts to."
+	FlashTokenRevoked          = "Token revoked."
+	FlashSessionRevoked        = "The device has been signed out."
+	FlashDigestSaved           = "Your email digest settings have been saved."
+	FlashPreferencesSaved      = "Your notification preferences have been saved."
 )
 
 func NewCookie(name, val string) *http.Cookie {
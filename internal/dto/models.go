@@ -1,82 +1,204 @@
 package dto
 
 import (
+	"html/template"
 	"time"
 )
 
 type User struct {
-	ID       int
-	Username string
-	Email    string
-	Created  time.Time
+	ID       int       `json:"id"`
+	Username string    `json:"username"`
+	Email    string    `json:"email,omitempty"`
+	Role     string    `json:"role,omitempty"`
+	Created  time.Time `json:"-"`
 }
 
 type Post struct {
-	ID               int
-	User             *User
-	Title            string
-	Content          string
-	Categories       []string
-	Image            *Image
-	Comments         []*Comment
-	Likes            int
-	Dislikes         int
-	Created          time.Time
-	AuthUserReaction int
+	ID               int        `json:"id"`
+	User             *User      `json:"user"`
+	Title            string     `json:"title"`
+	Content          string     `json:"content"`
+	Categories       []string   `json:"categories"`
+	Tags             []string   `json:"tags"`
+	Mentions         []string   `json:"mentions,omitempty"`
+	Image            *Image     `json:"image,omitempty"`
+	Comments         []*Comment `json:"comments,omitempty"`
+	Likes            int        `json:"likes"`
+	Dislikes         int        `json:"dislikes"`
+	Created          time.Time  `json:"created"`
+	AuthUserReaction int        `json:"auth_user_reaction"`
+	Pending          bool       `json:"pending,omitempty"`
+	Score            float64    `json:"-"`
+}
+
+// VisibleTo reports whether the user with userId and role can see the post. A
+// pending post is only visible to its author and to moderators and admins.
+func (p *Post) VisibleTo(userId int, role string) bool {
+	return !p.Pending || p.User.ID == userId || role == RoleModerator || role == RoleAdmin
 }
 
 type Comment struct {
-	ID               int
-	PostID           int
-	User             *User
-	Content          string
-	Likes            int
-	Dislikes         int
-	Created          time.Time
-	AuthUserReaction int
+	ID               int        `json:"id"`
+	PostID           int        `json:"post_id"`
+	ParentID         int        `json:"parent_id,omitempty"`
+	User             *User      `json:"user"`
+	Content          string     `json:"content"`
+	Mentions         []string   `json:"mentions,omitempty"`
+	Likes            int        `json:"likes"`
+	Dislikes         int        `json:"dislikes"`
+	Created          time.Time  `json:"created"`
+	AuthUserReaction int        `json:"auth_user_reaction"`
+	Replies          []*Comment `json:"replies,omitempty"`
 }
 
 type PostReaction struct {
-	ID      int
-	PostID  int
-	User    *User
-	IsLike  int
-	Created time.Time
+	ID      int       `json:"id"`
+	PostID  int       `json:"post_id"`
+	User    *User     `json:"user"`
+	IsLike  int       `json:"is_like"`
+	Created time.Time `json:"created"`
 }
 
 type CommentReaction struct {
-	ID        int
-	CommentID int
-	User      *User
-	IsLike    int
-	Created   time.Time
+	ID        int       `json:"id"`
+	CommentID int       `json:"comment_id"`
+	User      *User     `json:"user"`
+	IsLike    int       `json:"is_like"`
+	Created   time.Time `json:"created"`
 }
 
 type Image struct {
-	ID       int
-	PostID   int
-	Path     string
-	Uploaded time.Time
+	ID       int       `json:"id"`
+	PostID   int       `json:"post_id"`
+	Path     string    `json:"path"`
+	Uploaded time.Time `json:"uploaded"`
 }
 
 type Category struct {
-	ID      int
-	Name    string
-	Created time.Time
+	ID         int       `json:"id"`
+	Name       string    `json:"name"`
+	PostsCount int       `json:"posts_count"`
+	Created    time.Time `json:"created"`
+}
+
+const (
+	NotificationComment        = "comment"
+	NotificationReply          = "reply"
+	NotificationPostLike       = "post_like"
+	NotificationPostDislike    = "post_dislike"
+	NotificationCommentLike    = "comment_like"
+	NotificationCommentDislike = "comment_dislike"
+	NotificationMention        = "mention"
+)
+
+const (
+	PreferenceComments         = "comments"
+	PreferenceReplies          = "replies"
+	PreferencePostReactions    = "post_reactions"
+	PreferenceCommentReactions = "comment_reactions"
+	PreferenceMentions         = "mentions"
+)
+
+// PreferenceEvents lists the events users can configure notifications for.
+var PreferenceEvents = []string{
+	PreferenceComments,
+	PreferenceReplies,
+	PreferencePostReactions,
+	PreferenceCommentReactions,
+	PreferenceMentions,
+}
+
+var preferenceTypes = map[string][]string{
+	PreferenceComments:         {NotificationComment},
+	PreferenceReplies:          {NotificationReply},
+	PreferencePostReactions:    {NotificationPostLike, NotificationPostDislike},
+	PreferenceCommentReactions: {NotificationCommentLike, NotificationCommentDislike},
+	PreferenceMentions:         {NotificationMention},
+}
+
+// PreferenceTypes returns the notification types produced by a preference event.
+func PreferenceTypes(event string) []string {
+	return preferenceTypes[event]
+}
+
+// PreferenceEvent returns the preference event a notification type belongs to.
+func PreferenceEvent(notificationType string) string {
+	for event, types := range preferenceTypes {
+		for _, t := range types {
+			if t == notificationType {
+				return event
+			}
+		}
+	}
+
+	return ""
+}
+
+const (
+	DigestNever  = "never"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+type Notification struct {
+	ID        int       `json:"id"`
+	Type      string    `json:"type"`
+	Actor     *User     `json:"actor"`
+	PostID    int       `json:"post_id"`
+	CommentID int       `json:"comment_id,omitempty"`
+	Read      bool      `json:"read"`
+	Created   time.Time `json:"created"`
+}
+
+type NotificationPreference struct {
+	Event string `json:"event"`
+	InApp bool   `json:"in_app"`
+	Live  bool   `json:"live"`
+	Email bool   `json:"email"`
+}
+
+type DigestSubscription struct {
+	User      *User
+	Frequency string
+	LastSent  time.Time
+}
+
+type Tag struct {
+	ID         int       `json:"id"`
+	Name       string    `json:"name"`
+	PostsCount int       `json:"posts_count"`
+	Created    time.Time `json:"created"`
 }
 
 type Request struct {
-	ID      int
-	User    *User
-	Created time.Time
+	ID      int       `json:"id"`
+	User    *User     `json:"user"`
+	Created time.Time `json:"created"`
 }
 
 type Report struct {
-	ID          int
-	Post        *Post
-	Moderator   *User
-	Content     string
-	AdminReview string
-	Created     time.Time
-	Reviewed    time.Time
+	ID          int       `json:"id"`
+	Post        *Post     `json:"post"`
+	Moderator   *User     `json:"moderator"`
+	Content     string    `json:"content"`
+	AdminReview string    `json:"admin_review"`
+	Created     time.Time `json:"created"`
+	Reviewed    time.Time `json:"reviewed"`
+}
+
+type Token struct {
+	ID       int       `json:"id"`
+	UserID   int       `json:"user_id"`
+	Name     string    `json:"name"`
+	Scope    string    `json:"scope"`
+	LastUsed time.Time `json:"last_used"`
+	Created  time.Time `json:"created"`
+}
+
+type SearchResult struct {
+	Post    *Post         `json:"post"`
+	Comment *Comment      `json:"comment,omitempty"`
+	Title   template.HTML `json:"title"`
+	Snippet template.HTML `json:"snippet"`
+	Rank    float64       `json:"-"`
 }
@@ -3,8 +3,8 @@ package exception
 import "net/http"
 
 type errorData struct {
-	Code int
-	Text string
+	Code int    `json:"code"`
+	Text string `json:"text"`
 }
 
 func newErrorData(code int) errorData {
@@ -18,5 +18,6 @@ var (
 	errNotFoundData        = newErrorData(http.StatusNotFound)
 	errNotAllowedData      = newErrorData(http.StatusMethodNotAllowed)
 	errTooManyRequestsData = newErrorData(http.StatusTooManyRequests)
+	errUnprocessableData   = newErrorData(http.StatusUnprocessableEntity)
 	errInternalServerData  = newErrorData(http.StatusInternalServerError)
 )
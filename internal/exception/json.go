@@ -0,0 +1,76 @@
+package exception
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/itelman/forum/pkg/validator"
+)
+
+type JSONExceptions interface {
+	Exceptions
+	ErrUnprocessableEntityHandler(w http.ResponseWriter, r *http.Request, errs validator.Errors)
+}
+
+type jsonExceptions struct {
+	errorLog *log.Logger
+}
+
+func NewJSONExceptions(errorLog *log.Logger) *jsonExceptions {
+	return &jsonExceptions{errorLog: errorLog}
+}
+
+type jsonErrorBody struct {
+	Error  errorData        `json:"error"`
+	Fields validator.Errors `json:"fields,omitempty"`
+}
+
+func (e *jsonExceptions) defaultErrorHandler(w http.ResponseWriter, r *http.Request, body jsonErrorBody) {
+	respJson, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(body.Error.Code)
+	w.Write(respJson)
+}
+
+func (e *jsonExceptions) ErrBadRequestHandler(w http.ResponseWriter, r *http.Request) {
+	e.defaultErrorHandler(w, r, jsonErrorBody{Error: errBadRequestData})
+}
+
+func (e *jsonExceptions) ErrUnauthorizedHandler(w http.ResponseWriter, r *http.Request) {
+	e.defaultErrorHandler(w, r, jsonErrorBody{Error: errUnauthorizedData})
+}
+
+func (e *jsonExceptions) ErrForbiddenHandler(w http.ResponseWriter, r *http.Request) {
+	e.defaultErrorHandler(w, r, jsonErrorBody{Error: errForbiddenData})
+}
+
+func (e *jsonExceptions) ErrNotFoundHandler(w http.ResponseWriter, r *http.Request) {
+	e.defaultErrorHandler(w, r, jsonErrorBody{Error: errNotFoundData})
+}
+
+func (e *jsonExceptions) ErrNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
+	e.defaultErrorHandler(w, r, jsonErrorBody{Error: errNotAllowedData})
+}
+
+func (e *jsonExceptions) ErrTooManyRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	e.defaultErrorHandler(w, r, jsonErrorBody{Error: errTooManyRequestsData})
+}
+
+func (e *jsonExceptions) ErrUnprocessableEntityHandler(w http.ResponseWriter, r *http.Request, errs validator.Errors) {
+	e.defaultErrorHandler(w, r, jsonErrorBody{Error: errUnprocessableData, Fields: errs})
+}
+
+func (e *jsonExceptions) ErrInternalServerHandler(w http.ResponseWriter, r *http.Request, err error) {
+	trace := fmt.Sprintf("%s\n%s", err.Error(), debug.Stack())
+	e.errorLog.Output(2, trace)
+
+	e.defaultErrorHandler(w, r, jsonErrorBody{Error: errInternalServerData})
+}
@@ -30,7 +30,11 @@ func (h *handlers) RegisterMux(mux *http.ServeMux) {
 }
 
 func (h *handlers) getAllCreatedPosts(w http.ResponseWriter, r *http.Request) {
-	req := activity.DecodeGetAllCreatedPosts(r)
+	req, err := activity.DecodeGetAllCreatedPosts(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
 
 	resp, err := h.activity.GetAllCreatedPosts(req.(*activity.GetAllCreatedPostsInput))
 	if err != nil {
@@ -39,7 +43,9 @@ func (h *handlers) getAllCreatedPosts(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.TmplRender.RenderData(w, r, "activity_page", templates.TemplateData{
-		templates.Posts: resp.Posts,
+		templates.Posts:      resp.Posts,
+		templates.Cursor:     r.URL.Query().Get("cursor"),
+		templates.NextCursor: resp.NextCursor,
 	}); err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
@@ -47,7 +53,11 @@ func (h *handlers) getAllCreatedPosts(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handlers) getAllReactedPosts(w http.ResponseWriter, r *http.Request) {
-	req := activity.DecodeGetAllReactedPosts(r)
+	req, err := activity.DecodeGetAllReactedPosts(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
 
 	resp, err := h.activity.GetAllReactedPosts(req.(*activity.GetAllReactedPostsInput))
 	if err != nil {
@@ -56,7 +66,9 @@ func (h *handlers) getAllReactedPosts(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.TmplRender.RenderData(w, r, "activity_page", templates.TemplateData{
-		templates.Posts: resp.Posts,
+		templates.Posts:      resp.Posts,
+		templates.Cursor:     r.URL.Query().Get("cursor"),
+		templates.NextCursor: resp.NextCursor,
 	}); err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
@@ -64,7 +76,11 @@ func (h *handlers) getAllReactedPosts(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handlers) getAllCommentedPosts(w http.ResponseWriter, r *http.Request) {
-	req := activity.DecodeGetAllCommentedPosts(r)
+	req, err := activity.DecodeGetAllCommentedPosts(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
 
 	resp, err := h.activity.GetAllCommentedPosts(req.(*activity.GetAllCommentedPostsInput))
 	if err != nil {
@@ -73,7 +89,9 @@ func (h *handlers) getAllCommentedPosts(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := h.TmplRender.RenderData(w, r, "activity_page", templates.TemplateData{
-		templates.Posts: resp.Posts,
+		templates.Posts:      resp.Posts,
+		templates.Cursor:     r.URL.Query().Get("cursor"),
+		templates.NextCursor: resp.NextCursor,
 	}); err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/itelman/forum/internal/service/activity"
+)
+
+func (h *handlers) getAllCreatedPosts(w http.ResponseWriter, r *http.Request) {
+	req, err := activity.DecodeGetAllCreatedPosts(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	resp, err := h.activity.GetAllCreatedPosts(req.(*activity.GetAllCreatedPostsInput))
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, envelope{"posts": resp.Posts, "next_cursor": nextCursor(resp.NextCursor)})
+}
+
+func (h *handlers) getAllReactedPosts(w http.ResponseWriter, r *http.Request) {
+	req, err := activity.DecodeGetAllReactedPosts(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	resp, err := h.activity.GetAllReactedPosts(req.(*activity.GetAllReactedPostsInput))
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, envelope{"posts": resp.Posts, "next_cursor": nextCursor(resp.NextCursor)})
+}
+
+func (h *handlers) getAllCommentedPosts(w http.ResponseWriter, r *http.Request) {
+	req, err := activity.DecodeGetAllCommentedPosts(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	resp, err := h.activity.GetAllCommentedPosts(req.(*activity.GetAllCommentedPostsInput))
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, envelope{"posts": resp.Posts, "next_cursor": nextCursor(resp.NextCursor)})
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/exception"
+	"github.com/itelman/forum/internal/handler"
+	commentsMiddleware "github.com/itelman/forum/internal/handler/comments/middleware"
+	postsMiddleware "github.com/itelman/forum/internal/handler/posts/middleware"
+	"github.com/itelman/forum/internal/service/activity"
+	"github.com/itelman/forum/internal/service/categories"
+	"github.com/itelman/forum/internal/service/comment_reactions"
+	"github.com/itelman/forum/internal/service/comments"
+	"github.com/itelman/forum/internal/service/filters"
+	"github.com/itelman/forum/internal/service/notifications"
+	"github.com/itelman/forum/internal/service/post_reactions"
+	"github.com/itelman/forum/internal/service/posts"
+	"github.com/itelman/forum/internal/service/search"
+	"github.com/itelman/forum/internal/service/tags"
+)
+
+const prefix = "/api/v1"
+
+type envelope map[string]interface{}
+
+type handlers struct {
+	*handler.Handlers
+	jsonExceptions   exception.JSONExceptions
+	postsPerm        postsMiddleware.PostsCheckPermissionMiddleware
+	commentsPerm     commentsMiddleware.CommentsCheckPermissionMiddleware
+	posts            posts.Service
+	comments         comments.Service
+	postReactions    post_reactions.Service
+	commentReactions comment_reactions.Service
+	categories       categories.Service
+	filters          filters.Service
+	notifications    notifications.Service
+	activity         activity.Service
+	search           search.Service
+	tags             tags.Service
+	postImagesDir    string
+}
+
+func NewHandlers(
+	handler *handler.Handlers,
+	jsonExceptions exception.JSONExceptions,
+	posts posts.Service,
+	comments comments.Service,
+	postReactions post_reactions.Service,
+	commentReactions comment_reactions.Service,
+	categories categories.Service,
+	filters filters.Service,
+	notifications notifications.Service,
+	activity activity.Service,
+	search search.Service,
+	tags tags.Service,
+	postImagesDir string,
+) *handlers {
+	return &handlers{
+		Handlers:         handler,
+		jsonExceptions:   jsonExceptions,
+		postsPerm:        postsMiddleware.NewMiddleware(posts, jsonExceptions),
+		commentsPerm:     commentsMiddleware.NewMiddleware(comments, jsonExceptions),
+		posts:            posts,
+		comments:         comments,
+		postReactions:    postReactions,
+		commentReactions: commentReactions,
+		categories:       categories,
+		filters:          filters,
+		notifications:    notifications,
+		activity:         activity,
+		search:           search,
+		tags:             tags,
+		postImagesDir:    postImagesDir,
+	}
+}
+
+func (h *handlers) RegisterMux(mux *http.ServeMux) {
+	mux.Handle(prefix+"/", http.HandlerFunc(h.Exceptions.ErrNotFoundHandler))
+
+	publicRoutes := []dto.Route{
+		{Path: prefix + "/posts", Methods: dto.GetMethod, Handler: h.getAllPosts},
+		{Path: prefix + "/posts/show", Methods: dto.GetMethod, Handler: h.getPost},
+		{Path: prefix + "/posts/filter", Methods: dto.GetPostMethods, Handler: h.filterPosts},
+		{Path: prefix + "/categories", Methods: dto.GetMethod, Handler: h.getAllCategories},
+		{Path: prefix + "/search", Methods: dto.GetMethod, Handler: h.searchPosts},
+		{Path: prefix + "/tags", Methods: dto.GetMethod, Handler: h.getTag},
+		{Path: prefix + "/tags/autocomplete", Methods: dto.GetMethod, Handler: h.autocompleteTags},
+		{Path: prefix + "/tags/trending", Methods: dto.GetMethod, Handler: h.getTrendingTags},
+	}
+
+	for _, route := range publicRoutes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(http.HandlerFunc(route.Handler), route.Path, route.Methods))
+	}
+
+	userRoutes := []dto.Route{
+		{Path: prefix + "/posts/create", Methods: dto.PostMethod, Handler: h.createPost},
+		{Path: prefix + "/posts/react", Methods: dto.PostMethod, Handler: h.createPostReaction},
+		{Path: prefix + "/comments/create", Methods: dto.PostMethod, Handler: h.createComment},
+		{Path: prefix + "/comments/react", Methods: dto.PostMethod, Handler: h.createCommentReaction},
+		{Path: prefix + "/notifications", Methods: dto.GetMethod, Handler: h.getAllNotifications},
+		{Path: prefix + "/notifications/comments", Methods: dto.GetMethod, Handler: h.getAllCommentNotifications},
+		{Path: prefix + "/notifications/reactions", Methods: dto.GetMethod, Handler: h.getAllReactionNotifications},
+		{Path: prefix + "/notifications/read", Methods: dto.PostMethod, Handler: h.readNotification},
+		{Path: prefix + "/notifications/read-all", Methods: dto.PostMethod, Handler: h.readAllNotifications},
+		{Path: prefix + "/notifications/delete", Methods: dto.PostMethod, Handler: h.deleteNotification},
+		{Path: prefix + "/notifications/preferences", Methods: dto.GetPostMethods, Handler: h.notificationPreferences},
+		{Path: prefix + "/activity/created", Methods: dto.GetMethod, Handler: h.getAllCreatedPosts},
+		{Path: prefix + "/activity/reacted", Methods: dto.GetMethod, Handler: h.getAllReactedPosts},
+		{Path: prefix + "/activity/commented", Methods: dto.GetMethod, Handler: h.getAllCommentedPosts},
+	}
+
+	for _, route := range userRoutes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(h.requireAuthenticatedUser(http.HandlerFunc(route.Handler)), route.Path, route.Methods))
+	}
+
+	postOwnerRoutes := []dto.Route{
+		{Path: prefix + "/posts/edit", Methods: dto.PostMethod, Handler: h.updatePost},
+		{Path: prefix + "/posts/delete", Methods: dto.PostMethod, Handler: h.deletePost},
+	}
+
+	for _, route := range postOwnerRoutes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(h.requireAuthenticatedUser(h.postsPerm.CheckUserPermissions(http.HandlerFunc(route.Handler))), route.Path, route.Methods))
+	}
+
+	commentOwnerRoutes := []dto.Route{
+		{Path: prefix + "/comments/edit", Methods: dto.PostMethod, Handler: h.updateComment},
+		{Path: prefix + "/comments/delete", Methods: dto.PostMethod, Handler: h.deleteComment},
+	}
+
+	for _, route := range commentOwnerRoutes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(h.requireAuthenticatedUser(h.commentsPerm.CheckUserPermissions(http.HandlerFunc(route.Handler))), route.Path, route.Methods))
+	}
+}
+
+func (h *handlers) requireAuthenticatedUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if dto.GetAuthUser(r) == nil {
+			h.Exceptions.ErrUnauthorizedHandler(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func nextCursor(cursor string) interface{} {
+	if len(cursor) == 0 {
+		return nil
+	}
+
+	return cursor
+}
+
+func (h *handlers) writeJSON(w http.ResponseWriter, r *http.Request, status int, data envelope) {
+	respJson, err := json.Marshal(data)
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(respJson)
+}
@@ -0,0 +1,13 @@
+package api
+
+import "net/http"
+
+func (h *handlers) getAllCategories(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.categories.GetAllCategories()
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, envelope{"categories": resp.Categories})
+}
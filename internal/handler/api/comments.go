@@ -0,0 +1,74 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/itelman/forum/internal/handler/comments/middleware"
+	"github.com/itelman/forum/internal/service/comments"
+	"github.com/itelman/forum/internal/service/comments/domain"
+)
+
+func (h *handlers) createComment(w http.ResponseWriter, r *http.Request) {
+	req, err := comments.DecodeCreateComment(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	input := req.(*comments.CreateCommentInput)
+
+	resp, err := h.comments.CreateComment(input)
+	if errors.Is(err, domain.ErrCommentsBadRequest) {
+		if len(input.Errors) == 0 {
+			h.Exceptions.ErrBadRequestHandler(w, r)
+			return
+		}
+
+		h.jsonExceptions.ErrUnprocessableEntityHandler(w, r, input.Errors)
+		return
+	} else if errors.Is(err, domain.ErrPostNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusCreated, envelope{"comment_id": resp.CommentID})
+}
+
+func (h *handlers) updateComment(w http.ResponseWriter, r *http.Request) {
+	req, err := comments.DecodeUpdateComment(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	input := req.(*comments.UpdateCommentInput)
+
+	if err := h.comments.UpdateComment(input, middleware.GetCommentFromContext(r)); errors.Is(err, domain.ErrCommentsBadRequest) {
+		h.jsonExceptions.ErrUnprocessableEntityHandler(w, r, input.Errors)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handlers) deleteComment(w http.ResponseWriter, r *http.Request) {
+	req, err := comments.DecodeDeleteComment(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	if err := h.comments.DeleteComment(req.(*comments.DeleteCommentInput)); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
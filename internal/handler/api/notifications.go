@@ -0,0 +1,126 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/itelman/forum/internal/service/notifications"
+	"github.com/itelman/forum/internal/service/notifications/domain"
+)
+
+func (h *handlers) getAllNotifications(w http.ResponseWriter, r *http.Request) {
+	req, err := notifications.DecodeGetAllNotifications(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	h.writeNotifications(w, r, req.(*notifications.GetAllNotificationsInput))
+}
+
+func (h *handlers) getAllCommentNotifications(w http.ResponseWriter, r *http.Request) {
+	req, err := notifications.DecodeGetAllCommentNotifications(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	h.writeNotifications(w, r, req.(*notifications.GetAllNotificationsInput))
+}
+
+func (h *handlers) getAllReactionNotifications(w http.ResponseWriter, r *http.Request) {
+	req, err := notifications.DecodeGetAllReactionNotifications(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	h.writeNotifications(w, r, req.(*notifications.GetAllNotificationsInput))
+}
+
+func (h *handlers) writeNotifications(w http.ResponseWriter, r *http.Request, input *notifications.GetAllNotificationsInput) {
+	resp, err := h.notifications.GetAllNotifications(input)
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	countResp, err := h.notifications.CountUnreadNotifications(notifications.DecodeCountUnreadNotifications(r).(*notifications.CountUnreadNotificationsInput))
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, envelope{"notifications": resp.Notifications, "unread_count": countResp.Count, "next_cursor": nextCursor(resp.NextCursor)})
+}
+
+func (h *handlers) readNotification(w http.ResponseWriter, r *http.Request) {
+	req, err := notifications.DecodeNotification(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	if _, err := h.notifications.ReadNotification(req.(*notifications.NotificationInput)); errors.Is(err, domain.ErrNotificationNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handlers) readAllNotifications(w http.ResponseWriter, r *http.Request) {
+	if err := h.notifications.ReadAllNotifications(notifications.DecodeReadAllNotifications(r).(*notifications.ReadAllNotificationsInput)); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handlers) deleteNotification(w http.ResponseWriter, r *http.Request) {
+	req, err := notifications.DecodeNotification(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	if err := h.notifications.DeleteNotification(req.(*notifications.NotificationInput)); errors.Is(err, domain.ErrNotificationNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handlers) notificationPreferences(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		req, err := notifications.DecodeUpdateNotificationPreferences(r)
+		if err != nil {
+			h.Exceptions.ErrBadRequestHandler(w, r)
+			return
+		}
+
+		if err := h.notifications.UpdateNotificationPreferences(req.(*notifications.UpdateNotificationPreferencesInput)); errors.Is(err, domain.ErrNotificationsBadRequest) {
+			h.Exceptions.ErrBadRequestHandler(w, r)
+			return
+		} else if err != nil {
+			h.Exceptions.ErrInternalServerHandler(w, r, err)
+			return
+		}
+	}
+
+	resp, err := h.notifications.GetNotificationPreferences(notifications.DecodeGetNotificationPreferences(r).(*notifications.GetNotificationPreferencesInput))
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, envelope{"preferences": resp.Preferences})
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/itelman/forum/internal/handler/posts/middleware"
+	"github.com/itelman/forum/internal/service/filters"
+	filtersDomain "github.com/itelman/forum/internal/service/filters/domain"
+	"github.com/itelman/forum/internal/service/posts"
+	"github.com/itelman/forum/internal/service/posts/domain"
+)
+
+func (h *handlers) getAllPosts(w http.ResponseWriter, r *http.Request) {
+	req, err := posts.DecodeGetAllLatestPosts(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	resp, err := h.posts.GetAllLatestPosts(req.(*posts.GetAllLatestPostsInput))
+	if errors.Is(err, domain.ErrPostsBadRequest) {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, envelope{"posts": resp.Posts, "next_cursor": nextCursor(resp.NextCursor)})
+}
+
+func (h *handlers) getPost(w http.ResponseWriter, r *http.Request) {
+	req, err := posts.DecodeGetPost(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	resp, err := h.posts.GetPost(req.(*posts.GetPostInput))
+	if errors.Is(err, domain.ErrPostNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, envelope{"post": resp.Post})
+}
+
+func (h *handlers) createPost(w http.ResponseWriter, r *http.Request) {
+	req, err := posts.DecodeCreatePost(r)
+	if errors.Is(err, domain.ErrPostsBadRequest) {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	input := req.(*posts.CreatePostInput)
+
+	resp, err := h.posts.CreatePost(input, h.postImagesDir)
+	if errors.Is(err, domain.ErrPostsBadRequest) {
+		h.jsonExceptions.ErrUnprocessableEntityHandler(w, r, input.Errors)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusCreated, envelope{"post_id": resp.PostID, "pending": resp.Pending})
+}
+
+func (h *handlers) updatePost(w http.ResponseWriter, r *http.Request) {
+	req, err := posts.DecodeUpdatePost(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	input := req.(*posts.UpdatePostInput)
+
+	if err := h.posts.UpdatePost(input, middleware.GetPostFromContext(r)); errors.Is(err, domain.ErrPostsBadRequest) {
+		h.jsonExceptions.ErrUnprocessableEntityHandler(w, r, input.Errors)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handlers) deletePost(w http.ResponseWriter, r *http.Request) {
+	req, err := posts.DecodeDeletePost(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	if err := h.posts.DeletePost(req.(*posts.DeletePostInput), h.postImagesDir); errors.Is(err, domain.ErrPostNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handlers) filterPosts(w http.ResponseWriter, r *http.Request) {
+	req, err := filters.DecodeGetPostsByFilters(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	input := req.(*filters.GetPostsByFiltersInput)
+
+	resp, err := h.filters.GetPostsByFilters(input)
+	if errors.Is(err, filtersDomain.ErrFiltersNoneSelected) || errors.Is(err, filtersDomain.ErrFiltersBadRequest) {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	} else if errors.Is(err, filtersDomain.ErrUserUnauthorized) {
+		h.Exceptions.ErrUnauthorizedHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, envelope{"posts": resp.Posts, "next_cursor": nextCursor(resp.NextCursor)})
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/itelman/forum/internal/service/comment_reactions"
+	commentReactionsDomain "github.com/itelman/forum/internal/service/comment_reactions/domain"
+	"github.com/itelman/forum/internal/service/post_reactions"
+	postReactionsDomain "github.com/itelman/forum/internal/service/post_reactions/domain"
+)
+
+func (h *handlers) createPostReaction(w http.ResponseWriter, r *http.Request) {
+	req, err := post_reactions.DecodeCreatePostReaction(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	if err := h.postReactions.CreatePostReaction(req.(*post_reactions.CreatePostReactionInput)); errors.Is(err, postReactionsDomain.ErrPostReactionsBadRequest) {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	} else if errors.Is(err, postReactionsDomain.ErrPostNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handlers) createCommentReaction(w http.ResponseWriter, r *http.Request) {
+	req, err := comment_reactions.DecodeCreateCommentReaction(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	if _, err := h.commentReactions.CreateCommentReaction(req.(*comment_reactions.CreateCommentReactionInput)); errors.Is(err, commentReactionsDomain.ErrCommentReactionsBadRequest) {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	} else if errors.Is(err, commentReactionsDomain.ErrPostNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
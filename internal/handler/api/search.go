@@ -0,0 +1,24 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/itelman/forum/internal/service/search"
+	"github.com/itelman/forum/internal/service/search/domain"
+)
+
+func (h *handlers) searchPosts(w http.ResponseWriter, r *http.Request) {
+	input := search.DecodeSearch(r).(*search.SearchInput)
+
+	resp, err := h.search.Search(input)
+	if errors.Is(err, domain.ErrSearchBadRequest) {
+		h.jsonExceptions.ErrUnprocessableEntityHandler(w, r, input.Errors)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, envelope{"results": resp.Results})
+}
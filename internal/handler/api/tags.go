@@ -0,0 +1,51 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/itelman/forum/internal/service/tags"
+	"github.com/itelman/forum/internal/service/tags/domain"
+)
+
+func (h *handlers) getTag(w http.ResponseWriter, r *http.Request) {
+	req, err := tags.DecodeGetTag(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	resp, err := h.tags.GetTag(req.(*tags.GetTagInput))
+	if errors.Is(err, domain.ErrTagsBadRequest) {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	} else if errors.Is(err, domain.ErrTagNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, envelope{"tag": resp.Tag, "posts": resp.Posts, "next_cursor": nextCursor(resp.NextCursor)})
+}
+
+func (h *handlers) autocompleteTags(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.tags.AutocompleteTags(tags.DecodeAutocompleteTags(r).(*tags.AutocompleteTagsInput))
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, envelope{"tags": resp.Tags})
+}
+
+func (h *handlers) getTrendingTags(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.tags.GetTrendingTags()
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, envelope{"tags": resp.Tags})
+}
@@ -0,0 +1,113 @@
+package categories
+
+import (
+	"errors"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/handler"
+	"github.com/itelman/forum/internal/service/categories"
+	"github.com/itelman/forum/internal/service/categories/domain"
+	"github.com/itelman/forum/pkg/templates"
+	"github.com/itelman/forum/pkg/validator"
+	"net/http"
+)
+
+type handlers struct {
+	*handler.Handlers
+	categories categories.Service
+}
+
+func NewHandlers(handler *handler.Handlers, categories categories.Service) *handlers {
+	return &handlers{handler, categories}
+}
+
+func (h *handlers) RegisterMux(mux *http.ServeMux) {
+	adminRoutes := []dto.Route{
+		{Path: "/user/admin/categories", Methods: dto.GetMethod, Handler: h.getAll},
+		{Path: "/user/admin/categories/add", Methods: dto.PostMethod, Handler: h.create},
+		{Path: "/user/admin/categories/delete", Methods: dto.GetMethod, Handler: h.delete},
+	}
+
+	for _, route := range adminRoutes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(h.DynMiddleware.RequireAuthenticatedUser(h.DynMiddleware.RoleAccessControl(http.HandlerFunc(route.Handler), dto.RoleAdmin)), route.Path, route.Methods))
+	}
+}
+
+func (h *handlers) getAll(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.categories.GetAllCategories()
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.TmplRender.RenderData(w, r, "categories_page", templates.TemplateData{
+		templates.Categories: resp.Categories,
+		templates.Form:       validator.NewForm(nil, nil),
+	}); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+}
+
+func (h *handlers) create(w http.ResponseWriter, r *http.Request) {
+	req, err := categories.DecodeCreateCategory(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	input := req.(*categories.CreateCategoryInput)
+
+	if err := h.categories.CreateCategory(input); errors.Is(err, domain.ErrCategoriesBadRequest) || errors.Is(err, domain.ErrCategoryExists) {
+		resp, err := h.categories.GetAllCategories()
+		if err != nil {
+			h.Exceptions.ErrInternalServerHandler(w, r, err)
+			return
+		}
+
+		if err := h.TmplRender.RenderData(w, r, "categories_page", templates.TemplateData{
+			templates.Categories: resp.Categories,
+			templates.Form:       validator.NewForm(r.PostForm, input.Errors),
+		}); err != nil {
+			h.Exceptions.ErrInternalServerHandler(w, r, err)
+			return
+		}
+
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.SesManager.UpdateSessionFlash(r, dto.FlashCategoryAdded); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/user/admin/categories", http.StatusSeeOther)
+}
+
+func (h *handlers) delete(w http.ResponseWriter, r *http.Request) {
+	req, err := categories.DecodeDeleteCategory(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	flash := dto.FlashCategoryRemoved
+	if err := h.categories.DeleteCategory(req.(*categories.DeleteCategoryInput)); errors.Is(err, domain.ErrCategoryNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if errors.Is(err, domain.ErrCategoriesBadRequest) {
+		flash = dto.FlashCategoryFallback
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.SesManager.UpdateSessionFlash(r, flash); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/user/admin/categories", http.StatusSeeOther)
+}
@@ -49,7 +49,7 @@ func (h *handlers) create(w http.ResponseWriter, r *http.Request) {
 
 	input := req.(*comments.CreateCommentInput)
 
-	if err = h.comments.CreateComment(input); errors.Is(err, domain.ErrCommentsBadRequest) {
+	if _, err = h.comments.CreateComment(input); errors.Is(err, domain.ErrCommentsBadRequest) {
 		if err := h.SesManager.UpdateSessionFlash(r, dto.FlashCommentEnter); err != nil {
 			h.Exceptions.ErrInternalServerHandler(w, r, err)
 			return
@@ -0,0 +1,88 @@
+package digests
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/handler"
+	"github.com/itelman/forum/internal/service/digests"
+	"github.com/itelman/forum/internal/service/digests/domain"
+	"github.com/itelman/forum/pkg/templates"
+	"github.com/itelman/forum/pkg/validator"
+)
+
+type handlers struct {
+	*handler.Handlers
+	digests digests.Service
+}
+
+func NewHandlers(handler *handler.Handlers, digests digests.Service) *handlers {
+	return &handlers{handler, digests}
+}
+
+func (h *handlers) RegisterMux(mux *http.ServeMux) {
+	routes := []dto.Route{
+		{Path: "/user/digest", Methods: dto.GetPostMethods, Handler: h.settings},
+	}
+
+	for _, route := range routes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(h.DynMiddleware.RequireAuthenticatedUser(http.HandlerFunc(route.Handler)), route.Path, route.Methods))
+	}
+}
+
+func (h *handlers) settings(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		h.get(w, r)
+		return
+	}
+
+	h.update(w, r)
+}
+
+func (h *handlers) get(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.digests.GetDigestSettings(digests.DecodeGetDigestSettings(r).(*digests.GetDigestSettingsInput))
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	form := validator.NewForm(make(map[string][]string), nil)
+	form.Set("frequency", resp.Frequency)
+
+	if err := h.TmplRender.RenderData(w, r, "digest_page", templates.TemplateData{
+		templates.Form: form,
+	}); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+}
+
+func (h *handlers) update(w http.ResponseWriter, r *http.Request) {
+	req, err := digests.DecodeUpdateDigestSettings(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	input := req.(*digests.UpdateDigestSettingsInput)
+
+	if err := h.digests.UpdateDigestSettings(input); errors.Is(err, domain.ErrDigestsBadRequest) {
+		if err := h.TmplRender.RenderData(w, r, "digest_page", templates.TemplateData{
+			templates.Form: validator.NewForm(r.PostForm, input.Errors),
+		}); err != nil {
+			h.Exceptions.ErrInternalServerHandler(w, r, err)
+		}
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.SesManager.UpdateSessionFlash(r, dto.FlashDigestSaved); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/user/digest", http.StatusSeeOther)
+}
@@ -0,0 +1,151 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/handler"
+	"github.com/itelman/forum/internal/service/notifications"
+	"github.com/itelman/forum/internal/service/posts"
+	"github.com/itelman/forum/internal/service/posts/domain"
+	"github.com/itelman/forum/pkg/events"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	heartbeatInterval = 30 * time.Second
+	// Streams are closed after maxStreamDuration so that the browser reconnects
+	// and the session is checked again by the auth middleware.
+	maxStreamDuration = 10 * time.Minute
+	retryDelay        = 3 * time.Second
+)
+
+type handlers struct {
+	*handler.Handlers
+	hub           events.Hub
+	posts         posts.Service
+	notifications notifications.Service
+}
+
+func NewHandlers(handler *handler.Handlers, hub events.Hub, posts posts.Service, notifications notifications.Service) *handlers {
+	return &handlers{handler, hub, posts, notifications}
+}
+
+func (h *handlers) RegisterMux(mux *http.ServeMux) {
+	routes := []dto.Route{
+		{Path: "/user/events", Methods: dto.GetMethod, Handler: h.stream},
+	}
+
+	for _, route := range routes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(h.DynMiddleware.RequireAuthenticatedUser(http.HandlerFunc(route.Handler)), route.Path, route.Methods))
+	}
+}
+
+func (h *handlers) stream(w http.ResponseWriter, r *http.Request) {
+	user := dto.GetAuthUser(r)
+	topics := []string{events.UserTopic(user.ID)}
+
+	if r.URL.Query().Has("post_id") {
+		postId, err := strconv.Atoi(r.URL.Query().Get("post_id"))
+		if err != nil {
+			h.Exceptions.ErrBadRequestHandler(w, r)
+			return
+		}
+
+		if _, err := h.posts.GetPost(&posts.GetPostInput{
+			ID:           postId,
+			AuthUserID:   user.ID,
+			AuthUserRole: dto.GetUserRole(r),
+		}); errors.Is(err, domain.ErrPostNotFound) {
+			h.Exceptions.ErrNotFoundHandler(w, r)
+			return
+		} else if err != nil {
+			h.Exceptions.ErrInternalServerHandler(w, r, err)
+			return
+		}
+
+		topics = append(topics, events.PostTopic(postId))
+	}
+
+	// The server's read and write timeouts are meant for regular requests;
+	// lift them for this connection so the stream is not cut after a few seconds.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+	if err := rc.SetReadDeadline(time.Time{}); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	ch, unsubscribe := h.hub.Subscribe(topics...)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", retryDelay.Milliseconds())
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	deadline := time.NewTimer(maxStreamDuration)
+	defer deadline.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-deadline.C:
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		case event := <-ch:
+			if !h.allowsLive(user.ID, event) {
+				continue
+			}
+
+			data, err := json.Marshal(event.Data)
+			if err != nil {
+				continue
+			}
+
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Name, data)
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// allowsLive reports whether a notification event should be pushed to the user.
+// Live updates only cover notifications the user also sees in the app.
+func (h *handlers) allowsLive(userId int, event events.Event) bool {
+	notification, ok := event.Data.(*dto.Notification)
+	if event.Name != events.Notification || !ok {
+		return true
+	}
+
+	resp, err := h.notifications.GetNotificationPreferences(&notifications.GetNotificationPreferencesInput{AuthUserID: userId})
+	if err != nil {
+		return false
+	}
+
+	for _, preference := range resp.Preferences {
+		if preference.Event == dto.PreferenceEvent(notification.Type) {
+			return preference.InApp && preference.Live
+		}
+	}
+
+	return true
+}
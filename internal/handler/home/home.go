@@ -9,6 +9,8 @@ import (
 	"github.com/itelman/forum/internal/service/filters"
 	"github.com/itelman/forum/internal/service/filters/domain"
 	"github.com/itelman/forum/internal/service/posts"
+	postsDomain "github.com/itelman/forum/internal/service/posts/domain"
+	"github.com/itelman/forum/internal/service/tags"
 	"github.com/itelman/forum/pkg/templates"
 	"github.com/itelman/forum/pkg/validator"
 	"net/http"
@@ -19,16 +21,17 @@ type handlers struct {
 	posts      posts.Service
 	categories categories.Service
 	filters    filters.Service
+	tags       tags.Service
 }
 
-func NewHandlers(handler *handler.Handlers, posts posts.Service, categories categories.Service, filters filters.Service) *handlers {
-	return &handlers{handler, posts, categories, filters}
+func NewHandlers(handler *handler.Handlers, posts posts.Service, categories categories.Service, filters filters.Service, tags tags.Service) *handlers {
+	return &handlers{handler, posts, categories, filters, tags}
 }
 
 func (h *handlers) RegisterMux(mux *http.ServeMux) {
 	routes := []dto.Route{
 		{"/", dto.GetMethod, h.home},
-		{"/results", dto.PostMethod, h.results},
+		{"/results", dto.GetPostMethods, h.results},
 		{"/health", dto.GetMethod, h.healthCheck},
 	}
 
@@ -57,8 +60,25 @@ func (h *handlers) healthCheck(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handlers) home(w http.ResponseWriter, r *http.Request) {
-	postsResp, err := h.posts.GetAllLatestPosts()
+	req, err := posts.DecodeGetAllLatestPosts(r)
 	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	input := req.(*posts.GetAllLatestPostsInput)
+	input.Sort, input.Period = h.feedSort(r, input.Sort, input.Period)
+
+	postsResp, err := h.posts.GetAllLatestPosts(input)
+	if errors.Is(err, postsDomain.ErrPostsBadRequest) {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.rememberFeedSort(r, input.Sort, input.Period); err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
 	}
@@ -69,10 +89,21 @@ func (h *handlers) home(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	tagsResp, err := h.tags.GetTrendingTags()
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
 	if err := h.TmplRender.RenderData(w, r, "home_page", templates.TemplateData{
 		templates.Posts:      postsResp.Posts,
 		templates.Categories: catgRsp.Categories,
+		templates.Tags:       tagsResp.Tags,
 		templates.Form:       validator.NewForm(nil, nil),
+		templates.Sort:       input.Sort,
+		templates.Period:     input.Period,
+		templates.Cursor:     r.URL.Query().Get("cursor"),
+		templates.NextCursor: postsResp.NextCursor,
 	}); err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
@@ -87,6 +118,7 @@ func (h *handlers) results(w http.ResponseWriter, r *http.Request) {
 	}
 
 	input := req.(*filters.GetPostsByFiltersInput)
+	input.Sort, input.Period = h.feedSort(r, input.Sort, input.Period)
 
 	filtersResp, err := h.filters.GetPostsByFilters(input)
 	if errors.Is(err, domain.ErrFiltersNoneSelected) {
@@ -101,21 +133,41 @@ func (h *handlers) results(w http.ResponseWriter, r *http.Request) {
 	} else if errors.Is(err, domain.ErrUserUnauthorized) {
 		http.Redirect(w, r, "/user/login", http.StatusFound)
 		return
+	} else if errors.Is(err, domain.ErrFiltersBadRequest) {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
 	} else if err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
 	}
 
+	if err := h.rememberFeedSort(r, input.Sort, input.Period); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
 	catgRsp, err := h.categories.GetAllCategories()
 	if err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
 	}
 
+	tagsResp, err := h.tags.GetTrendingTags()
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
 	if err := h.TmplRender.RenderData(w, r, "home_page", templates.TemplateData{
 		templates.Posts:      filtersResp.Posts,
 		templates.Categories: catgRsp.Categories,
-		templates.Form:       validator.NewForm(nil, input.Errors),
+		templates.Tags:       tagsResp.Tags,
+		templates.Form:       validator.NewForm(r.Form, input.Errors),
+		templates.Sort:       input.Sort,
+		templates.Period:     input.Period,
+		templates.Cursor:     r.Form.Get("cursor"),
+		templates.NextCursor: filtersResp.NextCursor,
+		templates.Query:      r.Form,
 	}); err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
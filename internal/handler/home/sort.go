@@ -0,0 +1,43 @@
+package home
+
+import (
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/pkg/pagination"
+	"github.com/itelman/forum/pkg/sesm"
+	"net/http"
+)
+
+// feedSort falls back to the ordering remembered in the session when the
+// request doesn't pick one.
+func (h *handlers) feedSort(r *http.Request, sort, period string) (string, string) {
+	if len(sort) == 0 {
+		if val, err := h.SesManager.GetSessionData(r, sesm.FeedSort); err == nil {
+			sort, _ = val.(string)
+		}
+
+		if val, err := h.SesManager.GetSessionData(r, sesm.FeedPeriod); err == nil {
+			period, _ = val.(string)
+		}
+	}
+
+	if len(sort) == 0 {
+		sort = pagination.SortNew
+	}
+
+	if len(period) == 0 {
+		period = pagination.PeriodAll
+	}
+
+	return sort, period
+}
+
+func (h *handlers) rememberFeedSort(r *http.Request, sort, period string) error {
+	if dto.GetAuthUser(r) == nil {
+		return nil
+	}
+
+	return h.SesManager.AddOrUpdateSessionData(r, map[string]interface{}{
+		sesm.FeedSort:   sort,
+		sesm.FeedPeriod: period,
+	})
+}
@@ -0,0 +1,120 @@
+package moderation
+
+import (
+	"errors"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/handler"
+	"github.com/itelman/forum/internal/service/moderation"
+	"github.com/itelman/forum/internal/service/moderation/domain"
+	"github.com/itelman/forum/pkg/templates"
+	"net/http"
+)
+
+type handlers struct {
+	*handler.Handlers
+	moderation moderation.Service
+}
+
+func NewHandlers(handler *handler.Handlers, moderation moderation.Service) *handlers {
+	return &handlers{handler, moderation}
+}
+
+func (h *handlers) RegisterMux(mux *http.ServeMux) {
+	requestRoute := dto.Route{Path: "/user/request-mod", Methods: dto.PostMethod, Handler: h.createRequest}
+	mux.Handle(requestRoute.Path, h.DynMiddleware.Chain(h.DynMiddleware.RequireAuthenticatedUser(http.HandlerFunc(requestRoute.Handler)), requestRoute.Path, requestRoute.Methods))
+
+	adminRoutes := []dto.Route{
+		{Path: "/user/admin/moderators", Methods: dto.GetMethod, Handler: h.getAllRequests},
+		{Path: "/user/admin/moderators/approve", Methods: dto.GetMethod, Handler: h.approveRequest},
+		{Path: "/user/admin/moderators/decline", Methods: dto.GetMethod, Handler: h.declineRequest},
+	}
+
+	for _, route := range adminRoutes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(h.DynMiddleware.RequireAuthenticatedUser(h.DynMiddleware.RoleAccessControl(http.HandlerFunc(route.Handler), dto.RoleAdmin)), route.Path, route.Methods))
+	}
+}
+
+func (h *handlers) createRequest(w http.ResponseWriter, r *http.Request) {
+	input := moderation.DecodeCreateRequest(r).(*moderation.CreateRequestInput)
+
+	flash := dto.FlashModRequestSent
+	if err := h.moderation.CreateRequest(input); errors.Is(err, domain.ErrRequestExists) {
+		flash = dto.FlashModRequestExists
+	} else if errors.Is(err, domain.ErrModerationBadRequest) {
+		flash = dto.FlashModRequestDenied
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.SesManager.UpdateSessionFlash(r, flash); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+func (h *handlers) getAllRequests(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.moderation.GetAllRequests()
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.TmplRender.RenderData(w, r, "moderators_page", templates.TemplateData{
+		templates.Requests: resp.Requests,
+	}); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+}
+
+func (h *handlers) approveRequest(w http.ResponseWriter, r *http.Request) {
+	req, err := moderation.DecodeApproveRequest(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	if err := h.moderation.ApproveRequest(req.(*moderation.ApproveRequestInput)); errors.Is(err, domain.ErrRequestNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if errors.Is(err, domain.ErrModerationBadRequest) {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.SesManager.UpdateSessionFlash(r, dto.FlashRequestApproved); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/user/admin/moderators", http.StatusSeeOther)
+}
+
+func (h *handlers) declineRequest(w http.ResponseWriter, r *http.Request) {
+	req, err := moderation.DecodeDeclineRequest(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	if err := h.moderation.DeclineRequest(req.(*moderation.DeclineRequestInput)); errors.Is(err, domain.ErrRequestNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.SesManager.UpdateSessionFlash(r, dto.FlashRequestDeclined); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/user/admin/moderators", http.StatusSeeOther)
+}
@@ -1,9 +1,12 @@
 package notifications
 
 import (
+	"errors"
+	"fmt"
 	"github.com/itelman/forum/internal/dto"
 	"github.com/itelman/forum/internal/handler"
 	"github.com/itelman/forum/internal/service/notifications"
+	"github.com/itelman/forum/internal/service/notifications/domain"
 	"github.com/itelman/forum/pkg/templates"
 	"github.com/itelman/forum/pkg/validator"
 	"net/http"
@@ -22,7 +25,12 @@ func (h *handlers) RegisterMux(mux *http.ServeMux) {
 	routes := []dto.Route{
 		{Path: "/user/notifications", Methods: dto.GetPostMethods, Handler: h.redirect},
 		{Path: "/user/notifications/comments", Methods: dto.GetMethod, Handler: h.getCommentNotifications},
-		{Path: "/user/notifications/reactions", Methods: dto.GetMethod, Handler: h.getPostReactionNotifications},
+		{Path: "/user/notifications/reactions", Methods: dto.GetMethod, Handler: h.getReactionNotifications},
+		{Path: "/user/notifications/open", Methods: dto.GetMethod, Handler: h.open},
+		{Path: "/user/notifications/read", Methods: dto.PostMethod, Handler: h.read},
+		{Path: "/user/notifications/read-all", Methods: dto.PostMethod, Handler: h.readAll},
+		{Path: "/user/notifications/delete", Methods: dto.PostMethod, Handler: h.delete},
+		{Path: "/user/notifications/preferences", Methods: dto.GetPostMethods, Handler: h.preferences},
 	}
 
 	for _, route := range routes {
@@ -57,31 +65,155 @@ func (h *handlers) redirect(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handlers) getCommentNotifications(w http.ResponseWriter, r *http.Request) {
-	resp, err := h.notifications.GetAllCommentNotifications(notifications.DecodeGetAllCommentNotifications(r).(*notifications.GetAllCommentNotificationsInput))
+	req, err := notifications.DecodeGetAllCommentNotifications(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	h.render(w, r, req.(*notifications.GetAllNotificationsInput), "comments")
+}
+
+func (h *handlers) getReactionNotifications(w http.ResponseWriter, r *http.Request) {
+	req, err := notifications.DecodeGetAllReactionNotifications(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	h.render(w, r, req.(*notifications.GetAllNotificationsInput), "reactions")
+}
+
+func (h *handlers) render(w http.ResponseWriter, r *http.Request, input *notifications.GetAllNotificationsInput, filter string) {
+	resp, err := h.notifications.GetAllNotifications(input)
 	if err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
 	}
 
 	if err := h.TmplRender.RenderData(w, r, "notifications_page", templates.TemplateData{
-		templates.Comments: resp.Comments,
-		templates.Form:     validator.NewForm(nil, nil),
+		templates.Notifications: resp.Notifications,
+		templates.Filter:        filter,
+		templates.Form:          validator.NewForm(nil, nil),
+		templates.Cursor:        r.URL.Query().Get("cursor"),
+		templates.NextCursor:    resp.NextCursor,
 	}); err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
 	}
 }
 
-func (h *handlers) getPostReactionNotifications(w http.ResponseWriter, r *http.Request) {
-	resp, err := h.notifications.GetAllPostReactionNotifications(notifications.DecodeGetAllPostReactionNotifications(r).(*notifications.GetAllPostReactionNotificationsInput))
+func (h *handlers) open(w http.ResponseWriter, r *http.Request) {
+	req, err := notifications.DecodeNotification(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	resp, err := h.notifications.ReadNotification(req.(*notifications.NotificationInput))
+	if errors.Is(err, domain.ErrNotificationNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	target := fmt.Sprintf("/posts?id=%d", resp.Notification.PostID)
+	if resp.Notification.CommentID != 0 {
+		target += fmt.Sprintf("#comment-%d", resp.Notification.CommentID)
+	}
+
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
+
+func (h *handlers) read(w http.ResponseWriter, r *http.Request) {
+	req, err := notifications.DecodeNotification(r)
 	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	if _, err := h.notifications.ReadNotification(req.(*notifications.NotificationInput)); errors.Is(err, domain.ErrNotificationNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
 	}
 
-	if err := h.TmplRender.RenderData(w, r, "notifications_page", templates.TemplateData{
-		templates.PostReactions: resp.PostReactions,
-		templates.Form:          validator.NewForm(nil, nil),
+	http.Redirect(w, r, backTo(r), http.StatusSeeOther)
+}
+
+func (h *handlers) readAll(w http.ResponseWriter, r *http.Request) {
+	if err := h.notifications.ReadAllNotifications(notifications.DecodeReadAllNotifications(r).(*notifications.ReadAllNotificationsInput)); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, backTo(r), http.StatusSeeOther)
+}
+
+func (h *handlers) delete(w http.ResponseWriter, r *http.Request) {
+	req, err := notifications.DecodeNotification(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	if err := h.notifications.DeleteNotification(req.(*notifications.NotificationInput)); errors.Is(err, domain.ErrNotificationNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, backTo(r), http.StatusSeeOther)
+}
+
+// backTo returns the notifications page a form was submitted from.
+func backTo(r *http.Request) string {
+	if r.PostFormValue("page") == "reactions" {
+		return "/user/notifications/reactions"
+	}
+
+	return "/user/notifications/comments"
+}
+
+func (h *handlers) preferences(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		req, err := notifications.DecodeUpdateNotificationPreferences(r)
+		if err != nil {
+			h.Exceptions.ErrBadRequestHandler(w, r)
+			return
+		}
+
+		if err := h.notifications.UpdateNotificationPreferences(req.(*notifications.UpdateNotificationPreferencesInput)); errors.Is(err, domain.ErrNotificationsBadRequest) {
+			h.Exceptions.ErrBadRequestHandler(w, r)
+			return
+		} else if err != nil {
+			h.Exceptions.ErrInternalServerHandler(w, r, err)
+			return
+		}
+
+		if err := h.SesManager.UpdateSessionFlash(r, dto.FlashPreferencesSaved); err != nil {
+			h.Exceptions.ErrInternalServerHandler(w, r, err)
+			return
+		}
+
+		http.Redirect(w, r, "/user/notifications/preferences", http.StatusSeeOther)
+		return
+	}
+
+	resp, err := h.notifications.GetNotificationPreferences(notifications.DecodeGetNotificationPreferences(r).(*notifications.GetNotificationPreferencesInput))
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.TmplRender.RenderData(w, r, "preferences_page", templates.TemplateData{
+		templates.Preferences: resp.Preferences,
 	}); err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
@@ -52,10 +52,7 @@ func (h *handlers) callback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.SesManager.DeleteActiveUserSession(resp.UserID)
-	//http.SetCookie(w, dto.DeleteCookie(sesm.SessionId))
-
-	sessionID, err := h.SesManager.CreateSession(resp.UserID)
+	sessionID, err := h.SesManager.CreateSession(r, resp.UserID)
 	if err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
@@ -13,6 +13,7 @@ import (
 	"github.com/itelman/forum/pkg/validator"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 type handlers struct {
@@ -43,6 +44,16 @@ func (h *handlers) RegisterMux(mux *http.ServeMux) {
 	for _, route := range editDeleteRoutes {
 		mux.Handle(route.Path, h.DynMiddleware.Chain(h.DynMiddleware.RequireAuthenticatedUser(h.checkPerm.CheckUserPermissions(http.HandlerFunc(route.Handler))), route.Path, route.Methods))
 	}
+
+	modRoutes := []dto.Route{
+		{Path: "/user/moderator/posts/pending", Methods: dto.GetMethod, Handler: h.getAllPending},
+		{Path: "/user/moderator/posts/approve", Methods: dto.GetMethod, Handler: h.approve},
+		{Path: "/user/moderator/posts/delete", Methods: dto.GetMethod, Handler: h.deletePending},
+	}
+
+	for _, route := range modRoutes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(h.DynMiddleware.RequireAuthenticatedUser(h.DynMiddleware.RoleAccessControl(http.HandlerFunc(route.Handler), dto.RoleModerator, dto.RoleAdmin)), route.Path, route.Methods))
+	}
 }
 
 func (h *handlers) createForm(w http.ResponseWriter, r *http.Request) {
@@ -100,6 +111,13 @@ func (h *handlers) create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if resp.Pending {
+		if err := h.SesManager.UpdateSessionFlash(r, dto.FlashPostPending); err != nil {
+			h.Exceptions.ErrInternalServerHandler(w, r, err)
+			return
+		}
+	}
+
 	http.Redirect(w, r, fmt.Sprintf("/posts?id=%d", resp.PostID), http.StatusSeeOther)
 }
 
@@ -163,6 +181,7 @@ func (h *handlers) editForm(w http.ResponseWriter, r *http.Request) {
 	autoForm := make(url.Values)
 	autoForm.Set("title", post.Title)
 	autoForm.Set("content", post.Content)
+	autoForm.Set("tags", strings.Join(post.Tags, ", "))
 
 	if err := h.TmplRender.RenderData(w, r, "edit_post_page", templates.TemplateData{
 		templates.Post: post,
@@ -200,3 +219,64 @@ func (h *handlers) edit(w http.ResponseWriter, r *http.Request) {
 
 	http.Redirect(w, r, fmt.Sprintf("/posts?id=%d", post.ID), http.StatusSeeOther)
 }
+
+func (h *handlers) getAllPending(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.posts.GetAllPendingPosts()
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.TmplRender.RenderData(w, r, "pending_posts_page", templates.TemplateData{
+		templates.Posts: resp.Posts,
+	}); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+}
+
+func (h *handlers) approve(w http.ResponseWriter, r *http.Request) {
+	req, err := posts.DecodeApprovePost(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	if err := h.posts.ApprovePost(req.(*posts.ApprovePostInput)); errors.Is(err, domain.ErrPostNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.SesManager.UpdateSessionFlash(r, dto.FlashPostApproved); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/user/moderator/posts/pending", http.StatusSeeOther)
+}
+
+func (h *handlers) deletePending(w http.ResponseWriter, r *http.Request) {
+	req, err := posts.DecodeDeletePost(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	if err := h.posts.DeletePendingPost(req.(*posts.DeletePostInput), h.postImagesDir); errors.Is(err, domain.ErrPostNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.SesManager.UpdateSessionFlash(r, dto.FlashPostRemoved); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/user/moderator/posts/pending", http.StatusSeeOther)
+}
@@ -0,0 +1,60 @@
+package profiles
+
+import (
+	"errors"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/handler"
+	"github.com/itelman/forum/internal/service/profiles"
+	"github.com/itelman/forum/internal/service/profiles/domain"
+	"github.com/itelman/forum/pkg/templates"
+	"net/http"
+)
+
+type handlers struct {
+	*handler.Handlers
+	profiles profiles.Service
+}
+
+func NewHandlers(handler *handler.Handlers, profiles profiles.Service) *handlers {
+	return &handlers{handler, profiles}
+}
+
+func (h *handlers) RegisterMux(mux *http.ServeMux) {
+	routes := []dto.Route{
+		{Path: "/users", Methods: dto.GetMethod, Handler: h.get},
+	}
+
+	for _, route := range routes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(http.HandlerFunc(route.Handler), route.Path, route.Methods))
+	}
+}
+
+func (h *handlers) get(w http.ResponseWriter, r *http.Request) {
+	req, err := profiles.DecodeGetProfile(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	resp, err := h.profiles.GetProfile(req.(*profiles.GetProfileInput))
+	if errors.Is(err, domain.ErrProfilesBadRequest) {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	} else if errors.Is(err, domain.ErrUserNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.TmplRender.RenderData(w, r, "profile_page", templates.TemplateData{
+		templates.Profile:    resp.User,
+		templates.Posts:      resp.Posts,
+		templates.Cursor:     r.URL.Query().Get("cursor"),
+		templates.NextCursor: resp.NextCursor,
+	}); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+}
@@ -39,6 +39,9 @@ func (h *commentReactionHandlers) create(w http.ResponseWriter, r *http.Request)
 	if errors.Is(err, domain.ErrCommentReactionsBadRequest) {
 		h.Exceptions.ErrBadRequestHandler(w, r)
 		return
+	} else if errors.Is(err, domain.ErrPostNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
 	} else if err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
@@ -40,6 +40,9 @@ func (h *postReactionHandlers) create(w http.ResponseWriter, r *http.Request) {
 	if err := h.postReactions.CreatePostReaction(input); errors.Is(err, domain.ErrPostReactionsBadRequest) {
 		h.Exceptions.ErrBadRequestHandler(w, r)
 		return
+	} else if errors.Is(err, domain.ErrPostNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
 	} else if err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
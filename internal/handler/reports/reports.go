@@ -0,0 +1,178 @@
+package reports
+
+import (
+	"errors"
+	"fmt"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/handler"
+	"github.com/itelman/forum/internal/service/posts"
+	postsDomain "github.com/itelman/forum/internal/service/posts/domain"
+	"github.com/itelman/forum/internal/service/reports"
+	"github.com/itelman/forum/internal/service/reports/domain"
+	"github.com/itelman/forum/pkg/templates"
+	"github.com/itelman/forum/pkg/validator"
+	"net/http"
+)
+
+type handlers struct {
+	*handler.Handlers
+	reports reports.Service
+	posts   posts.Service
+}
+
+func NewHandlers(handler *handler.Handlers, reports reports.Service, posts posts.Service) *handlers {
+	return &handlers{handler, reports, posts}
+}
+
+func (h *handlers) RegisterMux(mux *http.ServeMux) {
+	modRoutes := []dto.Route{
+		{Path: "/user/moderator/reports", Methods: dto.GetMethod, Handler: h.getAllModeratorReports},
+		{Path: "/user/moderator/posts/report", Methods: dto.GetPostMethods, Handler: h.createForm},
+	}
+
+	for _, route := range modRoutes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(h.DynMiddleware.RequireAuthenticatedUser(h.DynMiddleware.RoleAccessControl(http.HandlerFunc(route.Handler), dto.RoleModerator)), route.Path, route.Methods))
+	}
+
+	adminRoutes := []dto.Route{
+		{Path: "/user/admin/reports", Methods: dto.GetMethod, Handler: h.getAllReports},
+		{Path: "/user/admin/reports/reply", Methods: dto.PostMethod, Handler: h.reply},
+	}
+
+	for _, route := range adminRoutes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(h.DynMiddleware.RequireAuthenticatedUser(h.DynMiddleware.RoleAccessControl(http.HandlerFunc(route.Handler), dto.RoleAdmin)), route.Path, route.Methods))
+	}
+}
+
+func (h *handlers) createForm(w http.ResponseWriter, r *http.Request) {
+	postReq, err := posts.DecodeGetPost(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	postResp, err := h.posts.GetPost(postReq.(*posts.GetPostInput))
+	if errors.Is(err, postsDomain.ErrPostNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		h.create(w, r, postResp.Post)
+		return
+	}
+
+	if err := h.TmplRender.RenderData(w, r, "report_page", templates.TemplateData{
+		templates.Post: postResp.Post,
+		templates.Form: validator.NewForm(nil, nil),
+	}); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+}
+
+func (h *handlers) create(w http.ResponseWriter, r *http.Request, post *dto.Post) {
+	req, err := reports.DecodeCreateReport(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	input := req.(*reports.CreateReportInput)
+
+	if err := h.reports.CreateReport(input); errors.Is(err, domain.ErrReportsBadRequest) {
+		if err := h.TmplRender.RenderData(w, r, "report_page", templates.TemplateData{
+			templates.Post: post,
+			templates.Form: validator.NewForm(r.PostForm, input.Errors),
+		}); err != nil {
+			h.Exceptions.ErrInternalServerHandler(w, r, err)
+			return
+		}
+
+		return
+	} else if errors.Is(err, domain.ErrReportExists) {
+		if err := h.SesManager.UpdateSessionFlash(r, dto.FlashReportExists); err != nil {
+			h.Exceptions.ErrInternalServerHandler(w, r, err)
+			return
+		}
+
+		http.Redirect(w, r, fmt.Sprintf("/posts?id=%d", post.ID), http.StatusSeeOther)
+		return
+	} else if errors.Is(err, domain.ErrPostNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.SesManager.UpdateSessionFlash(r, dto.FlashReportSent); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/user/moderator/reports", http.StatusSeeOther)
+}
+
+func (h *handlers) getAllModeratorReports(w http.ResponseWriter, r *http.Request) {
+	req := reports.DecodeGetAllModeratorReports(r)
+
+	resp, err := h.reports.GetAllModeratorReports(req.(*reports.GetAllModeratorReportsInput))
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.TmplRender.RenderData(w, r, "reports_page", templates.TemplateData{
+		templates.Reports: resp.Reports,
+	}); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+}
+
+func (h *handlers) getAllReports(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.reports.GetAllReports()
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.TmplRender.RenderData(w, r, "reports_page", templates.TemplateData{
+		templates.Reports: resp.Reports,
+	}); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+}
+
+func (h *handlers) reply(w http.ResponseWriter, r *http.Request) {
+	req, err := reports.DecodeReviewReport(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	flash := dto.FlashReportReviewed
+	if err := h.reports.ReviewReport(req.(*reports.ReviewReportInput)); errors.Is(err, domain.ErrReportNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if errors.Is(err, domain.ErrReportReviewed) {
+		flash = dto.FlashReportAlreadyReviewed
+	} else if errors.Is(err, domain.ErrReportsBadRequest) {
+		flash = dto.FlashReviewEnter
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.SesManager.UpdateSessionFlash(r, flash); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/user/admin/reports", http.StatusSeeOther)
+}
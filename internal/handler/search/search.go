@@ -0,0 +1,56 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/handler"
+	"github.com/itelman/forum/internal/service/search"
+	"github.com/itelman/forum/internal/service/search/domain"
+	"github.com/itelman/forum/pkg/templates"
+	"github.com/itelman/forum/pkg/validator"
+)
+
+type handlers struct {
+	*handler.Handlers
+	search search.Service
+}
+
+func NewHandlers(handler *handler.Handlers, search search.Service) *handlers {
+	return &handlers{handler, search}
+}
+
+func (h *handlers) RegisterMux(mux *http.ServeMux) {
+	routes := []dto.Route{
+		{Path: "/search", Methods: dto.GetMethod, Handler: h.results},
+	}
+
+	for _, route := range routes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(http.HandlerFunc(route.Handler), route.Path, route.Methods))
+	}
+}
+
+func (h *handlers) results(w http.ResponseWriter, r *http.Request) {
+	input := search.DecodeSearch(r).(*search.SearchInput)
+
+	td := templates.TemplateData{
+		templates.Form: validator.NewForm(url.Values{"q": {input.Query}}, input.Errors),
+	}
+
+	if len(input.Query) != 0 {
+		resp, err := h.search.Search(input)
+		if err != nil && !errors.Is(err, domain.ErrSearchBadRequest) {
+			h.Exceptions.ErrInternalServerHandler(w, r, err)
+			return
+		} else if err == nil {
+			td[templates.Results] = resp.Results
+		}
+	}
+
+	if err := h.TmplRender.RenderData(w, r, "search_page", td); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,60 @@
+package tags
+
+import (
+	"errors"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/handler"
+	"github.com/itelman/forum/internal/service/tags"
+	"github.com/itelman/forum/internal/service/tags/domain"
+	"github.com/itelman/forum/pkg/templates"
+	"net/http"
+)
+
+type handlers struct {
+	*handler.Handlers
+	tags tags.Service
+}
+
+func NewHandlers(handler *handler.Handlers, tags tags.Service) *handlers {
+	return &handlers{handler, tags}
+}
+
+func (h *handlers) RegisterMux(mux *http.ServeMux) {
+	routes := []dto.Route{
+		{Path: "/tags", Methods: dto.GetMethod, Handler: h.get},
+	}
+
+	for _, route := range routes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(http.HandlerFunc(route.Handler), route.Path, route.Methods))
+	}
+}
+
+func (h *handlers) get(w http.ResponseWriter, r *http.Request) {
+	req, err := tags.DecodeGetTag(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	resp, err := h.tags.GetTag(req.(*tags.GetTagInput))
+	if errors.Is(err, domain.ErrTagsBadRequest) {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	} else if errors.Is(err, domain.ErrTagNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.TmplRender.RenderData(w, r, "tag_page", templates.TemplateData{
+		templates.Tag:        resp.Tag,
+		templates.Posts:      resp.Posts,
+		templates.Cursor:     r.URL.Query().Get("cursor"),
+		templates.NextCursor: resp.NextCursor,
+	}); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,103 @@
+package tokens
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/handler"
+	"github.com/itelman/forum/internal/service/tokens"
+	"github.com/itelman/forum/internal/service/tokens/domain"
+	"github.com/itelman/forum/pkg/templates"
+	"github.com/itelman/forum/pkg/validator"
+)
+
+type handlers struct {
+	*handler.Handlers
+	tokens tokens.Service
+}
+
+func NewHandlers(handler *handler.Handlers, tokens tokens.Service) *handlers {
+	return &handlers{handler, tokens}
+}
+
+func (h *handlers) RegisterMux(mux *http.ServeMux) {
+	routes := []dto.Route{
+		{Path: "/user/tokens", Methods: dto.GetMethod, Handler: h.getAll},
+		{Path: "/user/tokens/create", Methods: dto.PostMethod, Handler: h.create},
+		{Path: "/user/tokens/revoke", Methods: dto.GetMethod, Handler: h.revoke},
+	}
+
+	for _, route := range routes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(h.DynMiddleware.RequireAuthenticatedUser(http.HandlerFunc(route.Handler)), route.Path, route.Methods))
+	}
+}
+
+func (h *handlers) render(w http.ResponseWriter, r *http.Request, td templates.TemplateData) {
+	resp, err := h.tokens.GetAllTokens(tokens.DecodeGetAllTokens(r).(*tokens.GetAllTokensInput))
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+	td[templates.Tokens] = resp.Tokens
+
+	if err := h.TmplRender.RenderData(w, r, "tokens_page", td); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+}
+
+func (h *handlers) getAll(w http.ResponseWriter, r *http.Request) {
+	h.render(w, r, templates.TemplateData{
+		templates.Form: validator.NewForm(nil, nil),
+	})
+}
+
+func (h *handlers) create(w http.ResponseWriter, r *http.Request) {
+	req, err := tokens.DecodeCreateToken(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	input := req.(*tokens.CreateTokenInput)
+
+	resp, err := h.tokens.CreateToken(input)
+	if errors.Is(err, domain.ErrTokensBadRequest) {
+		h.render(w, r, templates.TemplateData{
+			templates.Form: validator.NewForm(r.PostForm, input.Errors),
+		})
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	h.render(w, r, templates.TemplateData{
+		templates.Form:  validator.NewForm(nil, nil),
+		templates.Token: resp.Token,
+	})
+}
+
+func (h *handlers) revoke(w http.ResponseWriter, r *http.Request) {
+	req, err := tokens.DecodeRevokeToken(r)
+	if err != nil {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	if err := h.tokens.RevokeToken(req.(*tokens.RevokeTokenInput)); errors.Is(err, domain.ErrTokensBadRequest) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.SesManager.UpdateSessionFlash(r, dto.FlashTokenRevoked); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/user/tokens", http.StatusSeeOther)
+}
@@ -3,8 +3,11 @@ package middleware
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/itelman/forum/internal/dto"
 	"github.com/itelman/forum/internal/exception"
+	"github.com/itelman/forum/internal/service/tokens"
+	tokensDomain "github.com/itelman/forum/internal/service/tokens/domain"
 	"github.com/itelman/forum/internal/service/users"
 	"github.com/itelman/forum/pkg/sesm"
 	"net/http"
@@ -29,24 +32,65 @@ type AuthMiddleware interface {
 
 type middleware struct {
 	users      users.Service
+	tokens     tokens.Service
 	sesManager sesm.SessionManager
 	exceptions exception.Exceptions
+	limiter    *RateLimiter
+}
+
+// RateLimiter holds the request budget of every session and token. Middlewares
+// that share one count a session's requests together, whichever routes they
+// guard.
+type RateLimiter struct {
+	buckets       map[string]chan time.Time
+	blockedTokens map[int]time.Time
+	mutex         sync.RWMutex
+}
 
-	limiters map[string]chan time.Time
-	mutex    sync.RWMutex
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		buckets:       make(map[string]chan time.Time),
+		blockedTokens: make(map[int]time.Time),
+	}
 }
 
-func NewMiddleware(users users.Service, sesManager sesm.SessionManager, exceptions exception.Exceptions) *middleware {
-	return &middleware{
+func NewMiddleware(users users.Service, sesManager sesm.SessionManager, exceptions exception.Exceptions, opts ...Option) *middleware {
+	mid := &middleware{
 		users:      users,
 		sesManager: sesManager,
 		exceptions: exceptions,
-		limiters:   make(map[string]chan time.Time),
+		limiter:    NewRateLimiter(),
+	}
+	for _, opt := range opts {
+		opt(mid)
+	}
+
+	return mid
+}
+
+type Option func(*middleware)
+
+func WithTokens(tokens tokens.Service) Option {
+	return func(m *middleware) {
+		m.tokens = tokens
+	}
+}
+
+// WithRateLimiter makes the middleware draw from limiter instead of a budget
+// of its own.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(m *middleware) {
+		m.limiter = limiter
 	}
 }
 
 func (m *middleware) Authenticate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.tokens != nil && len(r.Header.Get("Authorization")) != 0 {
+			m.authenticateToken(w, r, next)
+			return
+		}
+
 		errInternalSrvResp := func(err error) {
 			m.sesManager.DeleteCurrentSession(r)
 			http.SetCookie(w, dto.DeleteCookie(sesm.SessionId))
@@ -92,11 +136,73 @@ func (m *middleware) Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), dto.ContextKeyUser, resp.User)
+		ctx, err := m.userContext(r, resp.User)
+		if err != nil {
+			errInternalSrvResp(err)
+			return
+		}
+
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+func (m *middleware) authenticateToken(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	req, err := tokens.DecodeAuthenticateToken(r)
+	if err != nil {
+		m.exceptions.ErrUnauthorizedHandler(w, r)
+		return
+	}
+
+	tokenResp, err := m.tokens.AuthenticateToken(req.(*tokens.AuthenticateTokenInput))
+	if errors.Is(err, tokensDomain.ErrTokenNotFound) {
+		m.exceptions.ErrUnauthorizedHandler(w, r)
+		return
+	} else if err != nil {
+		m.exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if tokenResp.Token.Scope == dto.ScopeRead && !(r.Method == http.MethodGet || r.Method == http.MethodHead) {
+		m.exceptions.ErrForbiddenHandler(w, r)
+		return
+	}
+
+	if err := m.tokenRateLimiting(tokenResp.Token.ID); errors.Is(err, ErrTooManyRequests) {
+		m.exceptions.ErrTooManyRequestsHandler(w, r)
+		return
+	} else if err != nil {
+		m.exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	userResp, err := m.users.GetUser(&users.GetUserInput{ID: tokenResp.Token.UserID})
+	if err != nil {
+		m.exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	ctx, err := m.userContext(r, userResp.User)
+	if err != nil {
+		m.exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+func (m *middleware) userContext(r *http.Request, user *dto.User) (context.Context, error) {
+	roleResp, err := m.users.GetUserRole(&users.GetUserRoleInput{UserID: user.ID})
+	if err != nil {
+		return nil, err
+	}
+	user.Role = roleResp.Role
+
+	ctx := context.WithValue(r.Context(), dto.ContextKeyUser, user)
+	ctx = context.WithValue(ctx, dto.ContextKeyRole, user.Role)
+
+	return ctx, nil
+}
+
 func (m *middleware) checkSessionActivity(r *http.Request) error {
 	lastRequest, err := m.sesManager.GetSessionData(r, sesm.LastRequest)
 	if err != nil {
@@ -115,9 +221,9 @@ func (m *middleware) checkSessionActivity(r *http.Request) error {
 }
 
 func (m *middleware) setRateLimiter(sessionId string) {
-	m.mutex.RLock()
-	limiter, exists := m.limiters[sessionId]
-	m.mutex.RUnlock()
+	m.limiter.mutex.RLock()
+	limiter, exists := m.limiter.buckets[sessionId]
+	m.limiter.mutex.RUnlock()
 
 	if !exists {
 		// Create a new rate limiter for this session
@@ -136,9 +242,9 @@ func (m *middleware) setRateLimiter(sessionId string) {
 			}
 		}()
 
-		m.mutex.Lock()
-		m.limiters[sessionId] = limiter
-		m.mutex.Unlock()
+		m.limiter.mutex.Lock()
+		m.limiter.buckets[sessionId] = limiter
+		m.limiter.mutex.Unlock()
 	}
 }
 
@@ -167,10 +273,10 @@ func (m *middleware) sessionRateLimiting(r *http.Request) error {
 		blockTimestamp := btVal.(time.Time)
 
 		if lastRequest.Sub(blockTimestamp) >= sesBlockTime {
-			m.mutex.Lock()
+			m.limiter.mutex.Lock()
 			//delete(m.blockedSessions, sessionId)
-			delete(m.limiters, sessionId)
-			m.mutex.Unlock()
+			delete(m.limiter.buckets, sessionId)
+			m.limiter.mutex.Unlock()
 
 			return ErrSessionExpired
 		} else {
@@ -180,11 +286,11 @@ func (m *middleware) sessionRateLimiting(r *http.Request) error {
 
 	m.setRateLimiter(sessionId)
 
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
+	m.limiter.mutex.Lock()
+	defer m.limiter.mutex.Unlock()
 
 	select {
-	case <-m.limiters[sessionId]:
+	case <-m.limiter.buckets[sessionId]:
 		return nil
 	default:
 		if err := m.sesManager.BlockSession(r); err != nil {
@@ -195,3 +301,33 @@ func (m *middleware) sessionRateLimiting(r *http.Request) error {
 	}
 
 }
+
+func (m *middleware) tokenRateLimiting(tokenId int) error {
+	limiterId := fmt.Sprintf("token_%d", tokenId)
+
+	m.limiter.mutex.Lock()
+	blockTimestamp, blocked := m.limiter.blockedTokens[tokenId]
+	if blocked && time.Now().Sub(blockTimestamp) >= sesBlockTime {
+		delete(m.limiter.blockedTokens, tokenId)
+		delete(m.limiter.buckets, limiterId)
+		blocked = false
+	}
+	m.limiter.mutex.Unlock()
+
+	if blocked {
+		return ErrTooManyRequests
+	}
+
+	m.setRateLimiter(limiterId)
+
+	m.limiter.mutex.Lock()
+	defer m.limiter.mutex.Unlock()
+
+	select {
+	case <-m.limiter.buckets[limiterId]:
+		return nil
+	default:
+		m.limiter.blockedTokens[tokenId] = time.Now()
+		return ErrTooManyRequests
+	}
+}
@@ -31,9 +31,16 @@ func (h *handlers) RegisterMux(mux *http.ServeMux) {
 		mux.Handle(route.Path, h.DynMiddleware.Chain(h.DynMiddleware.ForbidAuthenticatedUser(http.HandlerFunc(route.Handler)), route.Path, route.Methods))
 	}
 
-	logoutRoute := dto.Route{"/user/logout", dto.PostMethod, h.logout}
-	mux.Handle(logoutRoute.Path, h.DynMiddleware.Chain(h.DynMiddleware.RequireAuthenticatedUser(http.HandlerFunc(logoutRoute.Handler)), logoutRoute.Path, logoutRoute.Methods))
+	userRoutes := []dto.Route{
+		{Path: "/user/logout", Methods: dto.PostMethod, Handler: h.logout},
+		{Path: "/user/sessions", Methods: dto.GetMethod, Handler: h.getAllSessions},
+		{Path: "/user/sessions/revoke", Methods: dto.GetMethod, Handler: h.revokeSession},
+		{Path: "/user/sessions/revoke-all", Methods: dto.PostMethod, Handler: h.revokeAllSessions},
+	}
 
+	for _, route := range userRoutes {
+		mux.Handle(route.Path, h.DynMiddleware.Chain(h.DynMiddleware.RequireAuthenticatedUser(http.HandlerFunc(route.Handler)), route.Path, route.Methods))
+	}
 }
 
 func (h *handlers) signupGet(w http.ResponseWriter, r *http.Request) {
@@ -115,10 +122,7 @@ func (h *handlers) loginPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.SesManager.DeleteActiveUserSession(resp.UserID)
-	//http.SetCookie(w, dto.DeleteCookie(sesm.SessionId))
-
-	sessionID, err := h.SesManager.CreateSession(resp.UserID)
+	sessionID, err := h.SesManager.CreateSession(r, resp.UserID)
 	if err != nil {
 		h.Exceptions.ErrInternalServerHandler(w, r, err)
 		return
@@ -137,3 +141,70 @@ func (h *handlers) logout(w http.ResponseWriter, r *http.Request) {
 	http.SetCookie(w, dto.DeleteCookie(sesm.SessionId))
 	http.Redirect(w, r, "/user/login", http.StatusSeeOther)
 }
+
+func (h *handlers) getAllSessions(w http.ResponseWriter, r *http.Request) {
+	sessions, err := h.SesManager.GetUserSessions(dto.GetAuthUser(r).ID)
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	sessionId, err := h.SesManager.CurrentSessionID(r)
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.TmplRender.RenderData(w, r, "sessions_page", templates.TemplateData{
+		templates.Sessions:       sessions,
+		templates.CurrentSession: sesm.SessionKey(sessionId),
+	}); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+}
+
+func (h *handlers) revokeSession(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("key")
+	if len(key) == 0 {
+		h.Exceptions.ErrBadRequestHandler(w, r)
+		return
+	}
+
+	sessionId, err := h.SesManager.CurrentSessionID(r)
+	if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if err := h.SesManager.DeleteUserSession(dto.GetAuthUser(r).ID, key); errors.Is(err, sesm.ErrSessionNotFound) {
+		h.Exceptions.ErrNotFoundHandler(w, r)
+		return
+	} else if err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	if key == sesm.SessionKey(sessionId) {
+		http.SetCookie(w, dto.DeleteCookie(sesm.SessionId))
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := h.SesManager.UpdateSessionFlash(r, dto.FlashSessionRevoked); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/user/sessions", http.StatusSeeOther)
+}
+
+func (h *handlers) revokeAllSessions(w http.ResponseWriter, r *http.Request) {
+	if err := h.SesManager.DeleteAllUserSessions(dto.GetAuthUser(r).ID); err != nil {
+		h.Exceptions.ErrInternalServerHandler(w, r, err)
+		return
+	}
+
+	http.SetCookie(w, dto.DeleteCookie(sesm.SessionId))
+	http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+}
@@ -12,7 +12,7 @@ type DynamicMiddleware interface {
 	Chain(next http.Handler, path string, methods []string) http.Handler
 	RequireAuthenticatedUser(next http.Handler) http.Handler
 	ForbidAuthenticatedUser(next http.Handler) http.Handler
-	RoleAccessControl(next http.Handler, role string) http.Handler
+	RoleAccessControl(next http.Handler, roles ...string) http.Handler
 }
 
 type middleware struct {
@@ -74,13 +74,16 @@ func (m *middleware) ForbidAuthenticatedUser(next http.Handler) http.Handler {
 	})
 }
 
-func (m *middleware) RoleAccessControl(next http.Handler, role string) http.Handler {
+func (m *middleware) RoleAccessControl(next http.Handler, roles ...string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if dto.GetUserRole(r) != role {
-			m.exceptions.ErrForbiddenHandler(w, r)
-			return
+		role := dto.GetUserRole(r)
+		for _, allowed := range roles {
+			if role == allowed {
+				next.ServeHTTP(w, r)
+				return
+			}
 		}
 
-		next.ServeHTTP(w, r)
+		m.exceptions.ErrForbiddenHandler(w, r)
 	})
 }
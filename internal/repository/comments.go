@@ -0,0 +1,43 @@
+package repository
+
+import "errors"
+
+type CreateCommentInput struct {
+	PostID  int
+	UserID  int
+	Content string
+}
+
+type GetCommentInput struct {
+	ID         int
+	AuthUserID int
+}
+
+type GetAllCommentsForPostInput struct {
+	PostID         int
+	AuthUserID     int
+	SortedByNewest bool
+}
+
+type GetAllCommentsForPostByUserInput struct {
+	PostID         int
+	AuthUserID     int
+	SortedByNewest bool
+}
+
+type UpdateCommentInput struct {
+	ID      int
+	Content string
+}
+
+type UpdateCommentReactionsCountInput struct {
+	CommentID int
+}
+
+type DeleteCommentInput struct {
+	ID int
+}
+
+var (
+	ErrCommentNotFound = errors.New("DATABASE: Comment not found")
+)
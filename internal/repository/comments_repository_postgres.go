@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/itelman/forum/internal/dto"
+)
+
+const selectCommentsPostgres = "SELECT comments.id, comments.post_id, COALESCE(comment_replies.parent_id, 0) AS parent_id, users.id, users.username, comments.content, (SELECT COALESCE(STRING_AGG(u.username, ' '), '') FROM comment_mentions cm INNER JOIN users u ON cm.user_id = u.id WHERE cm.comment_id = comments.id) AS mentions, comments.likes, comments.dislikes, comments.created, COALESCE(comment_reactions.is_like, -1) AS is_like FROM comments INNER JOIN users ON comments.user_id = users.id LEFT JOIN comment_replies ON comments.id = comment_replies.comment_id LEFT JOIN comment_reactions ON comment_reactions.comment_id = comments.id AND comment_reactions.user_id = $1"
+
+type CommentsRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewCommentsRepositoryPostgres(db *sql.DB) *CommentsRepositoryPostgres {
+	return &CommentsRepositoryPostgres{db}
+}
+
+func (r *CommentsRepositoryPostgres) Create(tx *sql.Tx, input CreateCommentInput) (int, error) {
+	query := "INSERT INTO comments (post_id, user_id, content) VALUES ($1, $2, $3) RETURNING id"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return -1, err
+	}
+	defer stmt.Close()
+
+	var id int
+	if err := stmt.QueryRow(input.PostID, input.UserID, input.Content).Scan(&id); err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
+
+func (r *CommentsRepositoryPostgres) Get(input GetCommentInput) (*dto.Comment, error) {
+	query := selectCommentsPostgres + " WHERE comments.id = $2"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	comment, err := scanComment(stmt.QueryRow(input.AuthUserID, input.ID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCommentNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (r *CommentsRepositoryPostgres) GetAllForPost(input GetAllCommentsForPostInput) ([]*dto.Comment, error) {
+	query := selectCommentsPostgres + " WHERE comments.post_id = $2"
+	if input.SortedByNewest {
+		query += " ORDER BY comments.created DESC"
+	}
+
+	return r.getMany(query, input.AuthUserID, input.PostID)
+}
+
+func (r *CommentsRepositoryPostgres) GetAllForPostByUser(input GetAllCommentsForPostByUserInput) ([]*dto.Comment, error) {
+	query := selectCommentsPostgres + " WHERE comments.post_id = $2 AND comments.user_id = $1"
+	if input.SortedByNewest {
+		query += " ORDER BY comments.created DESC"
+	}
+
+	return r.getMany(query, input.AuthUserID, input.PostID)
+}
+
+func (r *CommentsRepositoryPostgres) Update(tx *sql.Tx, input UpdateCommentInput) error {
+	query := "UPDATE comments SET content = $1, edited = " + nowUTC + " WHERE id = $2"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.Content, input.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *CommentsRepositoryPostgres) UpdateReactionsCount(tx *sql.Tx, input UpdateCommentReactionsCountInput) error {
+	query := "UPDATE comments SET likes = (SELECT COUNT(*) FROM comment_reactions WHERE comment_id = comments.id AND is_like = 1), dislikes = (SELECT COUNT(*) FROM comment_reactions WHERE comment_id = comments.id AND is_like = 0) WHERE id = $1"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.CommentID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the comment together with all of its replies.
+func (r *CommentsRepositoryPostgres) Delete(input DeleteCommentInput) error {
+	query := "WITH RECURSIVE thread(id) AS (SELECT $1::INTEGER UNION ALL SELECT comment_replies.comment_id FROM comment_replies INNER JOIN thread ON comment_replies.parent_id = thread.id) DELETE FROM comments WHERE id IN (SELECT id FROM thread)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *CommentsRepositoryPostgres) getMany(query string, args ...interface{}) ([]*dto.Comment, error) {
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []*dto.Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
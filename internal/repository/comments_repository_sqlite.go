@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/itelman/forum/internal/dto"
+)
+
+const selectComments = "SELECT comments.id, comments.post_id, COALESCE(comment_replies.parent_id, 0) AS parent_id, users.id, users.username, comments.content, (SELECT COALESCE(GROUP_CONCAT(u.username, ' '), '') FROM comment_mentions cm INNER JOIN users u ON cm.user_id = u.id WHERE cm.comment_id = comments.id) AS mentions, comments.likes, comments.dislikes, comments.created, COALESCE(comment_reactions.is_like, -1) AS is_like FROM comments INNER JOIN users ON comments.user_id = users.id LEFT JOIN comment_replies ON comments.id = comment_replies.comment_id LEFT JOIN comment_reactions ON comment_reactions.comment_id = comments.id AND comment_reactions.user_id = ?"
+
+type CommentsRepositorySqlite struct {
+	db *sql.DB
+}
+
+func NewCommentsRepositorySqlite(db *sql.DB) *CommentsRepositorySqlite {
+	return &CommentsRepositorySqlite{db}
+}
+
+func (r *CommentsRepositorySqlite) Create(tx *sql.Tx, input CreateCommentInput) (int, error) {
+	query := "INSERT INTO comments (post_id, user_id, content) VALUES (?, ?, ?)"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return -1, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(input.PostID, input.UserID, input.Content)
+	if err != nil {
+		return -1, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, err
+	}
+
+	return int(id), nil
+}
+
+func (r *CommentsRepositorySqlite) Get(input GetCommentInput) (*dto.Comment, error) {
+	query := selectComments + " WHERE comments.id = ?"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	comment, err := scanComment(stmt.QueryRow(input.AuthUserID, input.ID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCommentNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (r *CommentsRepositorySqlite) GetAllForPost(input GetAllCommentsForPostInput) ([]*dto.Comment, error) {
+	query := selectComments + " WHERE comments.post_id = ?"
+	if input.SortedByNewest {
+		query += " ORDER BY comments.created DESC"
+	}
+
+	return r.getMany(query, input.AuthUserID, input.PostID)
+}
+
+func (r *CommentsRepositorySqlite) GetAllForPostByUser(input GetAllCommentsForPostByUserInput) ([]*dto.Comment, error) {
+	query := selectComments + " WHERE comments.post_id = ? AND comments.user_id = ?"
+	if input.SortedByNewest {
+		query += " ORDER BY comments.created DESC"
+	}
+
+	return r.getMany(query, input.AuthUserID, input.PostID, input.AuthUserID)
+}
+
+func (r *CommentsRepositorySqlite) Update(tx *sql.Tx, input UpdateCommentInput) error {
+	query := "UPDATE comments SET content = ?, edited = CURRENT_TIMESTAMP WHERE id = ?"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(input.Content, input.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *CommentsRepositorySqlite) UpdateReactionsCount(tx *sql.Tx, input UpdateCommentReactionsCountInput) error {
+	query := "UPDATE comments SET likes = (SELECT COUNT(*) FROM comment_reactions WHERE comment_id = comments.id AND is_like = 1), dislikes = (SELECT COUNT(*) FROM comment_reactions WHERE comment_id = comments.id AND is_like = 0) WHERE id = ?"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.CommentID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the comment together with all of its replies.
+func (r *CommentsRepositorySqlite) Delete(input DeleteCommentInput) error {
+	query := "WITH RECURSIVE thread(id) AS (SELECT ? UNION ALL SELECT comment_replies.comment_id FROM comment_replies INNER JOIN thread ON comment_replies.parent_id = thread.id) DELETE FROM comments WHERE id IN (SELECT id FROM thread)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(input.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *CommentsRepositorySqlite) getMany(query string, args ...interface{}) ([]*dto.Comment, error) {
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []*dto.Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func scanComment(row rowScanner) (*dto.Comment, error) {
+	comment := &dto.Comment{User: &dto.User{}}
+	var mentions string
+
+	if err := row.Scan(
+		&comment.ID,
+		&comment.PostID,
+		&comment.ParentID,
+		&comment.User.ID,
+		&comment.User.Username,
+		&comment.Content,
+		&mentions,
+		&comment.Likes,
+		&comment.Dislikes,
+		&comment.Created,
+		&comment.AuthUserReaction,
+	); err != nil {
+		return nil, err
+	}
+	comment.Mentions = strings.Fields(mentions)
+
+	return comment, nil
+}
@@ -0,0 +1,81 @@
+package repository_test
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/repositorytest"
+	categoriesAdapters "github.com/itelman/forum/internal/service/categories/adapters"
+	commentsAdapters "github.com/itelman/forum/internal/service/comments/adapters"
+	digestsAdapters "github.com/itelman/forum/internal/service/digests/adapters"
+	moderationAdapters "github.com/itelman/forum/internal/service/moderation/adapters"
+	oauthAdapters "github.com/itelman/forum/internal/service/oauth/adapters"
+	postsAdapters "github.com/itelman/forum/internal/service/posts/adapters"
+	reportsAdapters "github.com/itelman/forum/internal/service/reports/adapters"
+	searchAdapters "github.com/itelman/forum/internal/service/search/adapters"
+	tagsAdapters "github.com/itelman/forum/internal/service/tags/adapters"
+	tokensAdapters "github.com/itelman/forum/internal/service/tokens/adapters"
+	"github.com/itelman/forum/pkg/sesm"
+	"github.com/itelman/forum/pkg/sqlite"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestSqliteConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) *repositorytest.Backend {
+		db, err := sqlite.NewSqlite(filepath.Join(t.TempDir(), "forum.db") + "?parseTime=true&_foreign_keys=on")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		if err := sqlite.CheckFeatures(db); errors.Is(err, sqlite.ErrNoFTS5) || errors.Is(err, sqlite.ErrNoMathFunctions) {
+			t.Skipf("%v; run make test", err)
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := sqlite.Migrate(db, "../../migrations/sqlite/"); err != nil {
+			t.Fatal(err)
+		}
+
+		return sqliteBackend(t, db)
+	})
+}
+
+func sqliteBackend(t *testing.T, db *sql.DB) *repositorytest.Backend {
+	sessions := sesm.NewSqliteSessionManager(db, time.Hour)
+	t.Cleanup(sessions.Close)
+
+	return &repositorytest.Backend{
+		DB:                      db,
+		Users:                   repository.NewUsersRepositorySqlite(db),
+		Posts:                   repository.NewPostsRepositorySqlite(db),
+		Comments:                repository.NewCommentsRepositorySqlite(db),
+		PostReactions:           repository.NewPostReactionsRepositorySqlite(db),
+		CommentReactions:        repository.NewCommentReactionsRepositorySqlite(db),
+		PostMentions:            repository.NewPostMentionsRepositorySqlite(db),
+		CommentMentions:         repository.NewCommentMentionsRepositorySqlite(db),
+		PostCategories:          repository.NewPostCategoriesRepositorySqlite(db),
+		UserRoles:               repository.NewUserRolesRepositorySqlite(db),
+		Notifications:           repository.NewNotificationsRepositorySqlite(db),
+		NotificationPreferences: repository.NewNotificationPreferencesRepositorySqlite(db),
+		Categories:              categoriesAdapters.NewCategoriesRepositorySqlite(db),
+		CommentReplies:          commentsAdapters.NewCommentRepliesRepositorySqlite(db),
+		DigestSubscriptions:     digestsAdapters.NewDigestSubscriptionsRepositorySqlite(db),
+		Requests:                moderationAdapters.NewRequestsRepositorySqlite(db),
+		UsersOAuth:              oauthAdapters.NewUsersOAuthRepositorySqlite(db),
+		Images:                  postsAdapters.NewImagesRepositorySqlite(db),
+		PendingPosts:            postsAdapters.NewPendingPostsRepositorySqlite(db),
+		PostTags:                postsAdapters.NewPostTagsRepositorySqlite(db),
+		Reports:                 reportsAdapters.NewReportsRepositorySqlite(db),
+		SearchPosts:             searchAdapters.NewPostsRepositorySqlite(db),
+		SearchComments:          searchAdapters.NewCommentsRepositorySqlite(db),
+		Tags:                    tagsAdapters.NewTagsRepositorySqlite(db),
+		Tokens:                  tokensAdapters.NewTokensRepositorySqlite(db),
+		Sessions:                sessions,
+	}
+}
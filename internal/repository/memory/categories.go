@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"database/sql"
+	"sort"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	categoriesdomain "github.com/itelman/forum/internal/service/categories/domain"
+)
+
+type CategoriesRepository struct {
+	store *Store
+}
+
+func NewCategoriesRepository(store *Store) *CategoriesRepository {
+	return &CategoriesRepository{store}
+}
+
+func (r *CategoriesRepository) Create(input categoriesdomain.CreateCategoryInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, c := range t.categories {
+		if c.name == input.Name {
+			return categoriesdomain.ErrCategoryExists
+		}
+	}
+
+	t.categories = append(t.categories, category{id: t.nextID("categories"), name: input.Name, created: now()})
+
+	return nil
+}
+
+func (r *CategoriesRepository) Get(input categoriesdomain.GetCategoryInput) (*dto.Category, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, c := range t.categories {
+		if c.id == input.ID {
+			return &dto.Category{ID: c.id, Name: c.name, Created: c.created}, nil
+		}
+	}
+
+	return nil, categoriesdomain.ErrCategoryNotFound
+}
+
+func (r *CategoriesRepository) GetAll(input categoriesdomain.GetAllCategoriesInput) ([]*dto.Category, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	categories := []*dto.Category{}
+	for _, c := range t.categories {
+		count := 0
+		for _, pc := range t.postCategories {
+			if pc.categoryID == c.id {
+				count++
+			}
+		}
+
+		categories = append(categories, &dto.Category{ID: c.id, Name: c.name, PostsCount: count, Created: c.created})
+	}
+
+	if input.SortedByNewest {
+		sort.SliceStable(categories, func(i, j int) bool {
+			return categories[i].Created.After(categories[j].Created)
+		})
+	}
+
+	return categories, nil
+}
+
+func (r *CategoriesRepository) Delete(tx *sql.Tx, input categoriesdomain.DeleteCategoryInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	t.categories = filter(t.categories, func(c category) bool { return c.id != input.ID })
+	t.postCategories = filter(t.postCategories, func(pc postCategory) bool { return pc.categoryID != input.ID })
+
+	return nil
+}
+
+// PostCategoriesRepository serves both the posts and the categories services.
+type PostCategoriesRepository struct {
+	store *Store
+}
+
+func NewPostCategoriesRepository(store *Store) *PostCategoriesRepository {
+	return &PostCategoriesRepository{store}
+}
+
+func (r *PostCategoriesRepository) Create(tx *sql.Tx, input repository.CreatePostCategoriesInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, catgId := range input.CategoriesID {
+		if !t.hasCategory(catgId) {
+			return repository.ErrUnknownCategory
+		}
+
+		t.postCategories = append(t.postCategories, postCategory{postID: input.PostID, categoryID: catgId})
+	}
+
+	return nil
+}
+
+func (r *PostCategoriesRepository) GetAllForPost(input repository.GetPostCategoriesInput) ([]string, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	names := []string{}
+	for _, pc := range t.postCategories {
+		if pc.postID != input.PostID {
+			continue
+		}
+
+		for _, c := range t.categories {
+			if c.id == pc.categoryID {
+				names = append(names, c.name)
+			}
+		}
+	}
+
+	return names, nil
+}
+
+func (r *PostCategoriesRepository) Reassign(tx *sql.Tx, input repository.ReassignPostCategoriesInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, pc := range t.postCategories {
+		if pc.categoryID != input.FromCategoryID || t.hasPostCategory(pc.postID, input.ToCategoryID) {
+			continue
+		}
+
+		t.postCategories = append(t.postCategories, postCategory{postID: pc.postID, categoryID: input.ToCategoryID})
+	}
+
+	return nil
+}
+
+func (t *tables) hasCategory(id int) bool {
+	for _, c := range t.categories {
+		if c.id == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *tables) hasPostCategory(postId, categoryId int) bool {
+	for _, pc := range t.postCategories {
+		if pc.postID == postId && pc.categoryID == categoryId {
+			return true
+		}
+	}
+
+	return false
+}
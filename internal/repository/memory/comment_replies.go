@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"database/sql"
+
+	commentsdomain "github.com/itelman/forum/internal/service/comments/domain"
+)
+
+type CommentRepliesRepository struct {
+	store *Store
+}
+
+func NewCommentRepliesRepository(store *Store) *CommentRepliesRepository {
+	return &CommentRepliesRepository{store}
+}
+
+func (r *CommentRepliesRepository) Create(tx *sql.Tx, input commentsdomain.CreateCommentReplyInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if _, ok := t.commentReplies[input.CommentID]; ok || t.comment(input.CommentID) == nil || t.comment(input.ParentID) == nil {
+		return ErrConstraint
+	}
+	t.commentReplies[input.CommentID] = input.ParentID
+
+	return nil
+}
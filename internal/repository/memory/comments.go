@@ -0,0 +1,171 @@
+package memory
+
+import (
+	"database/sql"
+	"sort"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type CommentsRepository struct {
+	store *Store
+}
+
+func NewCommentsRepository(store *Store) *CommentsRepository {
+	return &CommentsRepository{store}
+}
+
+func (r *CommentsRepository) Create(tx *sql.Tx, input repository.CreateCommentInput) (int, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if t.post(input.PostID) == nil || t.user(input.UserID) == nil {
+		return -1, ErrConstraint
+	}
+
+	id := t.nextID("comments")
+	t.comments = append(t.comments, comment{id: id, postID: input.PostID, userID: input.UserID, content: input.Content, created: now()})
+
+	return id, nil
+}
+
+func (r *CommentsRepository) Get(input repository.GetCommentInput) (*dto.Comment, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if c := t.comment(input.ID); c != nil {
+		return t.commentDto(*c, input.AuthUserID), nil
+	}
+
+	return nil, repository.ErrCommentNotFound
+}
+
+func (r *CommentsRepository) GetAllForPost(input repository.GetAllCommentsForPostInput) ([]*dto.Comment, error) {
+	return r.getMany(input.AuthUserID, input.SortedByNewest, func(c comment) bool {
+		return c.postID == input.PostID
+	})
+}
+
+func (r *CommentsRepository) GetAllForPostByUser(input repository.GetAllCommentsForPostByUserInput) ([]*dto.Comment, error) {
+	return r.getMany(input.AuthUserID, input.SortedByNewest, func(c comment) bool {
+		return c.postID == input.PostID && c.userID == input.AuthUserID
+	})
+}
+
+func (r *CommentsRepository) Update(tx *sql.Tx, input repository.UpdateCommentInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if c := t.comment(input.ID); c != nil {
+		c.content = input.Content
+	}
+
+	return nil
+}
+
+func (r *CommentsRepository) UpdateReactionsCount(tx *sql.Tx, input repository.UpdateCommentReactionsCountInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if c := t.comment(input.CommentID); c != nil {
+		c.likes, c.dislikes = countReactions(t.commentReactions, input.CommentID)
+	}
+
+	return nil
+}
+
+func (r *CommentsRepository) Delete(input repository.DeleteCommentInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	t.deleteComment(input.ID)
+
+	return nil
+}
+
+func (r *CommentsRepository) getMany(authUserId int, sortedByNewest bool, match func(c comment) bool) ([]*dto.Comment, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	comments := []*dto.Comment{}
+	for _, c := range t.comments {
+		if match(c) {
+			comments = append(comments, t.commentDto(c, authUserId))
+		}
+	}
+
+	if sortedByNewest {
+		sort.SliceStable(comments, func(i, j int) bool {
+			return comments[i].Created.After(comments[j].Created)
+		})
+	}
+
+	return comments, nil
+}
+
+func (t *tables) commentDto(c comment, authUserId int) *dto.Comment {
+	author := t.user(c.userID)
+
+	reaction := -1
+	for _, cr := range t.commentReactions {
+		if cr.targetID == c.id && cr.userID == authUserId {
+			reaction = cr.isLike
+		}
+	}
+
+	return &dto.Comment{
+		ID:               c.id,
+		PostID:           c.postID,
+		ParentID:         t.commentReplies[c.id],
+		User:             &dto.User{ID: author.id, Username: author.username},
+		Content:          c.content,
+		Mentions:         t.mentionedUsernames(t.commentMentions, c.id),
+		Likes:            c.likes,
+		Dislikes:         c.dislikes,
+		Created:          c.created,
+		AuthUserReaction: reaction,
+	}
+}
+
+func (t *tables) comment(id int) *comment {
+	for i := range t.comments {
+		if t.comments[i].id == id {
+			return &t.comments[i]
+		}
+	}
+
+	return nil
+}
+
+// deleteComment removes a comment with its replies and everything that
+// references them.
+func (t *tables) deleteComment(id int) {
+	thread := []int{id}
+	for i := 0; i < len(thread); i++ {
+		for commentId, parentId := range t.commentReplies {
+			if parentId == thread[i] {
+				thread = append(thread, commentId)
+			}
+		}
+	}
+
+	for _, commentId := range thread {
+		t.comments = filter(t.comments, func(c comment) bool { return c.id != commentId })
+		delete(t.commentReplies, commentId)
+		t.commentMentions = filter(t.commentMentions, func(m mention) bool { return m.targetID != commentId })
+		t.commentReactions = filter(t.commentReactions, func(cr reaction) bool { return cr.targetID != commentId })
+		t.notifications = filter(t.notifications, func(n notification) bool { return n.commentID != commentId })
+	}
+}
+
+func (t *tables) mentionedUsernames(mentions []mention, targetId int) []string {
+	var usernames []string
+	for _, m := range mentions {
+		if m.targetID == targetId {
+			usernames = append(usernames, t.user(m.userID).username)
+		}
+	}
+
+	return usernames
+}
@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/itelman/forum/internal/repository/repositorytest"
+	"github.com/itelman/forum/pkg/sesm"
+)
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) *repositorytest.Backend {
+		store := NewStore()
+
+		return &repositorytest.Backend{
+			DB:                      store.DB(),
+			Users:                   NewUsersRepository(store),
+			Posts:                   NewPostsRepository(store),
+			Comments:                NewCommentsRepository(store),
+			PostReactions:           NewPostReactionsRepository(store),
+			CommentReactions:        NewCommentReactionsRepository(store),
+			PostMentions:            NewPostMentionsRepository(store),
+			CommentMentions:         NewCommentMentionsRepository(store),
+			PostCategories:          NewPostCategoriesRepository(store),
+			UserRoles:               NewUserRolesRepository(store),
+			Notifications:           NewNotificationsRepository(store),
+			NotificationPreferences: NewNotificationPreferencesRepository(store),
+			Categories:              NewCategoriesRepository(store),
+			CommentReplies:          NewCommentRepliesRepository(store),
+			DigestSubscriptions:     NewDigestSubscriptionsRepository(store),
+			Requests:                NewRequestsRepository(store),
+			UsersOAuth:              NewUsersOAuthRepository(store),
+			Images:                  NewImagesRepository(store),
+			PendingPosts:            NewPendingPostsRepository(store),
+			PostTags:                NewPostTagsRepository(store),
+			Reports:                 NewReportsRepository(store),
+			SearchPosts:             NewSearchPostsRepository(store),
+			SearchComments:          NewSearchCommentsRepository(store),
+			Tags:                    NewTagsRepository(store),
+			Tokens:                  NewTokensRepository(store),
+			Sessions:                sesm.NewSessionManager(),
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+	digestsdomain "github.com/itelman/forum/internal/service/digests/domain"
+)
+
+type DigestSubscriptionsRepository struct {
+	store *Store
+}
+
+func NewDigestSubscriptionsRepository(store *Store) *DigestSubscriptionsRepository {
+	return &DigestSubscriptionsRepository{store}
+}
+
+func (r *DigestSubscriptionsRepository) Get(input digestsdomain.GetDigestSubscriptionInput) (*dto.DigestSubscription, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if d := t.digest(input.UserID); d != nil {
+		return t.digestDto(*d), nil
+	}
+
+	return nil, digestsdomain.ErrDigestSubscriptionNotFound
+}
+
+func (r *DigestSubscriptionsRepository) GetAllDue(input digestsdomain.GetAllDueDigestSubscriptionsInput) ([]*dto.DigestSubscription, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	before := map[string]time.Time{
+		dto.DigestDaily:  input.DailyBefore.UTC().Truncate(time.Second),
+		dto.DigestWeekly: input.WeeklyBefore.UTC().Truncate(time.Second),
+	}
+
+	subscriptions := []*dto.DigestSubscription{}
+	for _, d := range t.digests {
+		if d.lastSent.IsZero() || !d.lastSent.After(before[d.frequency]) {
+			subscriptions = append(subscriptions, t.digestDto(d))
+		}
+	}
+
+	return subscriptions, nil
+}
+
+func (r *DigestSubscriptionsRepository) Upsert(input digestsdomain.UpsertDigestSubscriptionInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if input.Frequency != dto.DigestDaily && input.Frequency != dto.DigestWeekly || t.user(input.UserID) == nil {
+		return ErrConstraint
+	}
+
+	if d := t.digest(input.UserID); d != nil {
+		d.frequency = input.Frequency
+		return nil
+	}
+
+	t.digests = append(t.digests, digestSubscription{userID: input.UserID, frequency: input.Frequency})
+	sort.Slice(t.digests, func(i, j int) bool {
+		return t.digests[i].userID < t.digests[j].userID
+	})
+
+	return nil
+}
+
+func (r *DigestSubscriptionsRepository) MarkSent(input digestsdomain.MarkDigestSentInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if d := t.digest(input.UserID); d != nil {
+		d.lastSent = input.Sent.UTC().Truncate(time.Second)
+	}
+
+	return nil
+}
+
+func (r *DigestSubscriptionsRepository) Delete(input digestsdomain.DeleteDigestSubscriptionInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	t.digests = filter(t.digests, func(d digestSubscription) bool { return d.userID != input.UserID })
+
+	return nil
+}
+
+func (t *tables) digest(userId int) *digestSubscription {
+	for i := range t.digests {
+		if t.digests[i].userID == userId {
+			return &t.digests[i]
+		}
+	}
+
+	return nil
+}
+
+func (t *tables) digestDto(d digestSubscription) *dto.DigestSubscription {
+	u := t.user(d.userID)
+
+	return &dto.DigestSubscription{
+		User:      &dto.User{ID: u.id, Username: u.username, Email: u.email},
+		Frequency: d.frequency,
+		LastSent:  d.lastSent,
+	}
+}
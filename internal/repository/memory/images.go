@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/dto"
+	postsdomain "github.com/itelman/forum/internal/service/posts/domain"
+)
+
+type ImagesRepository struct {
+	store *Store
+}
+
+func NewImagesRepository(store *Store) *ImagesRepository {
+	return &ImagesRepository{store}
+}
+
+func (r *ImagesRepository) Create(tx *sql.Tx, input postsdomain.CreateImageInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if t.post(input.PostID) == nil {
+		return ErrConstraint
+	}
+
+	for _, i := range t.images {
+		if i.postID == input.PostID {
+			return ErrConstraint
+		}
+	}
+
+	t.images = append(t.images, image{id: t.nextID("images"), postID: input.PostID, path: input.Path, uploaded: now()})
+
+	return nil
+}
+
+func (r *ImagesRepository) Get(input postsdomain.GetImageInput) (*dto.Image, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, i := range t.images {
+		if i.postID == input.PostID {
+			return &dto.Image{ID: i.id, PostID: i.postID, Path: i.path, Uploaded: i.uploaded}, nil
+		}
+	}
+
+	return nil, postsdomain.ErrImageNotFound
+}
@@ -0,0 +1,73 @@
+// Package memorytest provides fixtures for tests that run services against
+// a memory.Store.
+package memorytest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
+	postsdomain "github.com/itelman/forum/internal/service/posts/domain"
+	"github.com/itelman/forum/pkg/events"
+)
+
+// Password is the password of every user created by Users.
+const Password = "secret1"
+
+// Users creates a user for each username, with the email
+// <username>@example.com. In a new store, they get ids from 1 in order.
+func Users(t testing.TB, store *memory.Store, usernames ...string) {
+	t.Helper()
+
+	users := memory.NewUsersRepository(store)
+	for _, username := range usernames {
+		if err := users.Create(repository.RegisterUserInput{Username: username, Email: username + "@example.com", Password: Password}); err != nil {
+			t.Fatalf("create %s: %v", username, err)
+		}
+	}
+}
+
+// Post creates a published post by userId and returns its id.
+func Post(t testing.TB, store *memory.Store, userId int, title string) int {
+	t.Helper()
+
+	postId, err := memory.NewPostsRepository(store).Create(nil, repository.CreatePostInput{UserID: userId, Title: title, Content: "Hello"})
+	if err != nil {
+		t.Fatalf("create %q: %v", title, err)
+	}
+
+	return postId
+}
+
+// Pending holds a post for moderator approval.
+func Pending(t testing.TB, store *memory.Store, postId int) {
+	t.Helper()
+
+	if err := memory.NewPendingPostsRepository(store).Create(nil, postsdomain.CreatePendingPostInput{PostID: postId}); err != nil {
+		t.Fatalf("hold post %d: %v", postId, err)
+	}
+}
+
+// PostIDs formats the ids of posts in order, e.g. "[3 1]".
+func PostIDs(posts []*dto.Post) string {
+	ids := make([]int, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+
+	return fmt.Sprint(ids)
+}
+
+// Recorder is an events.Publisher that keeps everything published to it.
+type Recorder struct {
+	// Topics holds "<topic>:<event name>" for each event, in order.
+	Topics []string
+	Events []events.Event
+}
+
+func (r *Recorder) Publish(topic string, event events.Event) {
+	r.Topics = append(r.Topics, topic+":"+event.Name)
+	r.Events = append(r.Events, event)
+}
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"database/sql"
+	"sort"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type PostMentionsRepository struct {
+	store *Store
+}
+
+func NewPostMentionsRepository(store *Store) *PostMentionsRepository {
+	return &PostMentionsRepository{store}
+}
+
+func (r *PostMentionsRepository) Create(tx *sql.Tx, input repository.CreatePostMentionsInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	mentions, err := t.addMentions(t.postMentions, input.PostID, input.UserIDs)
+	if err != nil {
+		return err
+	}
+	t.postMentions = mentions
+
+	return nil
+}
+
+func (r *PostMentionsRepository) Delete(tx *sql.Tx, input repository.DeletePostMentionsInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	t.postMentions = removeMentions(t.postMentions, input.PostID, input.UserIDs)
+
+	return nil
+}
+
+func (r *PostMentionsRepository) GetAllForPost(input repository.GetPostMentionsInput) ([]*dto.User, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	return t.mentionedUsers(t.postMentions, input.PostID), nil
+}
+
+type CommentMentionsRepository struct {
+	store *Store
+}
+
+func NewCommentMentionsRepository(store *Store) *CommentMentionsRepository {
+	return &CommentMentionsRepository{store}
+}
+
+func (r *CommentMentionsRepository) Create(tx *sql.Tx, input repository.CreateCommentMentionsInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	mentions, err := t.addMentions(t.commentMentions, input.CommentID, input.UserIDs)
+	if err != nil {
+		return err
+	}
+	t.commentMentions = mentions
+
+	return nil
+}
+
+func (r *CommentMentionsRepository) Delete(tx *sql.Tx, input repository.DeleteCommentMentionsInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	t.commentMentions = removeMentions(t.commentMentions, input.CommentID, input.UserIDs)
+
+	return nil
+}
+
+func (r *CommentMentionsRepository) GetAllForComment(input repository.GetCommentMentionsInput) ([]*dto.User, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	return t.mentionedUsers(t.commentMentions, input.CommentID), nil
+}
+
+// addMentions skips users who are already mentioned, like the INSERT OR IGNORE
+// of the SQLite repositories.
+func (t *tables) addMentions(mentions []mention, targetId int, userIds []int) ([]mention, error) {
+	for _, userId := range userIds {
+		if t.user(userId) == nil {
+			return nil, ErrConstraint
+		}
+
+		if !hasMention(mentions, targetId, userId) {
+			mentions = append(mentions, mention{targetID: targetId, userID: userId})
+		}
+	}
+
+	return mentions, nil
+}
+
+func hasMention(mentions []mention, targetId, userId int) bool {
+	for _, m := range mentions {
+		if m.targetID == targetId && m.userID == userId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func removeMentions(mentions []mention, targetId int, userIds []int) []mention {
+	return filter(mentions, func(m mention) bool {
+		return m.targetID != targetId || !containsInt(userIds, m.userID)
+	})
+}
+
+func (t *tables) mentionedUsers(mentions []mention, targetId int) []*dto.User {
+	users := []*dto.User{}
+	for _, m := range mentions {
+		if m.targetID == targetId {
+			u := t.user(m.userID)
+			users = append(users, &dto.User{ID: u.id, Username: u.username})
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Username < users[j].Username
+	})
+
+	return users
+}
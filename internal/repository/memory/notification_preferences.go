@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type NotificationPreferencesRepository struct {
+	store *Store
+}
+
+func NewNotificationPreferencesRepository(store *Store) *NotificationPreferencesRepository {
+	return &NotificationPreferencesRepository{store}
+}
+
+func (r *NotificationPreferencesRepository) GetAll(input repository.GetAllNotificationPreferencesInput) ([]*dto.NotificationPreference, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	preferences := []*dto.NotificationPreference{}
+	for _, preference := range t.preferences[input.UserID] {
+		preference := preference
+		preferences = append(preferences, &preference)
+	}
+
+	return preferences, nil
+}
+
+func (r *NotificationPreferencesRepository) Upsert(tx *sql.Tx, input repository.UpsertNotificationPreferenceInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if t.user(input.UserID) == nil {
+		return ErrConstraint
+	}
+
+	if _, ok := t.preferences[input.UserID]; !ok {
+		t.preferences[input.UserID] = make(map[string]dto.NotificationPreference)
+	}
+	t.preferences[input.UserID][input.Preference.Event] = *input.Preference
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package memory
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type NotificationsRepository struct {
+	store *Store
+}
+
+func NewNotificationsRepository(store *Store) *NotificationsRepository {
+	return &NotificationsRepository{store}
+}
+
+func (r *NotificationsRepository) Create(tx *sql.Tx, input repository.CreateNotificationInput) (int, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if t.user(input.UserID) == nil || t.user(input.ActorID) == nil || t.post(input.PostID) == nil {
+		return -1, ErrConstraint
+	}
+
+	id := t.nextID("notifications")
+	t.notifications = append(t.notifications, notification{
+		id:                id,
+		userID:            input.UserID,
+		actorID:           input.ActorID,
+		notificationType:  input.Type,
+		postID:            input.PostID,
+		commentID:         input.CommentID,
+		postReactionID:    input.PostReactionID,
+		commentReactionID: input.CommentReactionID,
+		created:           now(),
+	})
+
+	return id, nil
+}
+
+func (r *NotificationsRepository) Get(input repository.GetNotificationInput) (*dto.Notification, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if n := t.notification(input.ID, input.UserID); n != nil {
+		return t.notificationDto(*n), nil
+	}
+
+	return nil, repository.ErrNotificationNotFound
+}
+
+func (r *NotificationsRepository) GetAll(input repository.GetAllNotificationsInput) ([]*dto.Notification, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	notifications := []*dto.Notification{}
+	for _, n := range t.notifications {
+		if n.userID != input.UserID || containsString(input.ExcludeTypes, n.notificationType) {
+			continue
+		}
+
+		if len(input.Types) != 0 && !containsString(input.Types, n.notificationType) {
+			continue
+		}
+
+		if input.Cursor != nil && !createdBefore(n.created, n.id, input.Cursor) {
+			continue
+		}
+
+		notifications = append(notifications, t.notificationDto(n))
+	}
+
+	if input.SortedByNewest {
+		sortNewest(notifications, func(n *dto.Notification) (time.Time, int) { return n.Created, n.ID })
+	}
+
+	if input.Limit > 0 && len(notifications) > input.Limit {
+		notifications = notifications[:input.Limit]
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationsRepository) GetAllUnreadSince(input repository.GetAllUnreadNotificationsSinceInput) ([]*dto.Notification, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	since, until := input.Since.UTC().Truncate(time.Second), input.Until.UTC().Truncate(time.Second)
+
+	notifications := []*dto.Notification{}
+	for _, n := range t.notifications {
+		if n.userID != input.UserID || n.read || containsString(input.ExcludeTypes, n.notificationType) {
+			continue
+		}
+
+		if !n.created.After(since) || n.created.After(until) {
+			continue
+		}
+
+		notifications = append(notifications, t.notificationDto(n))
+	}
+
+	sortNewest(notifications, func(n *dto.Notification) (time.Time, int) { return n.Created, n.ID })
+
+	return notifications, nil
+}
+
+func (r *NotificationsRepository) CountUnread(input repository.CountUnreadNotificationsInput) (int, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	count := 0
+	for _, n := range t.notifications {
+		if n.userID == input.UserID && !n.read && !containsString(input.ExcludeTypes, n.notificationType) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *NotificationsRepository) MarkRead(input repository.MarkNotificationReadInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	n := t.notification(input.ID, input.UserID)
+	if n == nil {
+		return repository.ErrNotificationNotFound
+	}
+	n.read = true
+
+	return nil
+}
+
+func (r *NotificationsRepository) MarkAllRead(input repository.MarkAllNotificationsReadInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for i := range t.notifications {
+		if t.notifications[i].userID == input.UserID {
+			t.notifications[i].read = true
+		}
+	}
+
+	return nil
+}
+
+func (r *NotificationsRepository) Delete(input repository.DeleteNotificationInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if t.notification(input.ID, input.UserID) == nil {
+		return repository.ErrNotificationNotFound
+	}
+	t.notifications = filter(t.notifications, func(n notification) bool { return n.id != input.ID })
+
+	return nil
+}
+
+func (t *tables) notification(id, userId int) *notification {
+	for i := range t.notifications {
+		if t.notifications[i].id == id && t.notifications[i].userID == userId {
+			return &t.notifications[i]
+		}
+	}
+
+	return nil
+}
+
+func (t *tables) notificationDto(n notification) *dto.Notification {
+	actor := t.user(n.actorID)
+
+	return &dto.Notification{
+		ID:        n.id,
+		Type:      n.notificationType,
+		Actor:     &dto.User{ID: actor.id, Username: actor.username},
+		PostID:    n.postID,
+		CommentID: n.commentID,
+		Read:      n.read,
+		Created:   n.created,
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
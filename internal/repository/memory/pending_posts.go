@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"database/sql"
+
+	postsdomain "github.com/itelman/forum/internal/service/posts/domain"
+)
+
+type PendingPostsRepository struct {
+	store *Store
+}
+
+func NewPendingPostsRepository(store *Store) *PendingPostsRepository {
+	return &PendingPostsRepository{store}
+}
+
+func (r *PendingPostsRepository) Create(tx *sql.Tx, input postsdomain.CreatePendingPostInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if _, ok := t.pendingPosts[input.PostID]; ok || t.post(input.PostID) == nil {
+		return ErrConstraint
+	}
+	t.pendingPosts[input.PostID] = now()
+
+	return nil
+}
+
+func (r *PendingPostsRepository) Delete(tx *sql.Tx, input postsdomain.DeletePendingPostInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	delete(t.pendingPosts, input.PostID)
+
+	return nil
+}
@@ -0,0 +1,391 @@
+package memory
+
+import (
+	"database/sql"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/pkg/pagination"
+)
+
+type PostsRepository struct {
+	store *Store
+}
+
+func NewPostsRepository(store *Store) *PostsRepository {
+	return &PostsRepository{store}
+}
+
+func (r *PostsRepository) Create(tx *sql.Tx, input repository.CreatePostInput) (int, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if t.user(input.UserID) == nil {
+		return -1, ErrConstraint
+	}
+
+	id := t.nextID("posts")
+	t.posts = append(t.posts, post{id: id, userID: input.UserID, title: input.Title, content: input.Content, created: now()})
+
+	return id, nil
+}
+
+func (r *PostsRepository) Get(input repository.GetPostInput) (*dto.Post, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, p := range t.posts {
+		if p.id == input.ID {
+			return t.postDto(p, input.AuthUserID, ""), nil
+		}
+	}
+
+	return nil, repository.ErrPostNotFound
+}
+
+func (r *PostsRepository) GetAll(input repository.GetAllPostsInput) ([]*dto.Post, error) {
+	return r.getMany(postsQuery{
+		sortBy:         input.Sort,
+		period:         input.Period,
+		cursor:         input.Cursor,
+		sortedByNewest: input.SortedByNewest,
+		limit:          input.Limit,
+	}, func(t *tables, p post) bool {
+		_, pending := t.pendingPosts[p.id]
+		return pending == input.Pending
+	})
+}
+
+func (r *PostsRepository) GetManyByFilters(input repository.GetPostsByFiltersInput) ([]*dto.Post, error) {
+	return r.getMany(postsQuery{
+		authUserID:     input.AuthUserID,
+		sortBy:         input.Sort,
+		period:         input.Period,
+		cursor:         input.Cursor,
+		sortedByNewest: input.SortedByNewest,
+		limit:          input.Limit,
+	}, func(t *tables, p post) bool {
+		if _, pending := t.pendingPosts[p.id]; pending {
+			return false
+		}
+
+		matched := 0
+		for _, pc := range t.postCategories {
+			if pc.postID != p.id {
+				continue
+			}
+			if containsInt(input.ExcludedIDs, pc.categoryID) {
+				return false
+			}
+			if containsInt(input.CategoryIDs, pc.categoryID) {
+				matched++
+			}
+		}
+
+		if len(input.CategoryIDs) != 0 && input.MatchAll && matched != len(input.CategoryIDs) {
+			return false
+		} else if len(input.CategoryIDs) != 0 && matched == 0 {
+			return false
+		}
+
+		if input.Created && p.userID != input.AuthUserID {
+			return false
+		}
+
+		if input.Liked && t.postReaction(p.id, input.AuthUserID) != 1 {
+			return false
+		}
+
+		return true
+	})
+}
+
+func (r *PostsRepository) GetAllForTag(input repository.GetAllPostsForTagInput) ([]*dto.Post, error) {
+	return r.getMany(postsQuery{sortedByNewest: input.SortedByNewest, cursor: input.Cursor, limit: input.Limit}, func(t *tables, p post) bool {
+		if _, pending := t.pendingPosts[p.id]; pending {
+			return false
+		}
+
+		for _, pt := range t.postTags {
+			if pt.postID == p.id && pt.tagID == input.TagID {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *PostsRepository) GetAllForUser(input repository.GetAllPostsForUserInput) ([]*dto.Post, error) {
+	return r.getMany(postsQuery{sortedByNewest: input.SortedByNewest, cursor: input.Cursor, limit: input.Limit}, func(t *tables, p post) bool {
+		_, pending := t.pendingPosts[p.id]
+		return !pending && p.userID == input.UserID
+	})
+}
+
+func (r *PostsRepository) GetAllCreated(input repository.GetAllCreatedPostsInput) ([]*dto.Post, error) {
+	return r.getMany(postsQuery{authUserID: input.AuthUserID, sortedByNewest: input.SortedByNewest, cursor: input.Cursor, limit: input.Limit}, func(t *tables, p post) bool {
+		return p.userID == input.AuthUserID
+	})
+}
+
+func (r *PostsRepository) GetAllReacted(input repository.GetAllReactedPostsInput) ([]*dto.Post, error) {
+	return r.getMany(postsQuery{authUserID: input.AuthUserID, sortedByNewest: input.SortedByNewest, cursor: input.Cursor, limit: input.Limit}, func(t *tables, p post) bool {
+		return t.postReaction(p.id, input.AuthUserID) != -1
+	})
+}
+
+func (r *PostsRepository) GetAllCommented(input repository.GetAllCommentedPostsInput) ([]*dto.Post, error) {
+	return r.getMany(postsQuery{authUserID: input.AuthUserID, sortedByNewest: input.SortedByNewest, cursor: input.Cursor, limit: input.Limit}, func(t *tables, p post) bool {
+		for _, c := range t.comments {
+			if c.postID == p.id && c.userID == input.AuthUserID {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *PostsRepository) Update(tx *sql.Tx, input repository.UpdatePostInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for i := range t.posts {
+		if t.posts[i].id == input.ID {
+			t.posts[i].title = input.Title
+			t.posts[i].content = input.Content
+		}
+	}
+
+	return nil
+}
+
+func (r *PostsRepository) UpdateReactionsCount(tx *sql.Tx, input repository.UpdatePostReactionsCountInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for i := range t.posts {
+		if t.posts[i].id == input.PostID {
+			t.posts[i].likes, t.posts[i].dislikes = countReactions(t.postReactions, input.PostID)
+		}
+	}
+
+	return nil
+}
+
+func (r *PostsRepository) Delete(input repository.DeletePostInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	t.deletePost(input.ID)
+
+	return nil
+}
+
+type postsQuery struct {
+	authUserID     int
+	sortBy         string
+	period         string
+	cursor         *pagination.Cursor
+	sortedByNewest bool
+	limit          int
+}
+
+func (r *PostsRepository) getMany(q postsQuery, match func(t *tables, p post) bool) ([]*dto.Post, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	scored := q.sortBy == pagination.SortTop || q.sortBy == pagination.SortHot || q.sortBy == pagination.SortComments
+
+	posts := []*dto.Post{}
+	for _, p := range t.posts {
+		if !match(t, p) {
+			continue
+		}
+
+		if q.sortBy == pagination.SortTop && !inPeriod(p.created, q.period) {
+			continue
+		}
+
+		post := t.postDto(p, q.authUserID, q.sortBy)
+		if q.cursor != nil && scored && !before(post.Score, post.ID, q.cursor.Score, q.cursor.ID) {
+			continue
+		} else if q.cursor != nil && !scored && !createdBefore(post.Created, post.ID, q.cursor) {
+			continue
+		}
+
+		posts = append(posts, post)
+	}
+
+	if scored {
+		sort.SliceStable(posts, func(i, j int) bool {
+			return before(posts[j].Score, posts[j].ID, posts[i].Score, posts[i].ID)
+		})
+	} else if q.sortedByNewest {
+		sortNewest(posts, func(p *dto.Post) (time.Time, int) { return p.Created, p.ID })
+	}
+
+	if q.limit > 0 && len(posts) > q.limit {
+		posts = posts[:q.limit]
+	}
+
+	return posts, nil
+}
+
+func (t *tables) postDto(p post, authUserId int, sortBy string) *dto.Post {
+	author := t.user(p.userID)
+	_, pending := t.pendingPosts[p.id]
+
+	return &dto.Post{
+		ID:               p.id,
+		User:             &dto.User{ID: author.id, Username: author.username},
+		Title:            p.title,
+		Content:          p.content,
+		Likes:            p.likes,
+		Dislikes:         p.dislikes,
+		Created:          p.created,
+		AuthUserReaction: t.postReaction(p.id, authUserId),
+		Pending:          pending,
+		Score:            t.postScore(p, sortBy),
+	}
+}
+
+// postScore mirrors the scores of repository.PostsRepositorySqlite.
+func (t *tables) postScore(p post, sortBy string) float64 {
+	net := float64(p.likes - p.dislikes)
+
+	switch sortBy {
+	case pagination.SortTop:
+		return net
+	case pagination.SortHot:
+		sign := 0.0
+		if net > 0 {
+			sign = 1
+		} else if net < 0 {
+			sign = -1
+		}
+
+		score := sign*math.Log10(math.Max(math.Abs(net), 1)) + float64(p.created.Unix()-1134028003)/45000.0
+		return math.Round(score*1e7) / 1e7
+	case pagination.SortComments:
+		count := 0
+		for _, c := range t.comments {
+			if c.postID == p.id {
+				count++
+			}
+		}
+
+		return float64(count)
+	}
+
+	return 0
+}
+
+func (t *tables) postReaction(postId, userId int) int {
+	for _, pr := range t.postReactions {
+		if pr.targetID == postId && pr.userID == userId {
+			return pr.isLike
+		}
+	}
+
+	return -1
+}
+
+func (t *tables) post(id int) *post {
+	for i := range t.posts {
+		if t.posts[i].id == id {
+			return &t.posts[i]
+		}
+	}
+
+	return nil
+}
+
+// deletePost removes a post and everything that references it, like the
+// ON DELETE CASCADE foreign keys do.
+func (t *tables) deletePost(id int) {
+	t.posts = filter(t.posts, func(p post) bool { return p.id != id })
+	delete(t.pendingPosts, id)
+	t.images = filter(t.images, func(i image) bool { return i.postID != id })
+	t.postCategories = filter(t.postCategories, func(pc postCategory) bool { return pc.postID != id })
+	t.postTags = filter(t.postTags, func(pt postTag) bool { return pt.postID != id })
+	t.postMentions = filter(t.postMentions, func(m mention) bool { return m.targetID != id })
+	t.postReactions = filter(t.postReactions, func(pr reaction) bool { return pr.targetID != id })
+	t.notifications = filter(t.notifications, func(n notification) bool { return n.postID != id })
+	t.reports = filter(t.reports, func(r report) bool { return r.postID != id })
+
+	for _, c := range filter(t.comments, func(c comment) bool { return c.postID == id }) {
+		t.deleteComment(c.id)
+	}
+}
+
+func inPeriod(created time.Time, period string) bool {
+	switch period {
+	case pagination.PeriodDay:
+		return !created.Before(now().Add(-24 * time.Hour))
+	case pagination.PeriodWeek:
+		return !created.Before(now().Add(-7 * 24 * time.Hour))
+	}
+
+	return true
+}
+
+// before reports whether (score, id) sorts after (cursorScore, cursorId) in a
+// highest first listing.
+func before(score float64, id int, cursorScore float64, cursorId int) bool {
+	return score < cursorScore || (score == cursorScore && id < cursorId)
+}
+
+func createdBefore(created time.Time, id int, cursor *pagination.Cursor) bool {
+	return created.Before(cursor.Created) || (created.Equal(cursor.Created) && id < cursor.ID)
+}
+
+func sortNewest[T any](items []T, key func(T) (time.Time, int)) {
+	sort.SliceStable(items, func(i, j int) bool {
+		ci, idi := key(items[i])
+		cj, idj := key(items[j])
+		return ci.After(cj) || (ci.Equal(cj) && idi > idj)
+	})
+}
+
+func countReactions(reactions []reaction, targetId int) (int, int) {
+	likes, dislikes := 0, 0
+	for _, r := range reactions {
+		if r.targetID != targetId {
+			continue
+		}
+
+		if r.isLike == 1 {
+			likes++
+		} else {
+			dislikes++
+		}
+	}
+
+	return likes, dislikes
+}
+
+func filter[T any](items []T, keep func(T) bool) []T {
+	kept := make([]T, 0, len(items))
+	for _, item := range items {
+		if keep(item) {
+			kept = append(kept, item)
+		}
+	}
+
+	return kept
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
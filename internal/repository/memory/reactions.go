@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type PostReactionsRepository struct {
+	store *Store
+}
+
+func NewPostReactionsRepository(store *Store) *PostReactionsRepository {
+	return &PostReactionsRepository{store}
+}
+
+func (r *PostReactionsRepository) Get(input repository.GetPostReactionInput) (*dto.PostReaction, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, pr := range t.postReactions {
+		if pr.targetID == input.PostID && pr.userID == input.UserID {
+			return &dto.PostReaction{ID: pr.id, PostID: pr.targetID, User: &dto.User{ID: pr.userID}, IsLike: pr.isLike, Created: pr.created}, nil
+		}
+	}
+
+	return nil, repository.ErrPostReactionNotFound
+}
+
+func (r *PostReactionsRepository) Insert(tx *sql.Tx, input repository.CreatePostReactionInput) (int, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if t.post(input.PostID) == nil || t.postReaction(input.PostID, input.UserID) != -1 {
+		return -1, ErrConstraint
+	}
+
+	id := t.nextID("post_reactions")
+	t.postReactions = append(t.postReactions, reaction{id: id, targetID: input.PostID, userID: input.UserID, isLike: input.IsLike, created: now()})
+
+	return id, nil
+}
+
+func (r *PostReactionsRepository) Delete(tx *sql.Tx, input repository.DeletePostReactionInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	t.postReactions = filter(t.postReactions, func(pr reaction) bool { return pr.id != input.ID })
+	t.notifications = filter(t.notifications, func(n notification) bool { return n.postReactionID != input.ID })
+
+	return nil
+}
+
+type CommentReactionsRepository struct {
+	store *Store
+}
+
+func NewCommentReactionsRepository(store *Store) *CommentReactionsRepository {
+	return &CommentReactionsRepository{store}
+}
+
+func (r *CommentReactionsRepository) Get(input repository.GetCommentReactionInput) (*dto.CommentReaction, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, cr := range t.commentReactions {
+		if cr.targetID == input.CommentID && cr.userID == input.UserID {
+			return &dto.CommentReaction{ID: cr.id, CommentID: cr.targetID, User: &dto.User{ID: cr.userID}, IsLike: cr.isLike, Created: cr.created}, nil
+		}
+	}
+
+	return nil, repository.ErrCommentReactionNotFound
+}
+
+func (r *CommentReactionsRepository) Insert(tx *sql.Tx, input repository.CreateCommentReactionInput) (int, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if t.comment(input.CommentID) == nil {
+		return -1, ErrConstraint
+	}
+
+	for _, cr := range t.commentReactions {
+		if cr.targetID == input.CommentID && cr.userID == input.UserID {
+			return -1, ErrConstraint
+		}
+	}
+
+	id := t.nextID("comment_reactions")
+	t.commentReactions = append(t.commentReactions, reaction{id: id, targetID: input.CommentID, userID: input.UserID, isLike: input.IsLike, created: now()})
+
+	return id, nil
+}
+
+func (r *CommentReactionsRepository) Delete(tx *sql.Tx, input repository.DeleteCommentReactionInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	t.commentReactions = filter(t.commentReactions, func(cr reaction) bool { return cr.id != input.ID })
+	t.notifications = filter(t.notifications, func(n notification) bool { return n.commentReactionID != input.ID })
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+	reportsdomain "github.com/itelman/forum/internal/service/reports/domain"
+)
+
+type ReportsRepository struct {
+	store *Store
+}
+
+func NewReportsRepository(store *Store) *ReportsRepository {
+	return &ReportsRepository{store}
+}
+
+func (r *ReportsRepository) Create(input reportsdomain.CreateReportInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if t.post(input.PostID) == nil || t.user(input.ModeratorID) == nil {
+		return ErrConstraint
+	}
+
+	for _, rep := range t.reports {
+		if rep.postID == input.PostID {
+			return reportsdomain.ErrReportExists
+		}
+	}
+
+	t.reports = append(t.reports, report{
+		id:      t.nextID("reports"),
+		postID:  input.PostID,
+		modID:   input.ModeratorID,
+		content: input.Content,
+		created: now(),
+	})
+
+	return nil
+}
+
+func (r *ReportsRepository) Get(input reportsdomain.GetReportInput) (*dto.Report, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if rep := t.report(input.ID); rep != nil {
+		return t.reportDto(*rep), nil
+	}
+
+	return nil, reportsdomain.ErrReportNotFound
+}
+
+// GetAll returns every report when ModeratorID is -1.
+func (r *ReportsRepository) GetAll(input reportsdomain.GetAllReportsInput) ([]*dto.Report, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	reports := []*dto.Report{}
+	for _, rep := range t.reports {
+		if input.ModeratorID == -1 || rep.modID == input.ModeratorID {
+			reports = append(reports, t.reportDto(rep))
+		}
+	}
+
+	if input.SortedByNewest {
+		sortNewest(reports, func(rep *dto.Report) (time.Time, int) { return rep.Created, rep.ID })
+	}
+
+	return reports, nil
+}
+
+func (r *ReportsRepository) Review(input reportsdomain.ReviewReportInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if rep := t.report(input.ID); rep != nil {
+		rep.adminReview = input.AdminReview
+		rep.reviewed = now()
+	}
+
+	return nil
+}
+
+func (t *tables) report(id int) *report {
+	for i := range t.reports {
+		if t.reports[i].id == id {
+			return &t.reports[i]
+		}
+	}
+
+	return nil
+}
+
+func (t *tables) reportDto(rep report) *dto.Report {
+	p, u := t.post(rep.postID), t.user(rep.modID)
+
+	return &dto.Report{
+		ID:          rep.id,
+		Post:        &dto.Post{ID: p.id, Title: p.title},
+		Moderator:   &dto.User{ID: u.id, Username: u.username},
+		Content:     rep.content,
+		AdminReview: rep.adminReview,
+		Created:     rep.created,
+		Reviewed:    rep.reviewed,
+	}
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+	moderationdomain "github.com/itelman/forum/internal/service/moderation/domain"
+)
+
+type RequestsRepository struct {
+	store *Store
+}
+
+func NewRequestsRepository(store *Store) *RequestsRepository {
+	return &RequestsRepository{store}
+}
+
+func (r *RequestsRepository) Create(input moderationdomain.CreateRequestInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if t.user(input.UserID) == nil {
+		return ErrConstraint
+	}
+
+	for _, req := range t.requests {
+		if req.userID == input.UserID {
+			return moderationdomain.ErrRequestExists
+		}
+	}
+
+	t.requests = append(t.requests, request{id: t.nextID("requests"), userID: input.UserID, created: now()})
+
+	return nil
+}
+
+func (r *RequestsRepository) Get(input moderationdomain.GetRequestInput) (*dto.Request, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, req := range t.requests {
+		if req.id == input.ID {
+			return t.requestDto(req), nil
+		}
+	}
+
+	return nil, moderationdomain.ErrRequestNotFound
+}
+
+func (r *RequestsRepository) GetAll(input moderationdomain.GetAllRequestsInput) ([]*dto.Request, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	requests := []*dto.Request{}
+	for _, req := range t.requests {
+		requests = append(requests, t.requestDto(req))
+	}
+
+	if input.SortedByNewest {
+		sortNewest(requests, func(req *dto.Request) (time.Time, int) { return req.Created, req.ID })
+	}
+
+	return requests, nil
+}
+
+func (r *RequestsRepository) Delete(tx *sql.Tx, input moderationdomain.DeleteRequestInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	t.requests = filter(t.requests, func(req request) bool { return req.id != input.ID })
+
+	return nil
+}
+
+func (t *tables) requestDto(req request) *dto.Request {
+	u := t.user(req.userID)
+
+	return &dto.Request{
+		ID:      req.id,
+		User:    &dto.User{ID: u.id, Username: u.username},
+		Created: req.created,
+	}
+}
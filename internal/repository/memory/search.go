@@ -0,0 +1,295 @@
+package memory
+
+import (
+	"html"
+	"html/template"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/itelman/forum/internal/dto"
+	searchdomain "github.com/itelman/forum/internal/service/search/domain"
+)
+
+// snippetWords is how many words of the text around the first match a
+// snippet shows, like snippet() in the SQLite repositories.
+const snippetWords = 24
+
+// The match expressions built by the search service are quoted phrases, each
+// optionally followed by * for a prefix match.
+var phraseRx = regexp.MustCompile(`"((?:[^"]|"")*)"(\*?)`)
+
+// SearchPostsRepository stands in for the FTS5 index by matching whole
+// words, without stemming. A post ranks by how many words matched, those in
+// the title counting ten times.
+type SearchPostsRepository struct {
+	store *Store
+}
+
+func NewSearchPostsRepository(store *Store) *SearchPostsRepository {
+	return &SearchPostsRepository{store}
+}
+
+func (r *SearchPostsRepository) Search(input searchdomain.SearchInput) ([]*dto.SearchResult, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	phrases := parseMatch(input.Match)
+
+	results := []*dto.SearchResult{}
+	for _, p := range t.posts {
+		author := t.user(p.userID)
+		if !t.searchable(p, author, p.created, input) {
+			continue
+		}
+
+		title, content := matchText(p.title, phrases), matchText(p.content, phrases)
+		if !title.matchesAll(content) {
+			continue
+		}
+
+		results = append(results, &dto.SearchResult{
+			Post:    &dto.Post{ID: p.id, User: &dto.User{ID: author.id, Username: author.username}, Title: p.title, Created: p.created},
+			Title:   title.markup(false),
+			Snippet: content.markup(true),
+			Rank:    -float64(10*len(title.spans) + len(content.spans)),
+		})
+	}
+
+	return limitResults(results, input.Limit), nil
+}
+
+type SearchCommentsRepository struct {
+	store *Store
+}
+
+func NewSearchCommentsRepository(store *Store) *SearchCommentsRepository {
+	return &SearchCommentsRepository{store}
+}
+
+func (r *SearchCommentsRepository) Search(input searchdomain.SearchInput) ([]*dto.SearchResult, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	phrases := parseMatch(input.Match)
+
+	results := []*dto.SearchResult{}
+	for _, c := range t.comments {
+		p := t.post(c.postID)
+		author := t.user(c.userID)
+		if p == nil || !t.searchable(*p, author, c.created, input) {
+			continue
+		}
+
+		content := matchText(c.content, phrases)
+		if !content.matchesAll() {
+			continue
+		}
+
+		results = append(results, &dto.SearchResult{
+			Post:    &dto.Post{ID: p.id, Title: p.title},
+			Comment: &dto.Comment{ID: c.id, PostID: p.id, User: &dto.User{ID: author.id, Username: author.username}, Created: c.created},
+			Title:   template.HTML(html.EscapeString(p.title)),
+			Snippet: content.markup(true),
+			Rank:    -float64(len(content.spans)),
+		})
+	}
+
+	return limitResults(results, input.Limit), nil
+}
+
+// searchable applies the operators of input other than the match itself.
+// author wrote the post or comment, which was created at created.
+func (t *tables) searchable(p post, author *user, created time.Time, input searchdomain.SearchInput) bool {
+	if _, pending := t.pendingPosts[p.id]; pending {
+		return false
+	}
+
+	if len(input.Author) != 0 && !strings.EqualFold(author.username, input.Author) {
+		return false
+	}
+
+	if len(input.Category) != 0 {
+		found := false
+		for _, pc := range t.postCategories {
+			for _, c := range t.categories {
+				if pc.postID == p.id && pc.categoryID == c.id && strings.EqualFold(c.name, input.Category) {
+					found = true
+				}
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	date := created.UTC().Format("2006-01-02")
+	if len(input.After) != 0 && date <= input.After {
+		return false
+	} else if len(input.Before) != 0 && date >= input.Before {
+		return false
+	}
+
+	return true
+}
+
+type phrase struct {
+	words  []string
+	prefix bool
+}
+
+func parseMatch(match string) []phrase {
+	phrases := []phrase{}
+	for _, m := range phraseRx.FindAllStringSubmatch(match, -1) {
+		words := []string{}
+		for _, w := range splitWords(strings.ReplaceAll(m[1], `""`, `"`)) {
+			words = append(words, w.word)
+		}
+
+		if len(words) != 0 {
+			phrases = append(phrases, phrase{words: words, prefix: len(m[2]) != 0})
+		}
+	}
+
+	return phrases
+}
+
+type word struct {
+	word       string
+	start, end int
+}
+
+// splitWords lowercases text and splits it into runs of letters and digits,
+// like the unicode61 tokenizer.
+func splitWords(text string) []word {
+	words := []word{}
+
+	start := -1
+	for i, r := range text + " " {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start == -1 {
+				start = i
+			}
+			continue
+		}
+
+		if start != -1 {
+			words = append(words, word{strings.ToLower(text[start:i]), start, i})
+			start = -1
+		}
+	}
+
+	return words
+}
+
+// matchedText is a text with the byte ranges the phrases matched.
+type matchedText struct {
+	text    string
+	words   []word
+	spans   [][2]int
+	matched []bool
+}
+
+func matchText(text string, phrases []phrase) *matchedText {
+	m := &matchedText{text: text, words: splitWords(text), matched: make([]bool, len(phrases))}
+
+	for i := range m.words {
+		for j, ph := range phrases {
+			if i+len(ph.words) > len(m.words) || !ph.matches(m.words[i:i+len(ph.words)]) {
+				continue
+			}
+
+			m.matched[j] = true
+			m.spans = append(m.spans, [2]int{m.words[i].start, m.words[i+len(ph.words)-1].end})
+		}
+	}
+
+	sort.Slice(m.spans, func(i, j int) bool {
+		return m.spans[i][0] < m.spans[j][0]
+	})
+
+	return m
+}
+
+func (ph phrase) matches(words []word) bool {
+	for i, w := range ph.words {
+		if i == len(ph.words)-1 && ph.prefix {
+			return strings.HasPrefix(words[i].word, w)
+		} else if words[i].word != w {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesAll reports whether every phrase matched m or one of others.
+func (m *matchedText) matchesAll(others ...*matchedText) bool {
+	for i, matched := range m.matched {
+		for _, other := range others {
+			matched = matched || other.matched[i]
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// markup escapes the text and wraps the matches in <mark> tags. A snippet is
+// cut down to the words around the first match.
+func (m *matchedText) markup(snippet bool) template.HTML {
+	from, to := 0, len(m.text)
+	if snippet && len(m.words) > snippetWords {
+		first := 0
+		for i, w := range m.words {
+			if len(m.spans) != 0 && w.start == m.spans[0][0] {
+				first = i
+				break
+			}
+		}
+
+		first = max(0, min(first-snippetWords/4, len(m.words)-snippetWords))
+		from, to = m.words[first].start, m.words[first+snippetWords-1].end
+	}
+
+	var b strings.Builder
+	if from != 0 {
+		b.WriteString("…")
+	}
+
+	last := from
+	for _, span := range m.spans {
+		if span[0] < last || span[1] > to {
+			continue
+		}
+
+		b.WriteString(html.EscapeString(m.text[last:span[0]]))
+		b.WriteString("<mark>" + html.EscapeString(m.text[span[0]:span[1]]) + "</mark>")
+		last = span[1]
+	}
+	b.WriteString(html.EscapeString(m.text[last:to]))
+
+	if to != len(m.text) {
+		b.WriteString("…")
+	}
+
+	return template.HTML(b.String())
+}
+
+func limitResults(results []*dto.SearchResult, limit int) []*dto.SearchResult {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Rank < results[j].Rank
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
@@ -0,0 +1,419 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+)
+
+var (
+	ErrConstraint = errors.New("MEMORY: constraint failed")
+	ErrNoQueries  = errors.New("MEMORY: queries are not supported")
+)
+
+// Store is an in-memory stand-in for the SQLite database, meant for tests.
+// It starts with the categories the initial migration creates.
+//
+// Services begin transactions on the *sql.DB returned by DB. Beginning one
+// snapshots the store and rolling it back restores the snapshot, so a failed
+// write leaves the store as it was. Repositories ignore the *sql.Tx they are
+// given, and only one transaction can be open at a time.
+type Store struct {
+	mu       sync.Mutex
+	data     *tables
+	snapshot *tables
+	db       *sql.DB
+}
+
+type tables struct {
+	lastIDs map[string]int
+
+	users            []user
+	userRoles        map[int]string
+	posts            []post
+	pendingPosts     map[int]time.Time
+	images           []image
+	categories       []category
+	postCategories   []postCategory
+	tags             []tag
+	postTags         []postTag
+	postMentions     []mention
+	comments         []comment
+	commentReplies   map[int]int
+	commentMentions  []mention
+	postReactions    []reaction
+	commentReactions []reaction
+	notifications    []notification
+	preferences      map[int]map[string]dto.NotificationPreference
+	digests          []digestSubscription
+	requests         []request
+	reports          []report
+	tokens           []token
+	usersOAuth       []userOAuth
+}
+
+type user struct {
+	id             int
+	username       string
+	email          string
+	hashedPassword []byte
+	created        time.Time
+}
+
+type post struct {
+	id       int
+	userID   int
+	title    string
+	content  string
+	likes    int
+	dislikes int
+	created  time.Time
+}
+
+type image struct {
+	id       int
+	postID   int
+	path     string
+	uploaded time.Time
+}
+
+type category struct {
+	id      int
+	name    string
+	created time.Time
+}
+
+type postCategory struct {
+	postID     int
+	categoryID int
+}
+
+type tag struct {
+	id      int
+	name    string
+	created time.Time
+}
+
+type postTag struct {
+	postID  int
+	tagID   int
+	created time.Time
+}
+
+// mention links a post or a comment, depending on the table, to a user.
+type mention struct {
+	targetID int
+	userID   int
+}
+
+type comment struct {
+	id       int
+	postID   int
+	userID   int
+	content  string
+	likes    int
+	dislikes int
+	created  time.Time
+}
+
+// reaction is a like or dislike of a post or a comment, depending on the table.
+type reaction struct {
+	id       int
+	targetID int
+	userID   int
+	isLike   int
+	created  time.Time
+}
+
+type notification struct {
+	id                int
+	userID            int
+	actorID           int
+	notificationType  string
+	postID            int
+	commentID         int
+	postReactionID    int
+	commentReactionID int
+	read              bool
+	created           time.Time
+}
+
+type digestSubscription struct {
+	userID    int
+	frequency string
+	lastSent  time.Time
+}
+
+type request struct {
+	id      int
+	userID  int
+	created time.Time
+}
+
+type report struct {
+	id          int
+	postID      int
+	modID       int
+	content     string
+	adminReview string
+	created     time.Time
+	reviewed    time.Time
+}
+
+type token struct {
+	id       int
+	userID   int
+	name     string
+	hash     string
+	scope    string
+	lastUsed time.Time
+	created  time.Time
+}
+
+type userOAuth struct {
+	userID     int
+	authTypeID int
+	accountID  string
+}
+
+func NewStore() *Store {
+	s := &Store{data: &tables{
+		lastIDs:        make(map[string]int),
+		userRoles:      make(map[int]string),
+		pendingPosts:   make(map[int]time.Time),
+		commentReplies: make(map[int]int),
+		preferences:    make(map[int]map[string]dto.NotificationPreference),
+	}}
+
+	for _, name := range []string{"Music", "Books", "Hobbies", "Games", "Programming"} {
+		s.data.categories = append(s.data.categories, category{id: s.data.nextID("categories"), name: name, created: now()})
+	}
+
+	db := sql.OpenDB(&connector{s})
+	db.SetMaxOpenConns(1)
+	s.db = db
+
+	return s
+}
+
+// DB returns the handle services begin their transactions on. It does not
+// run queries.
+func (s *Store) DB() *sql.DB {
+	return s.db
+}
+
+// SetUserRole gives a user the "admin" or "moderator" role, which the memory
+// repositories have no other way to grant.
+func (s *Store) SetUserRole(userId int, role string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.userRoles[userId] = role
+}
+
+// Age moves every stored time d into the past, as if d had passed since the
+// rows were written.
+func (s *Store) Age(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.data
+	age := func(at *time.Time) {
+		if !at.IsZero() {
+			*at = at.Add(-d)
+		}
+	}
+
+	for i := range t.users {
+		age(&t.users[i].created)
+	}
+	for i := range t.posts {
+		age(&t.posts[i].created)
+	}
+	for id, created := range t.pendingPosts {
+		age(&created)
+		t.pendingPosts[id] = created
+	}
+	for i := range t.images {
+		age(&t.images[i].uploaded)
+	}
+	for i := range t.categories {
+		age(&t.categories[i].created)
+	}
+	for i := range t.tags {
+		age(&t.tags[i].created)
+	}
+	for i := range t.postTags {
+		age(&t.postTags[i].created)
+	}
+	for i := range t.comments {
+		age(&t.comments[i].created)
+	}
+	for i := range t.postReactions {
+		age(&t.postReactions[i].created)
+	}
+	for i := range t.commentReactions {
+		age(&t.commentReactions[i].created)
+	}
+	for i := range t.notifications {
+		age(&t.notifications[i].created)
+	}
+	for i := range t.digests {
+		age(&t.digests[i].lastSent)
+	}
+	for i := range t.requests {
+		age(&t.requests[i].created)
+	}
+	for i := range t.reports {
+		age(&t.reports[i].created)
+		age(&t.reports[i].reviewed)
+	}
+	for i := range t.tokens {
+		age(&t.tokens[i].lastUsed)
+		age(&t.tokens[i].created)
+	}
+}
+
+func (s *Store) begin() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshot = s.data.clone()
+}
+
+func (s *Store) commit() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshot = nil
+}
+
+func (s *Store) rollback() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.snapshot != nil {
+		s.data = s.snapshot
+		s.snapshot = nil
+	}
+}
+
+// lock locks the store and returns its tables, which are valid until unlock.
+func (s *Store) lock() *tables {
+	s.mu.Lock()
+	return s.data
+}
+
+func (s *Store) unlock() {
+	s.mu.Unlock()
+}
+
+func (t *tables) nextID(table string) int {
+	t.lastIDs[table]++
+	return t.lastIDs[table]
+}
+
+func (t *tables) clone() *tables {
+	c := &tables{
+		lastIDs:          make(map[string]int, len(t.lastIDs)),
+		users:            append([]user(nil), t.users...),
+		userRoles:        make(map[int]string, len(t.userRoles)),
+		posts:            append([]post(nil), t.posts...),
+		pendingPosts:     make(map[int]time.Time, len(t.pendingPosts)),
+		images:           append([]image(nil), t.images...),
+		categories:       append([]category(nil), t.categories...),
+		postCategories:   append([]postCategory(nil), t.postCategories...),
+		tags:             append([]tag(nil), t.tags...),
+		postTags:         append([]postTag(nil), t.postTags...),
+		postMentions:     append([]mention(nil), t.postMentions...),
+		comments:         append([]comment(nil), t.comments...),
+		commentReplies:   make(map[int]int, len(t.commentReplies)),
+		commentMentions:  append([]mention(nil), t.commentMentions...),
+		postReactions:    append([]reaction(nil), t.postReactions...),
+		commentReactions: append([]reaction(nil), t.commentReactions...),
+		notifications:    append([]notification(nil), t.notifications...),
+		preferences:      make(map[int]map[string]dto.NotificationPreference, len(t.preferences)),
+		digests:          append([]digestSubscription(nil), t.digests...),
+		requests:         append([]request(nil), t.requests...),
+		reports:          append([]report(nil), t.reports...),
+		tokens:           append([]token(nil), t.tokens...),
+		usersOAuth:       append([]userOAuth(nil), t.usersOAuth...),
+	}
+
+	for k, v := range t.lastIDs {
+		c.lastIDs[k] = v
+	}
+	for k, v := range t.userRoles {
+		c.userRoles[k] = v
+	}
+	for k, v := range t.pendingPosts {
+		c.pendingPosts[k] = v
+	}
+	for k, v := range t.commentReplies {
+		c.commentReplies[k] = v
+	}
+	for userId, byEvent := range t.preferences {
+		c.preferences[userId] = make(map[string]dto.NotificationPreference, len(byEvent))
+		for event, preference := range byEvent {
+			c.preferences[userId][event] = preference
+		}
+	}
+
+	return c
+}
+
+// now matches CURRENT_TIMESTAMP, which has a resolution of one second.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+type connector struct {
+	store *Store
+}
+
+func (c *connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{c.store}, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return memoryDriver{}
+}
+
+type memoryDriver struct{}
+
+func (memoryDriver) Open(string) (driver.Conn, error) {
+	return nil, ErrNoQueries
+}
+
+// conn only supports transactions; it is its own driver.Tx.
+type conn struct {
+	store *Store
+}
+
+func (c *conn) Prepare(string) (driver.Stmt, error) {
+	return nil, ErrNoQueries
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	c.store.begin()
+	return c, nil
+}
+
+func (c *conn) Commit() error {
+	c.store.commit()
+	return nil
+}
+
+func (c *conn) Rollback() error {
+	c.store.rollback()
+	return nil
+}
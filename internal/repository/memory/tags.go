@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+	postsdomain "github.com/itelman/forum/internal/service/posts/domain"
+	tagsdomain "github.com/itelman/forum/internal/service/tags/domain"
+)
+
+type PostTagsRepository struct {
+	store *Store
+}
+
+func NewPostTagsRepository(store *Store) *PostTagsRepository {
+	return &PostTagsRepository{store}
+}
+
+func (r *PostTagsRepository) Create(tx *sql.Tx, input postsdomain.CreatePostTagsInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, name := range input.Tags {
+		tagId := t.tagID(name)
+		if tagId == -1 {
+			tagId = t.nextID("tags")
+			t.tags = append(t.tags, tag{id: tagId, name: name, created: now()})
+		}
+
+		for _, pt := range t.postTags {
+			if pt.postID == input.PostID && pt.tagID == tagId {
+				return ErrConstraint
+			}
+		}
+
+		t.postTags = append(t.postTags, postTag{postID: input.PostID, tagID: tagId, created: now()})
+	}
+
+	return nil
+}
+
+func (r *PostTagsRepository) DeleteAllForPost(tx *sql.Tx, input postsdomain.DeletePostTagsInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	t.postTags = filter(t.postTags, func(pt postTag) bool { return pt.postID != input.PostID })
+
+	return nil
+}
+
+func (r *PostTagsRepository) GetAllForPost(input postsdomain.GetPostTagsInput) ([]string, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	names := []string{}
+	for _, pt := range t.postTags {
+		if pt.postID != input.PostID {
+			continue
+		}
+
+		for _, tg := range t.tags {
+			if tg.id == pt.tagID {
+				names = append(names, tg.name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+type TagsRepository struct {
+	store *Store
+}
+
+func NewTagsRepository(store *Store) *TagsRepository {
+	return &TagsRepository{store}
+}
+
+func (r *TagsRepository) Get(input tagsdomain.GetTagInput) (*dto.Tag, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, tg := range t.tags {
+		if tg.name == input.Name {
+			return &dto.Tag{ID: tg.id, Name: tg.name, PostsCount: t.countTagPosts(tg.id, func(postTag) bool { return true }), Created: tg.created}, nil
+		}
+	}
+
+	return nil, tagsdomain.ErrTagNotFound
+}
+
+func (r *TagsRepository) GetAllByPrefix(input tagsdomain.GetAllTagsByPrefixInput) ([]*dto.Tag, error) {
+	return r.getAll(input.Limit, func(tg tag) bool { return strings.HasPrefix(tg.name, input.Prefix) }, func(postTag) bool { return true })
+}
+
+func (r *TagsRepository) GetAllTrending(input tagsdomain.GetAllTrendingTagsInput) ([]*dto.Tag, error) {
+	since := input.Since.UTC().Truncate(time.Second)
+	return r.getAll(input.Limit, func(tag) bool { return true }, func(pt postTag) bool { return !pt.created.Before(since) })
+}
+
+// getAll returns the matching tags that have at least one counted post, the
+// most used first.
+func (r *TagsRepository) getAll(limit int, match func(tag) bool, counted func(postTag) bool) ([]*dto.Tag, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	tags := []*dto.Tag{}
+	for _, tg := range t.tags {
+		if !match(tg) {
+			continue
+		}
+
+		if count := t.countTagPosts(tg.id, counted); count > 0 {
+			tags = append(tags, &dto.Tag{ID: tg.id, Name: tg.name, PostsCount: count, Created: tg.created})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].PostsCount > tags[j].PostsCount || (tags[i].PostsCount == tags[j].PostsCount && tags[i].Name < tags[j].Name)
+	})
+
+	if limit > 0 && len(tags) > limit {
+		tags = tags[:limit]
+	}
+
+	return tags, nil
+}
+
+// countTagPosts counts the approved posts of a tag.
+func (t *tables) countTagPosts(tagId int, counted func(postTag) bool) int {
+	count := 0
+	for _, pt := range t.postTags {
+		if _, pending := t.pendingPosts[pt.postID]; pt.tagID == tagId && !pending && counted(pt) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (t *tables) tagID(name string) int {
+	for _, tg := range t.tags {
+		if tg.name == name {
+			return tg.id
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+	tokensdomain "github.com/itelman/forum/internal/service/tokens/domain"
+)
+
+type TokensRepository struct {
+	store *Store
+}
+
+func NewTokensRepository(store *Store) *TokensRepository {
+	return &TokensRepository{store}
+}
+
+func (r *TokensRepository) Create(input tokensdomain.CreateTokenInput) (int, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if t.user(input.UserID) == nil || (input.Scope != dto.ScopeRead && input.Scope != dto.ScopeWrite) {
+		return -1, ErrConstraint
+	}
+
+	for _, tok := range t.tokens {
+		if tok.hash == input.Hash {
+			return -1, ErrConstraint
+		}
+	}
+
+	id := t.nextID("api_tokens")
+	t.tokens = append(t.tokens, token{
+		id:      id,
+		userID:  input.UserID,
+		name:    input.Name,
+		hash:    input.Hash,
+		scope:   input.Scope,
+		created: now(),
+	})
+
+	return id, nil
+}
+
+func (r *TokensRepository) GetAll(input tokensdomain.GetAllTokensInput) ([]*dto.Token, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	tokens := []*dto.Token{}
+	for _, tok := range t.tokens {
+		if tok.userID == input.UserID {
+			tokens = append(tokens, tokenDto(tok))
+		}
+	}
+
+	if input.SortedByNewest {
+		sortNewest(tokens, func(tok *dto.Token) (time.Time, int) { return tok.Created, tok.ID })
+	}
+
+	return tokens, nil
+}
+
+func (r *TokensRepository) GetByHash(input tokensdomain.GetTokenByHashInput) (*dto.Token, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, tok := range t.tokens {
+		if tok.hash == input.Hash {
+			return tokenDto(tok), nil
+		}
+	}
+
+	return nil, tokensdomain.ErrTokenNotFound
+}
+
+func (r *TokensRepository) UpdateLastUsed(input tokensdomain.UpdateTokenLastUsedInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for i := range t.tokens {
+		if t.tokens[i].id == input.ID {
+			t.tokens[i].lastUsed = now()
+		}
+	}
+
+	return nil
+}
+
+func (r *TokensRepository) Delete(input tokensdomain.DeleteTokenInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	n := len(t.tokens)
+	t.tokens = filter(t.tokens, func(tok token) bool { return tok.id != input.ID || tok.userID != input.UserID })
+	if len(t.tokens) == n {
+		return tokensdomain.ErrTokenNotFound
+	}
+
+	return nil
+}
+
+func tokenDto(tok token) *dto.Token {
+	return &dto.Token{
+		ID:       tok.id,
+		UserID:   tok.userID,
+		Name:     tok.name,
+		Scope:    tok.scope,
+		LastUsed: tok.lastUsed,
+		Created:  tok.created,
+	}
+}
@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type UserRolesRepository struct {
+	store *Store
+}
+
+func NewUserRolesRepository(store *Store) *UserRolesRepository {
+	return &UserRolesRepository{store}
+}
+
+func (r *UserRolesRepository) Create(tx *sql.Tx, input repository.CreateUserRoleInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if input.Role != dto.RoleAdmin && input.Role != dto.RoleModerator {
+		return repository.ErrRoleNotFound
+	}
+
+	if _, ok := t.userRoles[input.UserID]; ok || t.user(input.UserID) == nil {
+		return ErrConstraint
+	}
+	t.userRoles[input.UserID] = input.Role
+
+	return nil
+}
+
+func (r *UserRolesRepository) Get(input repository.GetUserRoleInput) (string, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	role, ok := t.userRoles[input.UserID]
+	if !ok {
+		return "", repository.ErrUserRoleNotFound
+	}
+
+	return role, nil
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UsersRepository struct {
+	store *Store
+}
+
+func NewUsersRepository(store *Store) *UsersRepository {
+	return &UsersRepository{store}
+}
+
+// Create hashes passwords at the minimum bcrypt cost to keep tests fast.
+func (r *UsersRepository) Create(input repository.RegisterUserInput) error {
+	pwdHashed, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.MinCost)
+	if err != nil {
+		return err
+	}
+
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, u := range t.users {
+		if u.username == input.Username || (len(input.Email) != 0 && u.email == input.Email) {
+			return ErrConstraint
+		}
+	}
+
+	t.users = append(t.users, user{
+		id:             t.nextID("users"),
+		username:       input.Username,
+		email:          input.Email,
+		hashedPassword: pwdHashed,
+		created:        now(),
+	})
+
+	return nil
+}
+
+func (r *UsersRepository) Get(input repository.GetUserInput) (*dto.User, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, u := range t.users {
+		var value interface{}
+		switch input.Key {
+		case "id":
+			value = u.id
+		case "username":
+			value = u.username
+		case "email":
+			if len(u.email) == 0 {
+				continue
+			}
+			value = u.email
+		default:
+			return nil, fmt.Errorf("MEMORY: unknown users column %q", input.Key)
+		}
+
+		if value == input.Value {
+			return &dto.User{ID: u.id, Username: u.username, Email: u.email, Created: u.created}, nil
+		}
+	}
+
+	return nil, repository.ErrUserNotFound
+}
+
+func (r *UsersRepository) GetAllByUsernames(input repository.GetUsersByUsernamesInput) ([]*dto.User, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	users := []*dto.User{}
+	for _, u := range t.users {
+		for _, username := range input.Usernames {
+			if u.username == username {
+				users = append(users, &dto.User{ID: u.id, Username: u.username})
+				break
+			}
+		}
+	}
+
+	return users, nil
+}
+
+func (r *UsersRepository) Authenticate(input repository.AuthUserInput) (int, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, u := range t.users {
+		if u.username != input.Username {
+			continue
+		}
+
+		if len(u.hashedPassword) == 0 {
+			return -1, repository.ErrInvalidCredentials
+		}
+
+		if err := bcrypt.CompareHashAndPassword(u.hashedPassword, []byte(input.Password)); errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return -1, repository.ErrInvalidCredentials
+		} else if err != nil {
+			return -1, err
+		}
+
+		return u.id, nil
+	}
+
+	return -1, repository.ErrUserNotFound
+}
+
+func (t *tables) user(id int) *user {
+	for i := range t.users {
+		if t.users[i].id == id {
+			return &t.users[i]
+		}
+	}
+
+	return nil
+}
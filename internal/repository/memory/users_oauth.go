@@ -0,0 +1,45 @@
+package memory
+
+import (
+	oauthdomain "github.com/itelman/forum/internal/service/oauth/domain"
+)
+
+type UsersOAuthRepository struct {
+	store *Store
+}
+
+func NewUsersOAuthRepository(store *Store) *UsersOAuthRepository {
+	return &UsersOAuthRepository{store}
+}
+
+func (r *UsersOAuthRepository) GetUserID(input oauthdomain.GetUserOAuthInput) (int, error) {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	for _, u := range t.usersOAuth {
+		if u.authTypeID == input.AuthTypeID && u.accountID == input.AccountID {
+			return u.userID, nil
+		}
+	}
+
+	return -1, oauthdomain.ErrOAuthUserNotFound
+}
+
+func (r *UsersOAuthRepository) Create(input oauthdomain.CreateUserOAuthInput) error {
+	t := r.store.lock()
+	defer r.store.unlock()
+
+	if t.user(input.UserID) == nil {
+		return ErrConstraint
+	}
+
+	for _, u := range t.usersOAuth {
+		if (u.userID == input.UserID || u.accountID == input.AccountID) && u.authTypeID == input.AuthTypeID {
+			return ErrConstraint
+		}
+	}
+
+	t.usersOAuth = append(t.usersOAuth, userOAuth{userID: input.UserID, authTypeID: input.AuthTypeID, accountID: input.AccountID})
+
+	return nil
+}
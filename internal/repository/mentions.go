@@ -0,0 +1,29 @@
+package repository
+
+type CreatePostMentionsInput struct {
+	PostID  int
+	UserIDs []int
+}
+
+type DeletePostMentionsInput struct {
+	PostID  int
+	UserIDs []int
+}
+
+type GetPostMentionsInput struct {
+	PostID int
+}
+
+type CreateCommentMentionsInput struct {
+	CommentID int
+	UserIDs   []int
+}
+
+type DeleteCommentMentionsInput struct {
+	CommentID int
+	UserIDs   []int
+}
+
+type GetCommentMentionsInput struct {
+	CommentID int
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/dto"
+)
+
+type PostMentionsRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewPostMentionsRepositoryPostgres(db *sql.DB) *PostMentionsRepositoryPostgres {
+	return &PostMentionsRepositoryPostgres{db}
+}
+
+func (r *PostMentionsRepositoryPostgres) Create(tx *sql.Tx, input CreatePostMentionsInput) error {
+	return execForUsers(tx, "INSERT INTO post_mentions (post_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", input.PostID, input.UserIDs)
+}
+
+func (r *PostMentionsRepositoryPostgres) Delete(tx *sql.Tx, input DeletePostMentionsInput) error {
+	return execForUsers(tx, "DELETE FROM post_mentions WHERE post_id = $1 AND user_id = $2", input.PostID, input.UserIDs)
+}
+
+func (r *PostMentionsRepositoryPostgres) GetAllForPost(input GetPostMentionsInput) ([]*dto.User, error) {
+	return queryMentionedUsers(r.db, "SELECT users.id, users.username FROM post_mentions INNER JOIN users ON post_mentions.user_id = users.id WHERE post_mentions.post_id = $1 ORDER BY users.username", input.PostID)
+}
+
+type CommentMentionsRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewCommentMentionsRepositoryPostgres(db *sql.DB) *CommentMentionsRepositoryPostgres {
+	return &CommentMentionsRepositoryPostgres{db}
+}
+
+func (r *CommentMentionsRepositoryPostgres) Create(tx *sql.Tx, input CreateCommentMentionsInput) error {
+	return execForUsers(tx, "INSERT INTO comment_mentions (comment_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", input.CommentID, input.UserIDs)
+}
+
+func (r *CommentMentionsRepositoryPostgres) Delete(tx *sql.Tx, input DeleteCommentMentionsInput) error {
+	return execForUsers(tx, "DELETE FROM comment_mentions WHERE comment_id = $1 AND user_id = $2", input.CommentID, input.UserIDs)
+}
+
+func (r *CommentMentionsRepositoryPostgres) GetAllForComment(input GetCommentMentionsInput) ([]*dto.User, error) {
+	return queryMentionedUsers(r.db, "SELECT users.id, users.username FROM comment_mentions INNER JOIN users ON comment_mentions.user_id = users.id WHERE comment_mentions.comment_id = $1 ORDER BY users.username", input.CommentID)
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/dto"
+)
+
+type PostMentionsRepositorySqlite struct {
+	db *sql.DB
+}
+
+func NewPostMentionsRepositorySqlite(db *sql.DB) *PostMentionsRepositorySqlite {
+	return &PostMentionsRepositorySqlite{db}
+}
+
+func (r *PostMentionsRepositorySqlite) Create(tx *sql.Tx, input CreatePostMentionsInput) error {
+	return execForUsers(tx, "INSERT OR IGNORE INTO post_mentions (post_id, user_id) VALUES(?, ?)", input.PostID, input.UserIDs)
+}
+
+func (r *PostMentionsRepositorySqlite) Delete(tx *sql.Tx, input DeletePostMentionsInput) error {
+	return execForUsers(tx, "DELETE FROM post_mentions WHERE post_id = ? AND user_id = ?", input.PostID, input.UserIDs)
+}
+
+func (r *PostMentionsRepositorySqlite) GetAllForPost(input GetPostMentionsInput) ([]*dto.User, error) {
+	return queryMentionedUsers(r.db, "SELECT users.id, users.username FROM post_mentions INNER JOIN users ON post_mentions.user_id = users.id WHERE post_mentions.post_id = ? ORDER BY users.username", input.PostID)
+}
+
+type CommentMentionsRepositorySqlite struct {
+	db *sql.DB
+}
+
+func NewCommentMentionsRepositorySqlite(db *sql.DB) *CommentMentionsRepositorySqlite {
+	return &CommentMentionsRepositorySqlite{db}
+}
+
+func (r *CommentMentionsRepositorySqlite) Create(tx *sql.Tx, input CreateCommentMentionsInput) error {
+	return execForUsers(tx, "INSERT OR IGNORE INTO comment_mentions (comment_id, user_id) VALUES(?, ?)", input.CommentID, input.UserIDs)
+}
+
+func (r *CommentMentionsRepositorySqlite) Delete(tx *sql.Tx, input DeleteCommentMentionsInput) error {
+	return execForUsers(tx, "DELETE FROM comment_mentions WHERE comment_id = ? AND user_id = ?", input.CommentID, input.UserIDs)
+}
+
+func (r *CommentMentionsRepositorySqlite) GetAllForComment(input GetCommentMentionsInput) ([]*dto.User, error) {
+	return queryMentionedUsers(r.db, "SELECT users.id, users.username FROM comment_mentions INNER JOIN users ON comment_mentions.user_id = users.id WHERE comment_mentions.comment_id = ? ORDER BY users.username", input.CommentID)
+}
+
+// execForUsers runs query once per user, with targetId and the user id as args.
+func execForUsers(tx *sql.Tx, query string, targetId int, userIds []int) error {
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, userId := range userIds {
+		if _, err := stmt.Exec(targetId, userId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func queryMentionedUsers(db *sql.DB, query string, targetId int) ([]*dto.User, error) {
+	stmt, err := db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(targetId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*dto.User{}
+	for rows.Next() {
+		user := &dto.User{}
+		if err := rows.Scan(&user.ID, &user.Username); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/itelman/forum/internal/dto"
+
+type GetAllNotificationPreferencesInput struct {
+	UserID int
+}
+
+type UpsertNotificationPreferenceInput struct {
+	UserID     int
+	Preference *dto.NotificationPreference
+}
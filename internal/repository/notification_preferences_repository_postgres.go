@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/dto"
+)
+
+type NotificationPreferencesRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewNotificationPreferencesRepositoryPostgres(db *sql.DB) *NotificationPreferencesRepositoryPostgres {
+	return &NotificationPreferencesRepositoryPostgres{db}
+}
+
+func (r *NotificationPreferencesRepositoryPostgres) GetAll(input GetAllNotificationPreferencesInput) ([]*dto.NotificationPreference, error) {
+	query := "SELECT event, in_app, live, email FROM notification_preferences WHERE user_id = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	preferences := []*dto.NotificationPreference{}
+	for rows.Next() {
+		preference := &dto.NotificationPreference{}
+		if err := rows.Scan(&preference.Event, &preference.InApp, &preference.Live, &preference.Email); err != nil {
+			return nil, err
+		}
+
+		preferences = append(preferences, preference)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+func (r *NotificationPreferencesRepositoryPostgres) Upsert(tx *sql.Tx, input UpsertNotificationPreferenceInput) error {
+	query := "INSERT INTO notification_preferences (user_id, event, in_app, live, email) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (user_id, event) DO UPDATE SET in_app = excluded.in_app, live = excluded.live, email = excluded.email"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.UserID, input.Preference.Event, input.Preference.InApp, input.Preference.Live, input.Preference.Email); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/itelman/forum/pkg/pagination"
+)
+
+// CreateNotificationInput leaves the ids that do not apply to Type at 0.
+type CreateNotificationInput struct {
+	UserID            int
+	ActorID           int
+	Type              string
+	PostID            int
+	CommentID         int
+	PostReactionID    int
+	CommentReactionID int
+}
+
+type GetNotificationInput struct {
+	ID     int
+	UserID int
+}
+
+type GetAllNotificationsInput struct {
+	UserID         int
+	Types          []string
+	ExcludeTypes   []string
+	SortedByNewest bool
+	Cursor         *pagination.Cursor
+	Limit          int
+}
+
+// GetAllUnreadNotificationsSinceInput selects notifications created after
+// Since and up to Until.
+type GetAllUnreadNotificationsSinceInput struct {
+	UserID       int
+	ExcludeTypes []string
+	Since        time.Time
+	Until        time.Time
+}
+
+type CountUnreadNotificationsInput struct {
+	UserID       int
+	ExcludeTypes []string
+}
+
+type MarkNotificationReadInput struct {
+	ID     int
+	UserID int
+}
+
+type MarkAllNotificationsReadInput struct {
+	UserID int
+}
+
+type DeleteNotificationInput struct {
+	ID     int
+	UserID int
+}
+
+var (
+	ErrNotificationNotFound = errors.New("DATABASE: Notification not found")
+)
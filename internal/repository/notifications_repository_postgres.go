@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/itelman/forum/internal/dto"
+)
+
+type NotificationsRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewNotificationsRepositoryPostgres(db *sql.DB) *NotificationsRepositoryPostgres {
+	return &NotificationsRepositoryPostgres{db}
+}
+
+func (r *NotificationsRepositoryPostgres) Create(tx *sql.Tx, input CreateNotificationInput) (int, error) {
+	query := "INSERT INTO notifications (user_id, actor_id, type, post_id, comment_id, post_reaction_id, comment_reaction_id) VALUES ($1, $2, $3, $4, NULLIF($5, 0), NULLIF($6, 0), NULLIF($7, 0)) RETURNING id"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return -1, err
+	}
+	defer stmt.Close()
+
+	var id int
+	if err := stmt.QueryRow(input.UserID, input.ActorID, input.Type, input.PostID, input.CommentID, input.PostReactionID, input.CommentReactionID).Scan(&id); err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
+
+func (r *NotificationsRepositoryPostgres) Get(input GetNotificationInput) (*dto.Notification, error) {
+	query := selectNotifications + " WHERE n.id = $1 AND n.user_id = $2"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	notification, err := scanNotification(stmt.QueryRow(input.ID, input.UserID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotificationNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+func (r *NotificationsRepositoryPostgres) GetAll(input GetAllNotificationsInput) ([]*dto.Notification, error) {
+	query := selectNotifications + " WHERE n.user_id = ?"
+	args := []interface{}{input.UserID}
+
+	if len(input.Types) != 0 {
+		query += " AND n.type IN (" + placeholders(len(input.Types)) + ")"
+		for _, t := range input.Types {
+			args = append(args, t)
+		}
+	}
+
+	if len(input.ExcludeTypes) != 0 {
+		query += " AND n.type NOT IN (" + placeholders(len(input.ExcludeTypes)) + ")"
+		for _, t := range input.ExcludeTypes {
+			args = append(args, t)
+		}
+	}
+
+	if input.Cursor != nil {
+		query += " AND (n.created, n.id) < (?, ?)"
+		args = append(args, input.Cursor.Args()...)
+	}
+
+	if input.SortedByNewest {
+		query += " ORDER BY n.created DESC, n.id DESC"
+	}
+
+	if input.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, input.Limit)
+	}
+
+	stmt, err := r.db.Prepare(Rebind(query))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := []*dto.Notification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationsRepositoryPostgres) GetAllUnreadSince(input GetAllUnreadNotificationsSinceInput) ([]*dto.Notification, error) {
+	query := selectNotifications + " WHERE n.user_id = ? AND n.read = FALSE AND n.created > ? AND n.created <= ?"
+	args := []interface{}{input.UserID, input.Since.UTC().Format(TimeLayout), input.Until.UTC().Format(TimeLayout)}
+
+	if len(input.ExcludeTypes) != 0 {
+		query += " AND n.type NOT IN (" + placeholders(len(input.ExcludeTypes)) + ")"
+		for _, t := range input.ExcludeTypes {
+			args = append(args, t)
+		}
+	}
+
+	query += " ORDER BY n.created DESC, n.id DESC"
+
+	stmt, err := r.db.Prepare(Rebind(query))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := []*dto.Notification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationsRepositoryPostgres) CountUnread(input CountUnreadNotificationsInput) (int, error) {
+	query := "SELECT COUNT(*) FROM notifications WHERE user_id = ? AND read = FALSE"
+	args := []interface{}{input.UserID}
+
+	if len(input.ExcludeTypes) != 0 {
+		query += " AND type NOT IN (" + placeholders(len(input.ExcludeTypes)) + ")"
+		for _, t := range input.ExcludeTypes {
+			args = append(args, t)
+		}
+	}
+
+	stmt, err := r.db.Prepare(Rebind(query))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var count int
+	if err := stmt.QueryRow(args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *NotificationsRepositoryPostgres) MarkRead(input MarkNotificationReadInput) error {
+	return r.execOne("UPDATE notifications SET read = TRUE WHERE id = $1 AND user_id = $2", input.ID, input.UserID)
+}
+
+func (r *NotificationsRepositoryPostgres) MarkAllRead(input MarkAllNotificationsReadInput) error {
+	query := "UPDATE notifications SET read = TRUE WHERE user_id = $1 AND read = FALSE"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.UserID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *NotificationsRepositoryPostgres) Delete(input DeleteNotificationInput) error {
+	return r.execOne("DELETE FROM notifications WHERE id = $1 AND user_id = $2", input.ID, input.UserID)
+}
+
+// execOne runs a statement against a single notification owned by userId and
+// returns ErrNotificationNotFound if no row matched.
+func (r *NotificationsRepositoryPostgres) execOne(query string, id, userId int) error {
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(id, userId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotificationNotFound
+	}
+
+	return nil
+}
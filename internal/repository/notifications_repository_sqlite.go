@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/itelman/forum/internal/dto"
+)
+
+const selectNotifications = "SELECT n.id, n.type, u.id, u.username, n.post_id, COALESCE(n.comment_id, 0), n.read, n.created FROM notifications n INNER JOIN users u ON n.actor_id = u.id"
+
+type NotificationsRepositorySqlite struct {
+	db *sql.DB
+}
+
+func NewNotificationsRepositorySqlite(db *sql.DB) *NotificationsRepositorySqlite {
+	return &NotificationsRepositorySqlite{db}
+}
+
+func (r *NotificationsRepositorySqlite) Create(tx *sql.Tx, input CreateNotificationInput) (int, error) {
+	query := "INSERT INTO notifications (user_id, actor_id, type, post_id, comment_id, post_reaction_id, comment_reaction_id) VALUES(?, ?, ?, ?, NULLIF(?, 0), NULLIF(?, 0), NULLIF(?, 0))"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return -1, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(input.UserID, input.ActorID, input.Type, input.PostID, input.CommentID, input.PostReactionID, input.CommentReactionID)
+	if err != nil {
+		return -1, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, err
+	}
+
+	return int(id), nil
+}
+
+func (r *NotificationsRepositorySqlite) Get(input GetNotificationInput) (*dto.Notification, error) {
+	query := selectNotifications + " WHERE n.id = ? AND n.user_id = ?"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	notification, err := scanNotification(stmt.QueryRow(input.ID, input.UserID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotificationNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+func (r *NotificationsRepositorySqlite) GetAll(input GetAllNotificationsInput) ([]*dto.Notification, error) {
+	query := selectNotifications + " WHERE n.user_id = ?"
+	args := []interface{}{input.UserID}
+
+	if len(input.Types) != 0 {
+		query += " AND n.type IN (" + placeholders(len(input.Types)) + ")"
+		for _, t := range input.Types {
+			args = append(args, t)
+		}
+	}
+
+	if len(input.ExcludeTypes) != 0 {
+		query += " AND n.type NOT IN (" + placeholders(len(input.ExcludeTypes)) + ")"
+		for _, t := range input.ExcludeTypes {
+			args = append(args, t)
+		}
+	}
+
+	if input.Cursor != nil {
+		query += " AND (n.created, n.id) < (?, ?)"
+		args = append(args, input.Cursor.Args()...)
+	}
+
+	if input.SortedByNewest {
+		query += " ORDER BY n.created DESC, n.id DESC"
+	}
+
+	if input.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, input.Limit)
+	}
+
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := []*dto.Notification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationsRepositorySqlite) GetAllUnreadSince(input GetAllUnreadNotificationsSinceInput) ([]*dto.Notification, error) {
+	query := selectNotifications + " WHERE n.user_id = ? AND n.read = 0 AND n.created > ? AND n.created <= ?"
+	args := []interface{}{input.UserID, input.Since.UTC().Format(TimeLayout), input.Until.UTC().Format(TimeLayout)}
+
+	if len(input.ExcludeTypes) != 0 {
+		query += " AND n.type NOT IN (" + placeholders(len(input.ExcludeTypes)) + ")"
+		for _, t := range input.ExcludeTypes {
+			args = append(args, t)
+		}
+	}
+
+	query += " ORDER BY n.created DESC, n.id DESC"
+
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := []*dto.Notification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationsRepositorySqlite) CountUnread(input CountUnreadNotificationsInput) (int, error) {
+	query := "SELECT COUNT(*) FROM notifications WHERE user_id = ? AND read = 0"
+	args := []interface{}{input.UserID}
+
+	if len(input.ExcludeTypes) != 0 {
+		query += " AND type NOT IN (" + placeholders(len(input.ExcludeTypes)) + ")"
+		for _, t := range input.ExcludeTypes {
+			args = append(args, t)
+		}
+	}
+
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var count int
+	if err := stmt.QueryRow(args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *NotificationsRepositorySqlite) MarkRead(input MarkNotificationReadInput) error {
+	return r.execOne("UPDATE notifications SET read = 1 WHERE id = ? AND user_id = ?", input.ID, input.UserID)
+}
+
+func (r *NotificationsRepositorySqlite) MarkAllRead(input MarkAllNotificationsReadInput) error {
+	query := "UPDATE notifications SET read = 1 WHERE user_id = ? AND read = 0"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.UserID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *NotificationsRepositorySqlite) Delete(input DeleteNotificationInput) error {
+	return r.execOne("DELETE FROM notifications WHERE id = ? AND user_id = ?", input.ID, input.UserID)
+}
+
+// execOne runs a statement against a single notification owned by userId and
+// returns ErrNotificationNotFound if no row matched.
+func (r *NotificationsRepositorySqlite) execOne(query string, id, userId int) error {
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(id, userId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+func scanNotification(row rowScanner) (*dto.Notification, error) {
+	notification := &dto.Notification{Actor: &dto.User{}}
+
+	if err := row.Scan(
+		&notification.ID,
+		&notification.Type,
+		&notification.Actor.ID,
+		&notification.Actor.Username,
+		&notification.PostID,
+		&notification.CommentID,
+		&notification.Read,
+		&notification.Created,
+	); err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
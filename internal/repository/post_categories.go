@@ -0,0 +1,21 @@
+package repository
+
+import "errors"
+
+type CreatePostCategoriesInput struct {
+	PostID       int
+	CategoriesID []int
+}
+
+type GetPostCategoriesInput struct {
+	PostID int
+}
+
+type ReassignPostCategoriesInput struct {
+	FromCategoryID int
+	ToCategoryID   int
+}
+
+var (
+	ErrUnknownCategory = errors.New("DATABASE: Unknown category")
+)
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+type PostCategoriesRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewPostCategoriesRepositoryPostgres(db *sql.DB) *PostCategoriesRepositoryPostgres {
+	return &PostCategoriesRepositoryPostgres{db}
+}
+
+func (r *PostCategoriesRepositoryPostgres) Create(tx *sql.Tx, input CreatePostCategoriesInput) error {
+	query := "INSERT INTO post_categories (post_id, category_id) VALUES ($1, $2)"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, catgId := range input.CategoriesID {
+		_, err := stmt.Exec(input.PostID, catgId)
+
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == "foreign_key_violation" && pqErr.Constraint == "post_categories_category_id_fkey" {
+			return ErrUnknownCategory
+		} else if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *PostCategoriesRepositoryPostgres) GetAllForPost(input GetPostCategoriesInput) ([]string, error) {
+	query := "SELECT categories.name FROM post_categories INNER JOIN categories ON post_categories.category_id = categories.id WHERE post_categories.post_id = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(input.PostID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []string
+	for rows.Next() {
+		var category string
+		if err := rows.Scan(&category); err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *PostCategoriesRepositoryPostgres) Reassign(tx *sql.Tx, input ReassignPostCategoriesInput) error {
+	query := "INSERT INTO post_categories (post_id, category_id) SELECT post_id, $1::INTEGER FROM post_categories WHERE category_id = $2 ON CONFLICT DO NOTHING"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.ToCategoryID, input.FromCategoryID); err != nil {
+		return err
+	}
+
+	return nil
+}
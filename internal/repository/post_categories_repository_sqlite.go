@@ -1,9 +1,9 @@
-package adapters
+package repository
 
 import (
 	"database/sql"
 	"errors"
-	"github.com/itelman/forum/internal/service/posts/domain"
+
 	"github.com/mattn/go-sqlite3"
 )
 
@@ -15,7 +15,7 @@ func NewPostCategoriesRepositorySqlite(db *sql.DB) *PostCategoriesRepositorySqli
 	return &PostCategoriesRepositorySqlite{db}
 }
 
-func (r *PostCategoriesRepositorySqlite) Create(tx *sql.Tx, input domain.CreatePostCategoriesInput) error {
+func (r *PostCategoriesRepositorySqlite) Create(tx *sql.Tx, input CreatePostCategoriesInput) error {
 	query := "INSERT INTO post_categories (post_id, category_id) VALUES(?, ?)"
 	stmt, err := tx.Prepare(query)
 	if err != nil {
@@ -28,7 +28,7 @@ func (r *PostCategoriesRepositorySqlite) Create(tx *sql.Tx, input domain.CreateP
 
 		var sqliteErr sqlite3.Error
 		if errors.As(err, &sqliteErr) && errors.Is(sqliteErr.Code, sqlite3.ErrConstraint) && errors.Is(sqliteErr.ExtendedCode, sqlite3.ErrConstraintForeignKey) {
-			return domain.ErrPostsBadRequest
+			return ErrUnknownCategory
 		} else if err != nil {
 			return err
 		}
@@ -37,7 +37,7 @@ func (r *PostCategoriesRepositorySqlite) Create(tx *sql.Tx, input domain.CreateP
 	return nil
 }
 
-func (r *PostCategoriesRepositorySqlite) GetAllForPost(input domain.GetPostCategoriesInput) ([]string, error) {
+func (r *PostCategoriesRepositorySqlite) GetAllForPost(input GetPostCategoriesInput) ([]string, error) {
 	query := "SELECT categories.name FROM post_categories INNER JOIN categories ON post_categories.category_id = categories.id WHERE post_categories.post_id = ?"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
@@ -66,3 +66,18 @@ func (r *PostCategoriesRepositorySqlite) GetAllForPost(input domain.GetPostCateg
 
 	return categories, nil
 }
+
+func (r *PostCategoriesRepositorySqlite) Reassign(tx *sql.Tx, input ReassignPostCategoriesInput) error {
+	query := "INSERT OR IGNORE INTO post_categories (post_id, category_id) SELECT post_id, ? FROM post_categories WHERE category_id = ?"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.ToCategoryID, input.FromCategoryID); err != nil {
+		return err
+	}
+
+	return nil
+}
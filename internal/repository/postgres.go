@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+)
+
+// nowUTC is CURRENT_TIMESTAMP as the UTC wall time that PostgreSQL's
+// TIMESTAMP columns hold, like SQLite's.
+const nowUTC = "(CURRENT_TIMESTAMP AT TIME ZONE 'UTC')"
+
+// Rebind numbers the ? placeholders of a query as PostgreSQL's $1, $2, ...,
+// so that queries assembled from clauses can be written once for both
+// databases.
+func Rebind(query string) string {
+	var b strings.Builder
+
+	n := 0
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+
+		n++
+		b.WriteString("$" + strconv.Itoa(n))
+	}
+
+	return b.String()
+}
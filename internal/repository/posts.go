@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/itelman/forum/pkg/pagination"
+)
+
+type CreatePostInput struct {
+	UserID  int
+	Title   string
+	Content string
+}
+
+type GetPostInput struct {
+	ID         int
+	AuthUserID int
+}
+
+type GetAllPostsInput struct {
+	Pending        bool
+	SortedByNewest bool
+	Sort           string
+	Period         string
+	Cursor         *pagination.Cursor
+	Limit          int
+}
+
+type GetPostsByFiltersInput struct {
+	CategoryIDs    []int
+	MatchAll       bool
+	ExcludedIDs    []int
+	Created        bool
+	Liked          bool
+	AuthUserID     int
+	SortedByNewest bool
+	Sort           string
+	Period         string
+	Cursor         *pagination.Cursor
+	Limit          int
+}
+
+type GetAllPostsForTagInput struct {
+	TagID          int
+	SortedByNewest bool
+	Cursor         *pagination.Cursor
+	Limit          int
+}
+
+type GetAllPostsForUserInput struct {
+	UserID         int
+	SortedByNewest bool
+	Cursor         *pagination.Cursor
+	Limit          int
+}
+
+type GetAllCreatedPostsInput struct {
+	AuthUserID     int
+	SortedByNewest bool
+	Cursor         *pagination.Cursor
+	Limit          int
+}
+
+type GetAllReactedPostsInput struct {
+	AuthUserID     int
+	SortedByNewest bool
+	Cursor         *pagination.Cursor
+	Limit          int
+}
+
+type GetAllCommentedPostsInput struct {
+	AuthUserID     int
+	SortedByNewest bool
+	Cursor         *pagination.Cursor
+	Limit          int
+}
+
+type UpdatePostInput struct {
+	ID      int
+	Title   string
+	Content string
+}
+
+type UpdatePostReactionsCountInput struct {
+	PostID int
+}
+
+type DeletePostInput struct {
+	ID int
+}
+
+var (
+	ErrPostNotFound = errors.New("DATABASE: Post not found")
+)
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/itelman/forum/internal/dto"
+)
+
+// PostsRepositoryPostgres builds its listings with the same postsQuery as the
+// SQLite repository, in PostgreSQL's dialect.
+type PostsRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewPostsRepositoryPostgres(db *sql.DB) *PostsRepositoryPostgres {
+	return &PostsRepositoryPostgres{db}
+}
+
+func (r *PostsRepositoryPostgres) Create(tx *sql.Tx, input CreatePostInput) (int, error) {
+	query := "INSERT INTO posts (user_id, title, content) VALUES ($1, $2, $3) RETURNING id"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return -1, err
+	}
+	defer stmt.Close()
+
+	var id int
+	if err := stmt.QueryRow(input.UserID, input.Title, input.Content).Scan(&id); err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
+
+func (r *PostsRepositoryPostgres) Get(input GetPostInput) (*dto.Post, error) {
+	q := &postsQuery{postgres: true, authUserID: input.AuthUserID}
+	q.where("posts.id = ?", input.ID)
+
+	stmt, err := r.db.Prepare(q.build())
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	post, err := scanPost(stmt.QueryRow(q.args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPostNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (r *PostsRepositoryPostgres) GetAll(input GetAllPostsInput) ([]*dto.Post, error) {
+	q := &postsQuery{postgres: true, sortedByNewest: input.SortedByNewest, limit: input.Limit}
+	if input.Pending {
+		q.where(pendingClause)
+	} else {
+		q.where("NOT " + pendingClause)
+	}
+	q.sort(input.Sort, input.Period, input.Cursor)
+
+	return r.getMany(q)
+}
+
+func (r *PostsRepositoryPostgres) GetManyByFilters(input GetPostsByFiltersInput) ([]*dto.Post, error) {
+	q := &postsQuery{postgres: true, authUserID: input.AuthUserID, sortedByNewest: input.SortedByNewest, limit: input.Limit}
+	q.where("NOT " + pendingClause)
+
+	catgIn := "post_categories.category_id IN (" + placeholders(len(input.CategoryIDs)) + ")"
+	if len(input.CategoryIDs) != 0 && input.MatchAll {
+		q.where("(SELECT COUNT(*) FROM post_categories WHERE post_categories.post_id = posts.id AND "+catgIn+") = ?", append(idArgs(input.CategoryIDs), len(input.CategoryIDs))...)
+	} else if len(input.CategoryIDs) != 0 {
+		q.where("EXISTS (SELECT 1 FROM post_categories WHERE post_categories.post_id = posts.id AND "+catgIn+")", idArgs(input.CategoryIDs)...)
+	}
+
+	if len(input.ExcludedIDs) != 0 {
+		q.where("NOT EXISTS (SELECT 1 FROM post_categories WHERE post_categories.post_id = posts.id AND post_categories.category_id IN ("+placeholders(len(input.ExcludedIDs))+"))", idArgs(input.ExcludedIDs)...)
+	}
+
+	if input.Created {
+		q.where("posts.user_id = ?", input.AuthUserID)
+	}
+
+	if input.Liked {
+		q.where("post_reactions.is_like = 1")
+	}
+
+	q.sort(input.Sort, input.Period, input.Cursor)
+
+	return r.getMany(q)
+}
+
+func (r *PostsRepositoryPostgres) GetAllForTag(input GetAllPostsForTagInput) ([]*dto.Post, error) {
+	q := &postsQuery{postgres: true, sortedByNewest: input.SortedByNewest, limit: input.Limit}
+	q.where("NOT " + pendingClause)
+	q.where("EXISTS (SELECT 1 FROM post_tags WHERE post_tags.post_id = posts.id AND post_tags.tag_id = ?)", input.TagID)
+	q.sort("", "", input.Cursor)
+
+	return r.getMany(q)
+}
+
+func (r *PostsRepositoryPostgres) GetAllForUser(input GetAllPostsForUserInput) ([]*dto.Post, error) {
+	q := &postsQuery{postgres: true, sortedByNewest: input.SortedByNewest, limit: input.Limit}
+	q.where("NOT " + pendingClause)
+	q.where("posts.user_id = ?", input.UserID)
+	q.sort("", "", input.Cursor)
+
+	return r.getMany(q)
+}
+
+func (r *PostsRepositoryPostgres) GetAllCreated(input GetAllCreatedPostsInput) ([]*dto.Post, error) {
+	q := &postsQuery{postgres: true, authUserID: input.AuthUserID, sortedByNewest: input.SortedByNewest, limit: input.Limit}
+	q.where("posts.user_id = ?", input.AuthUserID)
+	q.sort("", "", input.Cursor)
+
+	return r.getMany(q)
+}
+
+func (r *PostsRepositoryPostgres) GetAllReacted(input GetAllReactedPostsInput) ([]*dto.Post, error) {
+	q := &postsQuery{postgres: true, authUserID: input.AuthUserID, sortedByNewest: input.SortedByNewest, limit: input.Limit}
+	q.where("post_reactions.user_id IS NOT NULL")
+	q.sort("", "", input.Cursor)
+
+	return r.getMany(q)
+}
+
+func (r *PostsRepositoryPostgres) GetAllCommented(input GetAllCommentedPostsInput) ([]*dto.Post, error) {
+	q := &postsQuery{postgres: true, authUserID: input.AuthUserID, sortedByNewest: input.SortedByNewest, limit: input.Limit}
+	q.where("EXISTS (SELECT 1 FROM comments WHERE comments.post_id = posts.id AND comments.user_id = ?)", input.AuthUserID)
+	q.sort("", "", input.Cursor)
+
+	return r.getMany(q)
+}
+
+func (r *PostsRepositoryPostgres) Update(tx *sql.Tx, input UpdatePostInput) error {
+	query := "UPDATE posts SET title = $1, content = $2, edited = " + nowUTC + " WHERE id = $3"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.Title, input.Content, input.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostsRepositoryPostgres) UpdateReactionsCount(tx *sql.Tx, input UpdatePostReactionsCountInput) error {
+	query := "UPDATE posts SET likes = (SELECT COUNT(*) FROM post_reactions WHERE post_id = posts.id AND is_like = 1), dislikes = (SELECT COUNT(*) FROM post_reactions WHERE post_id = posts.id AND is_like = 0) WHERE id = $1"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.PostID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostsRepositoryPostgres) Delete(input DeletePostInput) error {
+	query := "DELETE FROM posts WHERE id = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostsRepositoryPostgres) getMany(q *postsQuery) ([]*dto.Post, error) {
+	stmt, err := r.db.Prepare(q.build())
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(q.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	posts := []*dto.Post{}
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		posts = append(posts, post)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
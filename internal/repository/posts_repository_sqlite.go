@@ -0,0 +1,322 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/pkg/pagination"
+)
+
+const (
+	selectPosts = "SELECT posts.id, users.id, users.username, posts.title, posts.content, posts.likes, posts.dislikes, posts.created, COALESCE(post_reactions.is_like, -1) AS is_like, EXISTS (SELECT 1 FROM pending_posts WHERE pending_posts.post_id = posts.id) AS pending, "
+	fromPosts   = " FROM posts INNER JOIN users ON posts.user_id = users.id LEFT JOIN post_reactions ON post_reactions.post_id = posts.id AND post_reactions.user_id = ?"
+
+	pendingClause = "EXISTS (SELECT 1 FROM pending_posts WHERE pending_posts.post_id = posts.id)"
+)
+
+type PostsRepositorySqlite struct {
+	db *sql.DB
+}
+
+func NewPostsRepositorySqlite(db *sql.DB) *PostsRepositorySqlite {
+	return &PostsRepositorySqlite{db}
+}
+
+func (r *PostsRepositorySqlite) Create(tx *sql.Tx, input CreatePostInput) (int, error) {
+	query := "INSERT INTO posts (user_id, title, content) VALUES(?, ?, ?)"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return -1, err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(input.UserID, input.Title, input.Content)
+	if err != nil {
+		return -1, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return -1, err
+	}
+
+	return int(id), nil
+}
+
+func (r *PostsRepositorySqlite) Get(input GetPostInput) (*dto.Post, error) {
+	q := &postsQuery{authUserID: input.AuthUserID}
+	q.where("posts.id = ?", input.ID)
+
+	stmt, err := r.db.Prepare(q.build())
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	post, err := scanPost(stmt.QueryRow(q.args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPostNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (r *PostsRepositorySqlite) GetAll(input GetAllPostsInput) ([]*dto.Post, error) {
+	q := &postsQuery{sortedByNewest: input.SortedByNewest, limit: input.Limit}
+	if input.Pending {
+		q.where(pendingClause)
+	} else {
+		q.where("NOT " + pendingClause)
+	}
+	q.sort(input.Sort, input.Period, input.Cursor)
+
+	return r.getMany(q)
+}
+
+func (r *PostsRepositorySqlite) GetManyByFilters(input GetPostsByFiltersInput) ([]*dto.Post, error) {
+	q := &postsQuery{authUserID: input.AuthUserID, sortedByNewest: input.SortedByNewest, limit: input.Limit}
+	q.where("NOT " + pendingClause)
+
+	catgIn := "post_categories.category_id IN (" + placeholders(len(input.CategoryIDs)) + ")"
+	if len(input.CategoryIDs) != 0 && input.MatchAll {
+		q.where("(SELECT COUNT(*) FROM post_categories WHERE post_categories.post_id = posts.id AND "+catgIn+") = ?", append(idArgs(input.CategoryIDs), len(input.CategoryIDs))...)
+	} else if len(input.CategoryIDs) != 0 {
+		q.where("EXISTS (SELECT 1 FROM post_categories WHERE post_categories.post_id = posts.id AND "+catgIn+")", idArgs(input.CategoryIDs)...)
+	}
+
+	if len(input.ExcludedIDs) != 0 {
+		q.where("NOT EXISTS (SELECT 1 FROM post_categories WHERE post_categories.post_id = posts.id AND post_categories.category_id IN ("+placeholders(len(input.ExcludedIDs))+"))", idArgs(input.ExcludedIDs)...)
+	}
+
+	if input.Created {
+		q.where("posts.user_id = ?", input.AuthUserID)
+	}
+
+	if input.Liked {
+		q.where("post_reactions.is_like = 1")
+	}
+
+	q.sort(input.Sort, input.Period, input.Cursor)
+
+	return r.getMany(q)
+}
+
+func (r *PostsRepositorySqlite) GetAllForTag(input GetAllPostsForTagInput) ([]*dto.Post, error) {
+	q := &postsQuery{sortedByNewest: input.SortedByNewest, limit: input.Limit}
+	q.where("NOT " + pendingClause)
+	q.where("EXISTS (SELECT 1 FROM post_tags WHERE post_tags.post_id = posts.id AND post_tags.tag_id = ?)", input.TagID)
+	q.sort("", "", input.Cursor)
+
+	return r.getMany(q)
+}
+
+func (r *PostsRepositorySqlite) GetAllForUser(input GetAllPostsForUserInput) ([]*dto.Post, error) {
+	q := &postsQuery{sortedByNewest: input.SortedByNewest, limit: input.Limit}
+	q.where("NOT " + pendingClause)
+	q.where("posts.user_id = ?", input.UserID)
+	q.sort("", "", input.Cursor)
+
+	return r.getMany(q)
+}
+
+func (r *PostsRepositorySqlite) GetAllCreated(input GetAllCreatedPostsInput) ([]*dto.Post, error) {
+	q := &postsQuery{authUserID: input.AuthUserID, sortedByNewest: input.SortedByNewest, limit: input.Limit}
+	q.where("posts.user_id = ?", input.AuthUserID)
+	q.sort("", "", input.Cursor)
+
+	return r.getMany(q)
+}
+
+func (r *PostsRepositorySqlite) GetAllReacted(input GetAllReactedPostsInput) ([]*dto.Post, error) {
+	q := &postsQuery{authUserID: input.AuthUserID, sortedByNewest: input.SortedByNewest, limit: input.Limit}
+	q.where("post_reactions.user_id IS NOT NULL")
+	q.sort("", "", input.Cursor)
+
+	return r.getMany(q)
+}
+
+func (r *PostsRepositorySqlite) GetAllCommented(input GetAllCommentedPostsInput) ([]*dto.Post, error) {
+	q := &postsQuery{authUserID: input.AuthUserID, sortedByNewest: input.SortedByNewest, limit: input.Limit}
+	q.where("EXISTS (SELECT 1 FROM comments WHERE comments.post_id = posts.id AND comments.user_id = ?)", input.AuthUserID)
+	q.sort("", "", input.Cursor)
+
+	return r.getMany(q)
+}
+
+func (r *PostsRepositorySqlite) Update(tx *sql.Tx, input UpdatePostInput) error {
+	query := "UPDATE posts SET title = ?, content = ?, edited = CURRENT_TIMESTAMP WHERE id = ?"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.Title, input.Content, input.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostsRepositorySqlite) UpdateReactionsCount(tx *sql.Tx, input UpdatePostReactionsCountInput) error {
+	query := "UPDATE posts SET likes = (SELECT COUNT(*) FROM post_reactions WHERE post_id = posts.id AND is_like = 1), dislikes = (SELECT COUNT(*) FROM post_reactions WHERE post_id = posts.id AND is_like = 0) WHERE id = ?"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.PostID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostsRepositorySqlite) Delete(input DeletePostInput) error {
+	query := "DELETE FROM posts WHERE id = ?"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostsRepositorySqlite) getMany(q *postsQuery) ([]*dto.Post, error) {
+	stmt, err := r.db.Prepare(q.build())
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(q.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	posts := []*dto.Post{}
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		posts = append(posts, post)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// postsQuery builds every post listing from the same select, so that a post
+// always comes back with its author, the auth user's reaction and its score.
+type postsQuery struct {
+	postgres       bool
+	authUserID     int
+	score          string
+	conditions     []string
+	args           []interface{}
+	order          string
+	sortedByNewest bool
+	limit          int
+}
+
+func (q *postsQuery) where(condition string, args ...interface{}) {
+	q.conditions = append(q.conditions, condition)
+	q.args = append(q.args, args...)
+}
+
+// sort orders the posts by the score of sortBy, or by creation time if it has
+// none, and keeps the ones after cursor.
+func (q *postsQuery) sort(sortBy, period string, cursor *pagination.Cursor) {
+	scores, periods := postScores, periodClauses
+	if q.postgres {
+		scores, periods = postScoresPostgres, periodClausesPostgres
+	}
+
+	score, scored := scores[sortBy]
+	if scored {
+		q.score = score
+	}
+
+	if clause, ok := periods[period]; ok && sortBy == pagination.SortTop {
+		q.where(clause)
+	}
+
+	if cursor != nil && scored {
+		q.where("("+score+", posts.id) < (?, ?)", cursor.ScoreArgs()...)
+	} else if cursor != nil {
+		q.where("(posts.created, posts.id) < (?, ?)", cursor.Args()...)
+	}
+
+	if scored {
+		q.order = score + " DESC, posts.id DESC"
+	} else if q.sortedByNewest {
+		q.order = "posts.created DESC, posts.id DESC"
+	}
+}
+
+func (q *postsQuery) build() string {
+	score := q.score
+	if len(score) == 0 {
+		score = "0"
+	}
+
+	query := selectPosts + score + fromPosts
+	q.args = append([]interface{}{q.authUserID}, q.args...)
+
+	if len(q.conditions) != 0 {
+		query += " WHERE " + strings.Join(q.conditions, " AND ")
+	}
+
+	if len(q.order) != 0 {
+		query += " ORDER BY " + q.order
+	}
+
+	if q.limit > 0 {
+		query += " LIMIT ?"
+		q.args = append(q.args, q.limit)
+	}
+
+	if q.postgres {
+		query = Rebind(query)
+	}
+
+	return query
+}
+
+func scanPost(row rowScanner) (*dto.Post, error) {
+	post := &dto.Post{User: &dto.User{}}
+
+	if err := row.Scan(
+		&post.ID,
+		&post.User.ID,
+		&post.User.Username,
+		&post.Title,
+		&post.Content,
+		&post.Likes,
+		&post.Dislikes,
+		&post.Created,
+		&post.AuthUserReaction,
+		&post.Pending,
+		&post.Score,
+	); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
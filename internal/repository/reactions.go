@@ -0,0 +1,38 @@
+package repository
+
+import "errors"
+
+type CreatePostReactionInput struct {
+	PostID int
+	UserID int
+	IsLike int
+}
+
+type GetPostReactionInput struct {
+	PostID int
+	UserID int
+}
+
+type DeletePostReactionInput struct {
+	ID int
+}
+
+type CreateCommentReactionInput struct {
+	CommentID int
+	UserID    int
+	IsLike    int
+}
+
+type GetCommentReactionInput struct {
+	CommentID int
+	UserID    int
+}
+
+type DeleteCommentReactionInput struct {
+	ID int
+}
+
+var (
+	ErrPostReactionNotFound    = errors.New("DATABASE: Post reaction not found")
+	ErrCommentReactionNotFound = errors.New("DATABASE: Comment reaction not found")
+)
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/itelman/forum/internal/dto"
+)
+
+type PostReactionsRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewPostReactionsRepositoryPostgres(db *sql.DB) *PostReactionsRepositoryPostgres {
+	return &PostReactionsRepositoryPostgres{db}
+}
+
+func (r *PostReactionsRepositoryPostgres) Get(input GetPostReactionInput) (*dto.PostReaction, error) {
+	query := "SELECT id, post_id, user_id, is_like, created FROM post_reactions WHERE post_id = $1 AND user_id = $2"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	reaction := &dto.PostReaction{User: &dto.User{}}
+	if err := stmt.QueryRow(input.PostID, input.UserID).Scan(
+		&reaction.ID,
+		&reaction.PostID,
+		&reaction.User.ID,
+		&reaction.IsLike,
+		&reaction.Created,
+	); errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPostReactionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return reaction, nil
+}
+
+func (r *PostReactionsRepositoryPostgres) Insert(tx *sql.Tx, input CreatePostReactionInput) (int, error) {
+	return insertReactionPostgres(tx, "INSERT INTO post_reactions (post_id, user_id, is_like) VALUES ($1, $2, $3) RETURNING id", input.PostID, input.UserID, input.IsLike)
+}
+
+func (r *PostReactionsRepositoryPostgres) Delete(tx *sql.Tx, input DeletePostReactionInput) error {
+	return deleteReaction(tx, "DELETE FROM post_reactions WHERE id = $1", input.ID)
+}
+
+type CommentReactionsRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewCommentReactionsRepositoryPostgres(db *sql.DB) *CommentReactionsRepositoryPostgres {
+	return &CommentReactionsRepositoryPostgres{db}
+}
+
+func (r *CommentReactionsRepositoryPostgres) Get(input GetCommentReactionInput) (*dto.CommentReaction, error) {
+	query := "SELECT id, comment_id, user_id, is_like, created FROM comment_reactions WHERE comment_id = $1 AND user_id = $2"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	reaction := &dto.CommentReaction{User: &dto.User{}}
+	if err := stmt.QueryRow(input.CommentID, input.UserID).Scan(
+		&reaction.ID,
+		&reaction.CommentID,
+		&reaction.User.ID,
+		&reaction.IsLike,
+		&reaction.Created,
+	); errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCommentReactionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return reaction, nil
+}
+
+func (r *CommentReactionsRepositoryPostgres) Insert(tx *sql.Tx, input CreateCommentReactionInput) (int, error) {
+	return insertReactionPostgres(tx, "INSERT INTO comment_reactions (comment_id, user_id, is_like) VALUES ($1, $2, $3) RETURNING id", input.CommentID, input.UserID, input.IsLike)
+}
+
+func (r *CommentReactionsRepositoryPostgres) Delete(tx *sql.Tx, input DeleteCommentReactionInput) error {
+	return deleteReaction(tx, "DELETE FROM comment_reactions WHERE id = $1", input.ID)
+}
+
+func insertReactionPostgres(tx *sql.Tx, query string, targetId, userId, isLike int) (int, error) {
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return -1, err
+	}
+	defer stmt.Close()
+
+	var id int
+	if err := stmt.QueryRow(targetId, userId, isLike).Scan(&id); err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
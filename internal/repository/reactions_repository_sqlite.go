@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/itelman/forum/internal/dto"
+)
+
+type PostReactionsRepositorySqlite struct {
+	db *sql.DB
+}
+
+func NewPostReactionsRepositorySqlite(db *sql.DB) *PostReactionsRepositorySqlite {
+	return &PostReactionsRepositorySqlite{db}
+}
+
+func (r *PostReactionsRepositorySqlite) Get(input GetPostReactionInput) (*dto.PostReaction, error) {
+	query := "SELECT id, post_id, user_id, is_like, created FROM post_reactions WHERE post_id = ? AND user_id = ?"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	reaction := &dto.PostReaction{User: &dto.User{}}
+	if err := stmt.QueryRow(input.PostID, input.UserID).Scan(
+		&reaction.ID,
+		&reaction.PostID,
+		&reaction.User.ID,
+		&reaction.IsLike,
+		&reaction.Created,
+	); errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPostReactionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return reaction, nil
+}
+
+func (r *PostReactionsRepositorySqlite) Insert(tx *sql.Tx, input CreatePostReactionInput) (int, error) {
+	return insertReaction(tx, "INSERT INTO post_reactions (post_id, user_id, is_like) VALUES(?, ?, ?)", input.PostID, input.UserID, input.IsLike)
+}
+
+func (r *PostReactionsRepositorySqlite) Delete(tx *sql.Tx, input DeletePostReactionInput) error {
+	return deleteReaction(tx, "DELETE FROM post_reactions WHERE id = ?", input.ID)
+}
+
+type CommentReactionsRepositorySqlite struct {
+	db *sql.DB
+}
+
+func NewCommentReactionsRepositorySqlite(db *sql.DB) *CommentReactionsRepositorySqlite {
+	return &CommentReactionsRepositorySqlite{db}
+}
+
+func (r *CommentReactionsRepositorySqlite) Get(input GetCommentReactionInput) (*dto.CommentReaction, error) {
+	query := "SELECT id, comment_id, user_id, is_like, created FROM comment_reactions WHERE comment_id = ? AND user_id = ?"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	reaction := &dto.CommentReaction{User: &dto.User{}}
+	if err := stmt.QueryRow(input.CommentID, input.UserID).Scan(
+		&reaction.ID,
+		&reaction.CommentID,
+		&reaction.User.ID,
+		&reaction.IsLike,
+		&reaction.Created,
+	); errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCommentReactionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return reaction, nil
+}
+
+func (r *CommentReactionsRepositorySqlite) Insert(tx *sql.Tx, input CreateCommentReactionInput) (int, error) {
+	return insertReaction(tx, "INSERT INTO comment_reactions (comment_id, user_id, is_like) VALUES(?, ?, ?)", input.CommentID, input.UserID, input.IsLike)
+}
+
+func (r *CommentReactionsRepositorySqlite) Delete(tx *sql.Tx, input DeleteCommentReactionInput) error {
+	return deleteReaction(tx, "DELETE FROM comment_reactions WHERE id = ?", input.ID)
+}
+
+func insertReaction(tx *sql.Tx, query string, targetId, userId, isLike int) (int, error) {
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return -1, err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(targetId, userId, isLike)
+	if err != nil {
+		return -1, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return -1, err
+	}
+
+	return int(id), nil
+}
+
+func deleteReaction(tx *sql.Tx, query string, id int) error {
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package repositorytest
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	categoriesdomain "github.com/itelman/forum/internal/service/categories/domain"
+	commentsdomain "github.com/itelman/forum/internal/service/comments/domain"
+	digestsdomain "github.com/itelman/forum/internal/service/digests/domain"
+	moderationdomain "github.com/itelman/forum/internal/service/moderation/domain"
+	oauthdomain "github.com/itelman/forum/internal/service/oauth/domain"
+	postsdomain "github.com/itelman/forum/internal/service/posts/domain"
+	reportsdomain "github.com/itelman/forum/internal/service/reports/domain"
+	searchdomain "github.com/itelman/forum/internal/service/search/domain"
+	tagsdomain "github.com/itelman/forum/internal/service/tags/domain"
+	tokensdomain "github.com/itelman/forum/internal/service/tokens/domain"
+	"github.com/itelman/forum/pkg/sesm"
+)
+
+// Backend holds the repositories of one storage backend, all over the same
+// freshly migrated database.
+type Backend struct {
+	// DB begins the transactions that writes are made in.
+	DB                      *sql.DB
+	Users                   UsersRepository
+	Posts                   PostsRepository
+	Comments                CommentsRepository
+	PostReactions           PostReactionsRepository
+	CommentReactions        CommentReactionsRepository
+	PostMentions            PostMentionsRepository
+	CommentMentions         CommentMentionsRepository
+	PostCategories          PostCategoriesRepository
+	UserRoles               UserRolesRepository
+	Notifications           NotificationsRepository
+	NotificationPreferences NotificationPreferencesRepository
+
+	// The repositories below live in the service packages, and are checked
+	// against their own interfaces.
+	Categories          categoriesdomain.CategoriesRepository
+	CommentReplies      commentsdomain.CommentRepliesRepository
+	DigestSubscriptions digestsdomain.DigestSubscriptionsRepository
+	Requests            moderationdomain.RequestsRepository
+	UsersOAuth          oauthdomain.UsersOAuthRepository
+	Images              postsdomain.ImagesRepository
+	PendingPosts        postsdomain.PendingPostsRepository
+	PostTags            postsdomain.PostTagsRepository
+	Reports             reportsdomain.ReportsRepository
+	SearchPosts         searchdomain.PostsRepository
+	SearchComments      searchdomain.CommentsRepository
+	Tags                tagsdomain.TagsRepository
+	Tokens              tokensdomain.TokensRepository
+	Sessions            sesm.SessionManager
+}
+
+type UsersRepository interface {
+	Create(input repository.RegisterUserInput) error
+	Get(input repository.GetUserInput) (*dto.User, error)
+	GetAllByUsernames(input repository.GetUsersByUsernamesInput) ([]*dto.User, error)
+	Authenticate(input repository.AuthUserInput) (int, error)
+}
+
+type PostsRepository interface {
+	Create(tx *sql.Tx, input repository.CreatePostInput) (int, error)
+	Get(input repository.GetPostInput) (*dto.Post, error)
+	GetAll(input repository.GetAllPostsInput) ([]*dto.Post, error)
+	GetAllForUser(input repository.GetAllPostsForUserInput) ([]*dto.Post, error)
+	Update(tx *sql.Tx, input repository.UpdatePostInput) error
+	UpdateReactionsCount(tx *sql.Tx, input repository.UpdatePostReactionsCountInput) error
+	Delete(input repository.DeletePostInput) error
+}
+
+type CommentsRepository interface {
+	Create(tx *sql.Tx, input repository.CreateCommentInput) (int, error)
+	Get(input repository.GetCommentInput) (*dto.Comment, error)
+	GetAllForPost(input repository.GetAllCommentsForPostInput) ([]*dto.Comment, error)
+	GetAllForPostByUser(input repository.GetAllCommentsForPostByUserInput) ([]*dto.Comment, error)
+	Update(tx *sql.Tx, input repository.UpdateCommentInput) error
+	UpdateReactionsCount(tx *sql.Tx, input repository.UpdateCommentReactionsCountInput) error
+	Delete(input repository.DeleteCommentInput) error
+}
+
+type PostReactionsRepository interface {
+	Get(input repository.GetPostReactionInput) (*dto.PostReaction, error)
+	Insert(tx *sql.Tx, input repository.CreatePostReactionInput) (int, error)
+	Delete(tx *sql.Tx, input repository.DeletePostReactionInput) error
+}
+
+type CommentReactionsRepository interface {
+	Get(input repository.GetCommentReactionInput) (*dto.CommentReaction, error)
+	Insert(tx *sql.Tx, input repository.CreateCommentReactionInput) (int, error)
+	Delete(tx *sql.Tx, input repository.DeleteCommentReactionInput) error
+}
+
+type PostMentionsRepository interface {
+	Create(tx *sql.Tx, input repository.CreatePostMentionsInput) error
+	Delete(tx *sql.Tx, input repository.DeletePostMentionsInput) error
+	GetAllForPost(input repository.GetPostMentionsInput) ([]*dto.User, error)
+}
+
+type CommentMentionsRepository interface {
+	Create(tx *sql.Tx, input repository.CreateCommentMentionsInput) error
+	Delete(tx *sql.Tx, input repository.DeleteCommentMentionsInput) error
+	GetAllForComment(input repository.GetCommentMentionsInput) ([]*dto.User, error)
+}
+
+type PostCategoriesRepository interface {
+	Create(tx *sql.Tx, input repository.CreatePostCategoriesInput) error
+	GetAllForPost(input repository.GetPostCategoriesInput) ([]string, error)
+	Reassign(tx *sql.Tx, input repository.ReassignPostCategoriesInput) error
+}
+
+type UserRolesRepository interface {
+	Create(tx *sql.Tx, input repository.CreateUserRoleInput) error
+	Get(input repository.GetUserRoleInput) (string, error)
+}
+
+type NotificationsRepository interface {
+	Create(tx *sql.Tx, input repository.CreateNotificationInput) (int, error)
+	Get(input repository.GetNotificationInput) (*dto.Notification, error)
+	GetAll(input repository.GetAllNotificationsInput) ([]*dto.Notification, error)
+	GetAllUnreadSince(input repository.GetAllUnreadNotificationsSinceInput) ([]*dto.Notification, error)
+	CountUnread(input repository.CountUnreadNotificationsInput) (int, error)
+	MarkRead(input repository.MarkNotificationReadInput) error
+	MarkAllRead(input repository.MarkAllNotificationsReadInput) error
+	Delete(input repository.DeleteNotificationInput) error
+}
+
+type NotificationPreferencesRepository interface {
+	GetAll(input repository.GetAllNotificationPreferencesInput) ([]*dto.NotificationPreference, error)
+	Upsert(tx *sql.Tx, input repository.UpsertNotificationPreferenceInput) error
+}
@@ -0,0 +1,721 @@
+// Package repositorytest is a conformance suite for the repositories in
+// internal/repository and in the service packages, and for the session
+// store. Each storage backend runs it, so services behave the same whichever
+// backend they are given.
+package repositorytest
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+const (
+	alice = 1
+	bob   = 2
+
+	password = "secret1"
+)
+
+// Run runs the suite. newBackend is called once per test and must return
+// repositories over an empty, migrated database.
+func Run(t *testing.T, newBackend func(t *testing.T) *Backend) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, b *Backend)
+	}{
+		{"users", testUsers},
+		{"posts", testPosts},
+		{"comments", testComments},
+		{"post reactions", testPostReactions},
+		{"comment reactions", testCommentReactions},
+		{"mentions", testMentions},
+		{"post categories", testPostCategories},
+		{"user roles", testUserRoles},
+		{"notifications", testNotifications},
+		{"notification preferences", testNotificationPreferences},
+		{"categories", testCategories},
+		{"comment replies", testCommentReplies},
+		{"digest subscriptions", testDigestSubscriptions},
+		{"requests", testRequests},
+		{"users oauth", testUsersOAuth},
+		{"images", testImages},
+		{"tags", testTags},
+		{"pending posts", testPendingPosts},
+		{"reports", testReports},
+		{"tokens", testTokens},
+		{"search", testSearch},
+		{"sessions", testSessions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newBackend(t))
+		})
+	}
+}
+
+// seed creates alice and bob, and a post by alice with id 1.
+func seed(t *testing.T, b *Backend) {
+	t.Helper()
+
+	for _, username := range []string{"alice", "bob"} {
+		if err := b.Users.Create(repository.RegisterUserInput{Username: username, Email: username + "@example.com", Password: password}); err != nil {
+			t.Fatalf("create %s: %v", username, err)
+		}
+	}
+
+	createPost(t, b, alice, "Hello world")
+}
+
+// inTx runs fn in a transaction, which is committed if fn succeeds.
+func inTx(t *testing.T, b *Backend, fn func(tx *sql.Tx) error) error {
+	t.Helper()
+
+	tx, err := b.DB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	return nil
+}
+
+func createPost(t *testing.T, b *Backend, userId int, title string) int {
+	t.Helper()
+
+	var postId int
+	if err := inTx(t, b, func(tx *sql.Tx) (err error) {
+		postId, err = b.Posts.Create(tx, repository.CreatePostInput{UserID: userId, Title: title, Content: "Hello"})
+		return err
+	}); err != nil {
+		t.Fatalf("create %q: %v", title, err)
+	}
+
+	return postId
+}
+
+func createComment(t *testing.T, b *Backend, postId, userId int, content string) int {
+	t.Helper()
+
+	var commentId int
+	if err := inTx(t, b, func(tx *sql.Tx) (err error) {
+		commentId, err = b.Comments.Create(tx, repository.CreateCommentInput{PostID: postId, UserID: userId, Content: content})
+		return err
+	}); err != nil {
+		t.Fatalf("create comment %q: %v", content, err)
+	}
+
+	return commentId
+}
+
+func wantErr(t *testing.T, what string, err, want error) {
+	t.Helper()
+
+	if !errors.Is(err, want) {
+		t.Fatalf("%s: err = %v, want %v", what, err, want)
+	}
+}
+
+// wantFail is for constraint violations, whose errors are backend specific.
+func wantFail(t *testing.T, what string, err error) {
+	t.Helper()
+
+	if err == nil {
+		t.Fatalf("%s: succeeded, want an error", what)
+	}
+}
+
+func usernames(users []*dto.User) string {
+	names := make([]string, len(users))
+	for i, user := range users {
+		names[i] = user.Username
+	}
+
+	return fmt.Sprint(names)
+}
+
+func testUsers(t *testing.T, b *Backend) {
+	seed(t, b)
+
+	wantFail(t, "duplicate username", b.Users.Create(repository.RegisterUserInput{Username: "alice", Email: "alice2@example.com", Password: password}))
+	wantFail(t, "duplicate email", b.Users.Create(repository.RegisterUserInput{Username: "alice2", Email: "alice@example.com", Password: password}))
+
+	for _, input := range []repository.GetUserInput{
+		{Key: "id", Value: alice},
+		{Key: "username", Value: "alice"},
+		{Key: "email", Value: "alice@example.com"},
+	} {
+		user, err := b.Users.Get(input)
+		if err != nil {
+			t.Fatalf("get by %s: %v", input.Key, err)
+		}
+
+		if user.ID != alice || user.Username != "alice" || user.Email != "alice@example.com" || user.Created.IsZero() {
+			t.Fatalf("get by %s = %+v, want alice", input.Key, user)
+		}
+	}
+
+	_, err := b.Users.Get(repository.GetUserInput{Key: "username", Value: "dave"})
+	wantErr(t, "get missing", err, repository.ErrUserNotFound)
+
+	users, err := b.Users.GetAllByUsernames(repository.GetUsersByUsernamesInput{Usernames: []string{"bob", "dave", "alice"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	if got := usernames(users); got != "[alice bob]" {
+		t.Fatalf("by usernames = %s, want [alice bob]", got)
+	}
+
+	users, err = b.Users.GetAllByUsernames(repository.GetUsersByUsernamesInput{})
+	if err != nil || users == nil || len(users) != 0 {
+		t.Fatalf("by no usernames = %v, %v, want an empty list", users, err)
+	}
+
+	id, err := b.Users.Authenticate(repository.AuthUserInput{Username: "bob", Password: password})
+	if err != nil || id != bob {
+		t.Fatalf("authenticate = %d, %v, want %d", id, err, bob)
+	}
+
+	_, err = b.Users.Authenticate(repository.AuthUserInput{Username: "bob", Password: "wrong"})
+	wantErr(t, "wrong password", err, repository.ErrInvalidCredentials)
+
+	_, err = b.Users.Authenticate(repository.AuthUserInput{Username: "dave", Password: password})
+	wantErr(t, "unknown user", err, repository.ErrUserNotFound)
+}
+
+func testPosts(t *testing.T, b *Backend) {
+	seed(t, b)
+	second := createPost(t, b, bob, "Second")
+
+	post, err := b.Posts.Get(repository.GetPostInput{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if post.Title != "Hello world" || post.Content != "Hello" || post.User.ID != alice || post.User.Username != "alice" || post.Created.IsZero() {
+		t.Fatalf("post = %+v", post)
+	}
+
+	_, err = b.Posts.Get(repository.GetPostInput{ID: 99})
+	wantErr(t, "get missing", err, repository.ErrPostNotFound)
+
+	wantFail(t, "post by missing user", inTx(t, b, func(tx *sql.Tx) error {
+		_, err := b.Posts.Create(tx, repository.CreatePostInput{UserID: 99, Title: "Ghost", Content: "Boo"})
+		return err
+	}))
+
+	posts, err := b.Posts.GetAll(repository.GetAllPostsInput{SortedByNewest: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := postIDs(posts); got != fmt.Sprint([]int{second, 1}) {
+		t.Fatalf("all = %s, want newest first", got)
+	}
+
+	posts, err = b.Posts.GetAllForUser(repository.GetAllPostsForUserInput{UserID: bob, SortedByNewest: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := postIDs(posts); got != fmt.Sprint([]int{second}) {
+		t.Fatalf("bob's = %s, want [%d]", got, second)
+	}
+
+	if err := inTx(t, b, func(tx *sql.Tx) error {
+		return b.Posts.Update(tx, repository.UpdatePostInput{ID: 1, Title: "Edited", Content: "Changed"})
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	post, err = b.Posts.Get(repository.GetPostInput{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if post.Title != "Edited" || post.Content != "Changed" {
+		t.Fatalf("updated post = %+v", post)
+	}
+
+	if err := b.Posts.Delete(repository.DeletePostInput{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.Posts.Get(repository.GetPostInput{ID: 1})
+	wantErr(t, "get deleted", err, repository.ErrPostNotFound)
+}
+
+func postIDs(posts []*dto.Post) string {
+	ids := make([]int, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+
+	return fmt.Sprint(ids)
+}
+
+func testComments(t *testing.T, b *Backend) {
+	seed(t, b)
+	first := createComment(t, b, 1, alice, "First")
+	second := createComment(t, b, 1, bob, "Second")
+
+	comment, err := b.Comments.Get(repository.GetCommentInput{ID: first})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment.PostID != 1 || comment.User.ID != alice || comment.User.Username != "alice" || comment.Content != "First" || comment.Created.IsZero() {
+		t.Fatalf("comment = %+v", comment)
+	}
+
+	_, err = b.Comments.Get(repository.GetCommentInput{ID: 99})
+	wantErr(t, "get missing", err, repository.ErrCommentNotFound)
+
+	wantFail(t, "comment on missing post", inTx(t, b, func(tx *sql.Tx) error {
+		_, err := b.Comments.Create(tx, repository.CreateCommentInput{PostID: 99, UserID: alice, Content: "Lost"})
+		return err
+	}))
+
+	comments, err := b.Comments.GetAllForPost(repository.GetAllCommentsForPostInput{PostID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("comments = %d, want 2", len(comments))
+	}
+
+	comments, err = b.Comments.GetAllForPostByUser(repository.GetAllCommentsForPostByUserInput{PostID: 1, AuthUserID: bob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 1 || comments[0].ID != second {
+		t.Fatalf("bob's comments = %+v, want [%d]", comments, second)
+	}
+
+	if err := inTx(t, b, func(tx *sql.Tx) error {
+		return b.Comments.Update(tx, repository.UpdateCommentInput{ID: first, Content: "Edited"})
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	comment, err = b.Comments.Get(repository.GetCommentInput{ID: first})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment.Content != "Edited" {
+		t.Fatalf("content = %q, want %q", comment.Content, "Edited")
+	}
+
+	if err := b.Comments.Delete(repository.DeleteCommentInput{ID: first}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.Comments.Get(repository.GetCommentInput{ID: first})
+	wantErr(t, "get deleted", err, repository.ErrCommentNotFound)
+
+	// Deleting a post deletes its comments.
+	if err := b.Posts.Delete(repository.DeletePostInput{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.Comments.Get(repository.GetCommentInput{ID: second})
+	wantErr(t, "get comment of deleted post", err, repository.ErrCommentNotFound)
+}
+
+func testPostReactions(t *testing.T, b *Backend) {
+	seed(t, b)
+
+	var likeId int
+	for _, input := range []repository.CreatePostReactionInput{
+		{PostID: 1, UserID: alice, IsLike: 1},
+		{PostID: 1, UserID: bob, IsLike: 0},
+	} {
+		if err := inTx(t, b, func(tx *sql.Tx) error {
+			id, err := b.PostReactions.Insert(tx, input)
+			if err != nil {
+				return err
+			}
+
+			if input.IsLike == 1 {
+				likeId = id
+			}
+
+			return b.Posts.UpdateReactionsCount(tx, repository.UpdatePostReactionsCountInput{PostID: 1})
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wantFail(t, "second reaction", inTx(t, b, func(tx *sql.Tx) error {
+		_, err := b.PostReactions.Insert(tx, repository.CreatePostReactionInput{PostID: 1, UserID: alice, IsLike: 0})
+		return err
+	}))
+
+	reaction, err := b.PostReactions.Get(repository.GetPostReactionInput{PostID: 1, UserID: alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reaction.ID != likeId || reaction.PostID != 1 || reaction.User.ID != alice || reaction.IsLike != 1 {
+		t.Fatalf("reaction = %+v", reaction)
+	}
+
+	post, err := b.Posts.Get(repository.GetPostInput{ID: 1, AuthUserID: alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if post.Likes != 1 || post.Dislikes != 1 || post.AuthUserReaction != 1 {
+		t.Fatalf("likes %d, dislikes %d, own reaction %d, want 1, 1, 1", post.Likes, post.Dislikes, post.AuthUserReaction)
+	}
+
+	if err := inTx(t, b, func(tx *sql.Tx) error {
+		if err := b.PostReactions.Delete(tx, repository.DeletePostReactionInput{ID: likeId}); err != nil {
+			return err
+		}
+
+		return b.Posts.UpdateReactionsCount(tx, repository.UpdatePostReactionsCountInput{PostID: 1})
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = b.PostReactions.Get(repository.GetPostReactionInput{PostID: 1, UserID: alice})
+	wantErr(t, "get deleted", err, repository.ErrPostReactionNotFound)
+
+	post, err = b.Posts.Get(repository.GetPostInput{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if post.Likes != 0 || post.Dislikes != 1 {
+		t.Fatalf("likes %d, dislikes %d, want 0, 1", post.Likes, post.Dislikes)
+	}
+}
+
+func testCommentReactions(t *testing.T, b *Backend) {
+	seed(t, b)
+	commentId := createComment(t, b, 1, alice, "First")
+
+	var likeId int
+	if err := inTx(t, b, func(tx *sql.Tx) (err error) {
+		if likeId, err = b.CommentReactions.Insert(tx, repository.CreateCommentReactionInput{CommentID: commentId, UserID: bob, IsLike: 1}); err != nil {
+			return err
+		}
+
+		return b.Comments.UpdateReactionsCount(tx, repository.UpdateCommentReactionsCountInput{CommentID: commentId})
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	wantFail(t, "second reaction", inTx(t, b, func(tx *sql.Tx) error {
+		_, err := b.CommentReactions.Insert(tx, repository.CreateCommentReactionInput{CommentID: commentId, UserID: bob, IsLike: 0})
+		return err
+	}))
+
+	reaction, err := b.CommentReactions.Get(repository.GetCommentReactionInput{CommentID: commentId, UserID: bob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reaction.ID != likeId || reaction.CommentID != commentId || reaction.User.ID != bob || reaction.IsLike != 1 {
+		t.Fatalf("reaction = %+v", reaction)
+	}
+
+	comment, err := b.Comments.Get(repository.GetCommentInput{ID: commentId, AuthUserID: bob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment.Likes != 1 || comment.Dislikes != 0 || comment.AuthUserReaction != 1 {
+		t.Fatalf("likes %d, dislikes %d, own reaction %d, want 1, 0, 1", comment.Likes, comment.Dislikes, comment.AuthUserReaction)
+	}
+
+	if err := inTx(t, b, func(tx *sql.Tx) error {
+		return b.CommentReactions.Delete(tx, repository.DeleteCommentReactionInput{ID: likeId})
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = b.CommentReactions.Get(repository.GetCommentReactionInput{CommentID: commentId, UserID: bob})
+	wantErr(t, "get deleted", err, repository.ErrCommentReactionNotFound)
+}
+
+func testMentions(t *testing.T, b *Backend) {
+	seed(t, b)
+	commentId := createComment(t, b, 1, bob, "Hi @alice")
+
+	// Mentioning a user twice is not an error.
+	for _, userIds := range [][]int{{bob, alice}, {bob}} {
+		if err := inTx(t, b, func(tx *sql.Tx) error {
+			if err := b.PostMentions.Create(tx, repository.CreatePostMentionsInput{PostID: 1, UserIDs: userIds}); err != nil {
+				return err
+			}
+
+			return b.CommentMentions.Create(tx, repository.CreateCommentMentionsInput{CommentID: commentId, UserIDs: userIds})
+		}); err != nil {
+			t.Fatalf("mention %v: %v", userIds, err)
+		}
+	}
+
+	postMentions, err := b.PostMentions.GetAllForPost(repository.GetPostMentionsInput{PostID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commentMentions, err := b.CommentMentions.GetAllForComment(repository.GetCommentMentionsInput{CommentID: commentId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := usernames(postMentions) + usernames(commentMentions); got != "[alice bob][alice bob]" {
+		t.Fatalf("mentions = %s, want [alice bob] for both, by username", got)
+	}
+
+	if err := inTx(t, b, func(tx *sql.Tx) error {
+		if err := b.PostMentions.Delete(tx, repository.DeletePostMentionsInput{PostID: 1, UserIDs: []int{alice}}); err != nil {
+			return err
+		}
+
+		return b.CommentMentions.Delete(tx, repository.DeleteCommentMentionsInput{CommentID: commentId, UserIDs: []int{alice, bob}})
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	postMentions, err = b.PostMentions.GetAllForPost(repository.GetPostMentionsInput{PostID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commentMentions, err = b.CommentMentions.GetAllForComment(repository.GetCommentMentionsInput{CommentID: commentId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := usernames(postMentions) + usernames(commentMentions); got != "[bob][]" {
+		t.Fatalf("mentions = %s, want [bob] and []", got)
+	}
+}
+
+func testPostCategories(t *testing.T, b *Backend) {
+	seed(t, b)
+	second := createPost(t, b, bob, "Second")
+	third := createPost(t, b, bob, "Third")
+
+	// Categories 1-5 are created by the migrations: Music, Books, Hobbies,
+	// Games and Programming.
+	for postId, categoryIds := range map[int][]int{1: {1, 3}, second: {1}} {
+		if err := inTx(t, b, func(tx *sql.Tx) error {
+			return b.PostCategories.Create(tx, repository.CreatePostCategoriesInput{PostID: postId, CategoriesID: categoryIds})
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := inTx(t, b, func(tx *sql.Tx) error {
+		return b.PostCategories.Create(tx, repository.CreatePostCategoriesInput{PostID: third, CategoriesID: []int{99}})
+	})
+	wantErr(t, "unknown category", err, repository.ErrUnknownCategory)
+
+	if err := inTx(t, b, func(tx *sql.Tx) error {
+		return b.PostCategories.Reassign(tx, repository.ReassignPostCategoriesInput{FromCategoryID: 1, ToCategoryID: 3})
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for postId, want := range map[int]string{1: "[Hobbies Music]", second: "[Hobbies Music]", third: "[]"} {
+		categories, err := b.PostCategories.GetAllForPost(repository.GetPostCategoriesInput{PostID: postId})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sort.Strings(categories)
+		if got := fmt.Sprint(categories); got != want {
+			t.Fatalf("post %d categories = %s, want %s", postId, got, want)
+		}
+	}
+}
+
+func testUserRoles(t *testing.T, b *Backend) {
+	seed(t, b)
+
+	_, err := b.UserRoles.Get(repository.GetUserRoleInput{UserID: alice})
+	wantErr(t, "get missing", err, repository.ErrUserRoleNotFound)
+
+	create := func(userId int, role string) error {
+		return inTx(t, b, func(tx *sql.Tx) error {
+			return b.UserRoles.Create(tx, repository.CreateUserRoleInput{UserID: userId, Role: role})
+		})
+	}
+
+	if err := create(alice, dto.RoleModerator); err != nil {
+		t.Fatal(err)
+	}
+
+	role, err := b.UserRoles.Get(repository.GetUserRoleInput{UserID: alice})
+	if err != nil || role != dto.RoleModerator {
+		t.Fatalf("role = %q, %v, want %q", role, err, dto.RoleModerator)
+	}
+
+	wantErr(t, "unknown role", create(bob, "owner"), repository.ErrRoleNotFound)
+	wantFail(t, "second role", create(alice, dto.RoleAdmin))
+	wantFail(t, "missing user", create(99, dto.RoleAdmin))
+}
+
+func testNotifications(t *testing.T, b *Backend) {
+	seed(t, b)
+
+	var ids []int
+	for _, input := range []repository.CreateNotificationInput{
+		{UserID: alice, ActorID: bob, Type: dto.NotificationComment, PostID: 1},
+		{UserID: alice, ActorID: bob, Type: dto.NotificationMention, PostID: 1},
+		{UserID: bob, ActorID: alice, Type: dto.NotificationMention, PostID: 1},
+	} {
+		if err := inTx(t, b, func(tx *sql.Tx) error {
+			id, err := b.Notifications.Create(tx, input)
+			ids = append(ids, id)
+			return err
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	notification, err := b.Notifications.Get(repository.GetNotificationInput{ID: ids[0], UserID: alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notification.Type != dto.NotificationComment || notification.Actor.ID != bob || notification.Actor.Username != "bob" || notification.PostID != 1 || notification.Read || notification.Created.IsZero() {
+		t.Fatalf("notification = %+v", notification)
+	}
+
+	_, err = b.Notifications.Get(repository.GetNotificationInput{ID: ids[0], UserID: bob})
+	wantErr(t, "get someone else's", err, repository.ErrNotificationNotFound)
+
+	all := func(input repository.GetAllNotificationsInput) string {
+		t.Helper()
+
+		notifications, err := b.Notifications.GetAll(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := make([]int, len(notifications))
+		for i, notification := range notifications {
+			got[i] = notification.ID
+		}
+
+		return fmt.Sprint(got)
+	}
+
+	for _, tt := range []struct {
+		input repository.GetAllNotificationsInput
+		want  []int
+	}{
+		{repository.GetAllNotificationsInput{UserID: alice, SortedByNewest: true}, []int{ids[1], ids[0]}},
+		{repository.GetAllNotificationsInput{UserID: alice, SortedByNewest: true, Limit: 1}, []int{ids[1]}},
+		{repository.GetAllNotificationsInput{UserID: alice, Types: []string{dto.NotificationComment}}, []int{ids[0]}},
+		{repository.GetAllNotificationsInput{UserID: alice, ExcludeTypes: []string{dto.NotificationComment}}, []int{ids[1]}},
+		{repository.GetAllNotificationsInput{UserID: bob}, []int{ids[2]}},
+	} {
+		if got := all(tt.input); got != fmt.Sprint(tt.want) {
+			t.Fatalf("all %+v = %s, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	count := func(excludeTypes ...string) int {
+		t.Helper()
+
+		n, err := b.Notifications.CountUnread(repository.CountUnreadNotificationsInput{UserID: alice, ExcludeTypes: excludeTypes})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return n
+	}
+
+	if n, muted := count(), count(dto.NotificationMention); n != 2 || muted != 1 {
+		t.Fatalf("unread = %d, %d without mentions, want 2, 1", n, muted)
+	}
+
+	wantErr(t, "mark someone else's read", b.Notifications.MarkRead(repository.MarkNotificationReadInput{ID: ids[0], UserID: bob}), repository.ErrNotificationNotFound)
+	if err := b.Notifications.MarkRead(repository.MarkNotificationReadInput{ID: ids[0], UserID: alice}); err != nil {
+		t.Fatal(err)
+	}
+	if n := count(); n != 1 {
+		t.Fatalf("unread = %d, want 1", n)
+	}
+
+	now := time.Now()
+	unread, err := b.Notifications.GetAllUnreadSince(repository.GetAllUnreadNotificationsSinceInput{UserID: alice, Since: now.Add(-time.Hour), Until: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unread) != 1 || unread[0].ID != ids[1] {
+		t.Fatalf("unread since = %+v, want [%d]", unread, ids[1])
+	}
+
+	unread, err = b.Notifications.GetAllUnreadSince(repository.GetAllUnreadNotificationsSinceInput{UserID: alice, Since: now.Add(time.Hour), Until: now.Add(2 * time.Hour)})
+	if err != nil || len(unread) != 0 {
+		t.Fatalf("unread since later = %v, %v, want none", unread, err)
+	}
+
+	if err := b.Notifications.MarkAllRead(repository.MarkAllNotificationsReadInput{UserID: alice}); err != nil {
+		t.Fatal(err)
+	}
+	if n := count(); n != 0 {
+		t.Fatalf("unread = %d, want 0", n)
+	}
+
+	if err := b.Notifications.Delete(repository.DeleteNotificationInput{ID: ids[0], UserID: alice}); err != nil {
+		t.Fatal(err)
+	}
+	wantErr(t, "delete again", b.Notifications.Delete(repository.DeleteNotificationInput{ID: ids[0], UserID: alice}), repository.ErrNotificationNotFound)
+	if got := all(repository.GetAllNotificationsInput{UserID: alice}); got != fmt.Sprint([]int{ids[1]}) {
+		t.Fatalf("all = %s, want [%d]", got, ids[1])
+	}
+}
+
+func testNotificationPreferences(t *testing.T, b *Backend) {
+	seed(t, b)
+
+	upsert := func(userId int, preference dto.NotificationPreference) {
+		t.Helper()
+
+		if err := inTx(t, b, func(tx *sql.Tx) error {
+			return b.NotificationPreferences.Upsert(tx, repository.UpsertNotificationPreferenceInput{UserID: userId, Preference: &preference})
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	get := func(userId int) string {
+		t.Helper()
+
+		preferences, err := b.NotificationPreferences.GetAll(repository.GetAllNotificationPreferencesInput{UserID: userId})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sort.Slice(preferences, func(i, j int) bool { return preferences[i].Event < preferences[j].Event })
+		got := make([]dto.NotificationPreference, len(preferences))
+		for i, preference := range preferences {
+			got[i] = *preference
+		}
+
+		return fmt.Sprint(got)
+	}
+
+	if got := get(alice); got != "[]" {
+		t.Fatalf("preferences = %s, want none", got)
+	}
+
+	upsert(alice, dto.NotificationPreference{Event: dto.PreferenceComments, InApp: true})
+	upsert(alice, dto.NotificationPreference{Event: dto.PreferenceMentions, InApp: true, Live: true})
+	upsert(alice, dto.NotificationPreference{Event: dto.PreferenceComments, Email: true})
+	upsert(bob, dto.NotificationPreference{Event: dto.PreferenceReplies})
+
+	if got, want := get(alice), "[{comments false false true} {mentions true true false}]"; got != want {
+		t.Fatalf("alice's preferences = %s, want %s", got, want)
+	}
+	if got, want := get(bob), "[{replies false false false}]"; got != want {
+		t.Fatalf("bob's preferences = %s, want %s", got, want)
+	}
+}
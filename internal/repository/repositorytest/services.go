@@ -0,0 +1,636 @@
+package repositorytest
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	categoriesdomain "github.com/itelman/forum/internal/service/categories/domain"
+	commentsdomain "github.com/itelman/forum/internal/service/comments/domain"
+	digestsdomain "github.com/itelman/forum/internal/service/digests/domain"
+	moderationdomain "github.com/itelman/forum/internal/service/moderation/domain"
+	oauthdomain "github.com/itelman/forum/internal/service/oauth/domain"
+	postsdomain "github.com/itelman/forum/internal/service/posts/domain"
+	reportsdomain "github.com/itelman/forum/internal/service/reports/domain"
+	searchdomain "github.com/itelman/forum/internal/service/search/domain"
+	tagsdomain "github.com/itelman/forum/internal/service/tags/domain"
+	tokensdomain "github.com/itelman/forum/internal/service/tokens/domain"
+	"github.com/itelman/forum/pkg/sesm"
+)
+
+func testCategories(t *testing.T, b *Backend) {
+	seed(t, b)
+	if err := inTx(t, b, func(tx *sql.Tx) error {
+		return b.PostCategories.Create(tx, repository.CreatePostCategoriesInput{PostID: 1, CategoriesID: []int{1}})
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.Categories.Create(categoriesdomain.CreateCategoryInput{Name: "Travel"}); err != nil {
+		t.Fatal(err)
+	}
+	wantErr(t, "duplicate", b.Categories.Create(categoriesdomain.CreateCategoryInput{Name: "Travel"}), categoriesdomain.ErrCategoryExists)
+
+	categories, err := b.Categories.GetAll(categoriesdomain.GetAllCategoriesInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byName := make(map[string]*dto.Category)
+	for _, category := range categories {
+		byName[category.Name] = category
+	}
+	if len(categories) != 6 || byName["Travel"] == nil || byName["Music"] == nil {
+		t.Fatalf("categories = %d, want the 5 created by the migrations and Travel", len(categories))
+	}
+	if byName["Music"].PostsCount != 1 || byName["Travel"].PostsCount != 0 {
+		t.Fatalf("posts count = %d, %d, want 1, 0", byName["Music"].PostsCount, byName["Travel"].PostsCount)
+	}
+
+	travel := byName["Travel"].ID
+	category, err := b.Categories.Get(categoriesdomain.GetCategoryInput{ID: travel})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if category.Name != "Travel" || category.Created.IsZero() {
+		t.Fatalf("category = %+v", category)
+	}
+
+	_, err = b.Categories.Get(categoriesdomain.GetCategoryInput{ID: 99})
+	wantErr(t, "get missing", err, categoriesdomain.ErrCategoryNotFound)
+
+	if err := inTx(t, b, func(tx *sql.Tx) error {
+		return b.Categories.Delete(tx, categoriesdomain.DeleteCategoryInput{ID: travel})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.Categories.Get(categoriesdomain.GetCategoryInput{ID: travel})
+	wantErr(t, "get deleted", err, categoriesdomain.ErrCategoryNotFound)
+}
+
+func testCommentReplies(t *testing.T, b *Backend) {
+	seed(t, b)
+	parent := createComment(t, b, 1, alice, "Question")
+	reply := createComment(t, b, 1, bob, "Answer")
+
+	create := func(commentId, parentId int) error {
+		return inTx(t, b, func(tx *sql.Tx) error {
+			return b.CommentReplies.Create(tx, commentsdomain.CreateCommentReplyInput{CommentID: commentId, ParentID: parentId})
+		})
+	}
+
+	if err := create(reply, parent); err != nil {
+		t.Fatal(err)
+	}
+	wantFail(t, "second parent", create(reply, parent))
+	wantFail(t, "missing parent", create(parent, 99))
+}
+
+func testDigestSubscriptions(t *testing.T, b *Backend) {
+	seed(t, b)
+
+	_, err := b.DigestSubscriptions.Get(digestsdomain.GetDigestSubscriptionInput{UserID: alice})
+	wantErr(t, "get missing", err, digestsdomain.ErrDigestSubscriptionNotFound)
+
+	upsert := func(userId int, frequency string) error {
+		return b.DigestSubscriptions.Upsert(digestsdomain.UpsertDigestSubscriptionInput{UserID: userId, Frequency: frequency})
+	}
+
+	for userId, frequency := range map[int]string{alice: dto.DigestDaily, bob: dto.DigestWeekly} {
+		if err := upsert(userId, frequency); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wantFail(t, "unknown frequency", upsert(alice, "hourly"))
+	wantFail(t, "missing user", upsert(99, dto.DigestDaily))
+
+	subscription, err := b.DigestSubscriptions.Get(digestsdomain.GetDigestSubscriptionInput{UserID: alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subscription.User.ID != alice || subscription.User.Username != "alice" || subscription.User.Email != "alice@example.com" || subscription.Frequency != dto.DigestDaily || !subscription.LastSent.IsZero() {
+		t.Fatalf("subscription = %+v, %+v", subscription, subscription.User)
+	}
+
+	now := time.Now()
+	due := func() string {
+		t.Helper()
+
+		subscriptions, err := b.DigestSubscriptions.GetAllDue(digestsdomain.GetAllDueDigestSubscriptionsInput{
+			DailyBefore:  now.Add(-24 * time.Hour),
+			WeeklyBefore: now.Add(-7 * 24 * time.Hour),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		users := make([]*dto.User, len(subscriptions))
+		for i, subscription := range subscriptions {
+			users[i] = subscription.User
+		}
+		sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+		return usernames(users)
+	}
+
+	if got := due(); got != "[alice bob]" {
+		t.Fatalf("due = %s, want both, never sent", got)
+	}
+
+	if err := b.DigestSubscriptions.MarkSent(digestsdomain.MarkDigestSentInput{UserID: alice, Sent: now}); err != nil {
+		t.Fatal(err)
+	}
+	if got := due(); got != "[bob]" {
+		t.Fatalf("due = %s, want [bob]", got)
+	}
+
+	// Changing the frequency keeps the last digest.
+	if err := upsert(alice, dto.DigestWeekly); err != nil {
+		t.Fatal(err)
+	}
+	subscription, err = b.DigestSubscriptions.Get(digestsdomain.GetDigestSubscriptionInput{UserID: alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subscription.Frequency != dto.DigestWeekly || subscription.LastSent.Sub(now).Abs() > time.Second {
+		t.Fatalf("subscription = %+v, want weekly, sent at %v", subscription, now)
+	}
+
+	if err := b.DigestSubscriptions.Delete(digestsdomain.DeleteDigestSubscriptionInput{UserID: alice}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.DigestSubscriptions.Get(digestsdomain.GetDigestSubscriptionInput{UserID: alice})
+	wantErr(t, "get deleted", err, digestsdomain.ErrDigestSubscriptionNotFound)
+}
+
+func testRequests(t *testing.T, b *Backend) {
+	seed(t, b)
+
+	if err := b.Requests.Create(moderationdomain.CreateRequestInput{UserID: bob}); err != nil {
+		t.Fatal(err)
+	}
+	wantErr(t, "duplicate", b.Requests.Create(moderationdomain.CreateRequestInput{UserID: bob}), moderationdomain.ErrRequestExists)
+	wantFail(t, "missing user", b.Requests.Create(moderationdomain.CreateRequestInput{UserID: 99}))
+
+	requests, err := b.Requests.GetAll(moderationdomain.GetAllRequestsInput{SortedByNewest: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 || requests[0].User.ID != bob || requests[0].User.Username != "bob" || requests[0].Created.IsZero() {
+		t.Fatalf("requests = %+v, want bob's", requests)
+	}
+
+	id := requests[0].ID
+	request, err := b.Requests.Get(moderationdomain.GetRequestInput{ID: id})
+	if err != nil || request.User.ID != bob {
+		t.Fatalf("request = %+v, %v, want bob's", request, err)
+	}
+
+	_, err = b.Requests.Get(moderationdomain.GetRequestInput{ID: 99})
+	wantErr(t, "get missing", err, moderationdomain.ErrRequestNotFound)
+
+	if err := inTx(t, b, func(tx *sql.Tx) error {
+		return b.Requests.Delete(tx, moderationdomain.DeleteRequestInput{ID: id})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.Requests.Get(moderationdomain.GetRequestInput{ID: id})
+	wantErr(t, "get deleted", err, moderationdomain.ErrRequestNotFound)
+}
+
+func testUsersOAuth(t *testing.T, b *Backend) {
+	seed(t, b)
+
+	// OAuth types 1 and 2 are created by the migrations: Github and Google.
+	if err := b.UsersOAuth.Create(oauthdomain.CreateUserOAuthInput{UserID: alice, AuthTypeID: 1, AccountID: "1001"}); err != nil {
+		t.Fatal(err)
+	}
+	wantFail(t, "account of another user", b.UsersOAuth.Create(oauthdomain.CreateUserOAuthInput{UserID: bob, AuthTypeID: 1, AccountID: "1001"}))
+	wantFail(t, "missing user", b.UsersOAuth.Create(oauthdomain.CreateUserOAuthInput{UserID: 99, AuthTypeID: 2, AccountID: "1002"}))
+
+	userId, err := b.UsersOAuth.GetUserID(oauthdomain.GetUserOAuthInput{AuthTypeID: 1, AccountID: "1001"})
+	if err != nil || userId != alice {
+		t.Fatalf("user = %d, %v, want %d", userId, err, alice)
+	}
+
+	_, err = b.UsersOAuth.GetUserID(oauthdomain.GetUserOAuthInput{AuthTypeID: 2, AccountID: "1001"})
+	wantErr(t, "other type", err, oauthdomain.ErrOAuthUserNotFound)
+}
+
+func testImages(t *testing.T, b *Backend) {
+	seed(t, b)
+
+	create := func(postId int, path string) error {
+		return inTx(t, b, func(tx *sql.Tx) error {
+			return b.Images.Create(tx, postsdomain.CreateImageInput{PostID: postId, Path: path})
+		})
+	}
+
+	if err := create(1, "1.png"); err != nil {
+		t.Fatal(err)
+	}
+	wantFail(t, "second image", create(1, "2.png"))
+	wantFail(t, "missing post", create(99, "99.png"))
+
+	image, err := b.Images.Get(postsdomain.GetImageInput{PostID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if image.Path != "1.png" || image.Uploaded.IsZero() {
+		t.Fatalf("image = %+v", image)
+	}
+
+	second := createPost(t, b, bob, "Second")
+	_, err = b.Images.Get(postsdomain.GetImageInput{PostID: second})
+	wantErr(t, "get missing", err, postsdomain.ErrImageNotFound)
+}
+
+func testTags(t *testing.T, b *Backend) {
+	seed(t, b)
+	second := createPost(t, b, bob, "Second")
+
+	for postId, tags := range map[int][]string{1: {"web", "go"}, second: {"go"}} {
+		if err := inTx(t, b, func(tx *sql.Tx) error {
+			return b.PostTags.Create(tx, postsdomain.CreatePostTagsInput{PostID: postId, Tags: tags})
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tags, err := b.PostTags.GetAllForPost(postsdomain.GetPostTagsInput{PostID: 1})
+	if err != nil || fmt.Sprint(tags) != "[go web]" {
+		t.Fatalf("post tags = %v, %v, want [go web]", tags, err)
+	}
+
+	tag, err := b.Tags.Get(tagsdomain.GetTagInput{Name: "go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.Name != "go" || tag.PostsCount != 2 || tag.Created.IsZero() {
+		t.Fatalf("tag = %+v", tag)
+	}
+
+	_, err = b.Tags.Get(tagsdomain.GetTagInput{Name: "rust"})
+	wantErr(t, "get missing", err, tagsdomain.ErrTagNotFound)
+
+	names := func(tags []*dto.Tag, err error) string {
+		t.Helper()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := make([]string, len(tags))
+		for i, tag := range tags {
+			got[i] = fmt.Sprintf("%s:%d", tag.Name, tag.PostsCount)
+		}
+
+		return fmt.Sprint(got)
+	}
+
+	since := time.Now().Add(-time.Hour)
+	for _, tt := range []struct {
+		what string
+		got  string
+		want string
+	}{
+		{"prefix g", names(b.Tags.GetAllByPrefix(tagsdomain.GetAllTagsByPrefixInput{Prefix: "g", Limit: 10})), "[go:2]"},
+		{"prefix w", names(b.Tags.GetAllByPrefix(tagsdomain.GetAllTagsByPrefixInput{Prefix: "w", Limit: 10})), "[web:1]"},
+		{"trending", names(b.Tags.GetAllTrending(tagsdomain.GetAllTrendingTagsInput{Since: since, Limit: 10})), "[go:2 web:1]"},
+		{"trending, limited", names(b.Tags.GetAllTrending(tagsdomain.GetAllTrendingTagsInput{Since: since, Limit: 1})), "[go:2]"},
+		{"trending later", names(b.Tags.GetAllTrending(tagsdomain.GetAllTrendingTagsInput{Since: time.Now().Add(time.Hour), Limit: 10})), "[]"},
+	} {
+		if tt.got != tt.want {
+			t.Fatalf("%s = %s, want %s", tt.what, tt.got, tt.want)
+		}
+	}
+
+	// Posts waiting for approval are not counted.
+	if err := inTx(t, b, func(tx *sql.Tx) error {
+		return b.PendingPosts.Create(tx, postsdomain.CreatePendingPostInput{PostID: second})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := names(b.Tags.GetAllTrending(tagsdomain.GetAllTrendingTagsInput{Since: since, Limit: 10})); got != "[go:1 web:1]" {
+		t.Fatalf("trending with a pending post = %s, want [go:1 web:1]", got)
+	}
+
+	if err := inTx(t, b, func(tx *sql.Tx) error {
+		return b.PostTags.DeleteAllForPost(tx, postsdomain.DeletePostTagsInput{PostID: 1})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	tags, err = b.PostTags.GetAllForPost(postsdomain.GetPostTagsInput{PostID: 1})
+	if err != nil || len(tags) != 0 {
+		t.Fatalf("post tags = %v, %v, want none", tags, err)
+	}
+}
+
+func testPendingPosts(t *testing.T, b *Backend) {
+	seed(t, b)
+
+	create := func(postId int) error {
+		return inTx(t, b, func(tx *sql.Tx) error {
+			return b.PendingPosts.Create(tx, postsdomain.CreatePendingPostInput{PostID: postId})
+		})
+	}
+
+	if err := create(1); err != nil {
+		t.Fatal(err)
+	}
+	wantFail(t, "pending twice", create(1))
+	wantFail(t, "missing post", create(99))
+
+	if err := inTx(t, b, func(tx *sql.Tx) error {
+		return b.PendingPosts.Delete(tx, postsdomain.DeletePendingPostInput{PostID: 1})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := create(1); err != nil {
+		t.Fatalf("pending again after approval: %v", err)
+	}
+}
+
+func testReports(t *testing.T, b *Backend) {
+	seed(t, b)
+
+	if err := b.Reports.Create(reportsdomain.CreateReportInput{PostID: 1, ModeratorID: bob, Content: "Spam"}); err != nil {
+		t.Fatal(err)
+	}
+	wantErr(t, "second report", b.Reports.Create(reportsdomain.CreateReportInput{PostID: 1, ModeratorID: alice, Content: "Spam"}), reportsdomain.ErrReportExists)
+	wantFail(t, "missing post", b.Reports.Create(reportsdomain.CreateReportInput{PostID: 99, ModeratorID: bob, Content: "Spam"}))
+
+	all := func(moderatorId int) []*dto.Report {
+		t.Helper()
+
+		reports, err := b.Reports.GetAll(reportsdomain.GetAllReportsInput{ModeratorID: moderatorId, SortedByNewest: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return reports
+	}
+
+	// -1 lists the reports of every moderator.
+	if reports := all(-1); len(reports) != 1 {
+		t.Fatalf("all reports = %d, want 1", len(reports))
+	}
+	if reports := all(alice); len(reports) != 0 {
+		t.Fatalf("alice's reports = %d, want 0", len(reports))
+	}
+
+	id := all(bob)[0].ID
+	report, err := b.Reports.Get(reportsdomain.GetReportInput{ID: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Post.ID != 1 || report.Post.Title != "Hello world" || report.Moderator.ID != bob || report.Moderator.Username != "bob" || report.Content != "Spam" || report.AdminReview != "" || report.Created.IsZero() || !report.Reviewed.IsZero() {
+		t.Fatalf("report = %+v", report)
+	}
+
+	_, err = b.Reports.Get(reportsdomain.GetReportInput{ID: 99})
+	wantErr(t, "get missing", err, reportsdomain.ErrReportNotFound)
+
+	if err := b.Reports.Review(reportsdomain.ReviewReportInput{ID: id, AdminReview: "Deleted"}); err != nil {
+		t.Fatal(err)
+	}
+	report, err = b.Reports.Get(reportsdomain.GetReportInput{ID: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.AdminReview != "Deleted" || report.Reviewed.IsZero() {
+		t.Fatalf("reviewed report = %+v", report)
+	}
+}
+
+func testTokens(t *testing.T, b *Backend) {
+	seed(t, b)
+
+	create := func(userId int, hash, scope string) (int, error) {
+		return b.Tokens.Create(tokensdomain.CreateTokenInput{UserID: userId, Name: "cli", Hash: hash, Scope: scope})
+	}
+
+	id, err := create(alice, "hash1", dto.ScopeRead)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = create(bob, "hash1", dto.ScopeRead)
+	wantFail(t, "duplicate hash", err)
+	_, err = create(bob, "hash2", "admin")
+	wantFail(t, "unknown scope", err)
+	_, err = create(99, "hash3", dto.ScopeRead)
+	wantFail(t, "missing user", err)
+
+	token, err := b.Tokens.GetByHash(tokensdomain.GetTokenByHashInput{Hash: "hash1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.ID != id || token.UserID != alice || token.Name != "cli" || token.Scope != dto.ScopeRead || !token.LastUsed.IsZero() || token.Created.IsZero() {
+		t.Fatalf("token = %+v", token)
+	}
+
+	_, err = b.Tokens.GetByHash(tokensdomain.GetTokenByHashInput{Hash: "hash2"})
+	wantErr(t, "get missing", err, tokensdomain.ErrTokenNotFound)
+
+	if err := b.Tokens.UpdateLastUsed(tokensdomain.UpdateTokenLastUsedInput{ID: id}); err != nil {
+		t.Fatal(err)
+	}
+
+	tokens, err := b.Tokens.GetAll(tokensdomain.GetAllTokensInput{UserID: alice, SortedByNewest: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 1 || tokens[0].ID != id || tokens[0].LastUsed.IsZero() {
+		t.Fatalf("alice's tokens = %+v, want one, used", tokens)
+	}
+
+	tokens, err = b.Tokens.GetAll(tokensdomain.GetAllTokensInput{UserID: bob})
+	if err != nil || len(tokens) != 0 {
+		t.Fatalf("bob's tokens = %v, %v, want none", tokens, err)
+	}
+
+	wantErr(t, "delete someone else's", b.Tokens.Delete(tokensdomain.DeleteTokenInput{ID: id, UserID: bob}), tokensdomain.ErrTokenNotFound)
+	if err := b.Tokens.Delete(tokensdomain.DeleteTokenInput{ID: id, UserID: alice}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.Tokens.GetByHash(tokensdomain.GetTokenByHashInput{Hash: "hash1"})
+	wantErr(t, "get deleted", err, tokensdomain.ErrTokenNotFound)
+}
+
+// testSearch only checks what every backend agrees on: which posts and
+// comments match, and that title matches rank first. Stemming and snippets
+// differ between them.
+func testSearch(t *testing.T, b *Backend) {
+	seed(t, b)
+	inTitle := createPost(t, b, bob, "Tomatoes")
+	inContent := createPost(t, b, alice, "Garden")
+	if err := inTx(t, b, func(tx *sql.Tx) error {
+		return b.Posts.Update(tx, repository.UpdatePostInput{ID: inContent, Title: "Garden", Content: "Tomatoes"})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	pending := createPost(t, b, bob, "Tomatoes again")
+	if err := inTx(t, b, func(tx *sql.Tx) error {
+		return b.PendingPosts.Create(tx, postsdomain.CreatePendingPostInput{PostID: pending})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	comment := createComment(t, b, 1, bob, "I grow tomatoes")
+
+	search := func(input searchdomain.SearchInput) ([]int, []*dto.SearchResult) {
+		t.Helper()
+
+		input.Limit = 10
+		posts, err := b.SearchPosts.Search(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids := make([]int, len(posts))
+		for i, result := range posts {
+			ids[i] = result.Post.ID
+		}
+
+		comments, err := b.SearchComments.Search(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return ids, comments
+	}
+
+	ids, comments := search(searchdomain.SearchInput{Match: `"tomatoes"`})
+	if fmt.Sprint(ids) != fmt.Sprint([]int{inTitle, inContent}) {
+		t.Fatalf("posts = %v, want [%d %d], title match first", ids, inTitle, inContent)
+	}
+	if len(comments) != 1 || comments[0].Comment.ID != comment || comments[0].Post.ID != 1 || comments[0].Comment.User.Username != "bob" {
+		t.Fatalf("comments = %+v, want [%d]", comments, comment)
+	}
+
+	posts, _ := b.SearchPosts.Search(searchdomain.SearchInput{Match: `"tomatoes"`, Limit: 10})
+	if posts[0].Post.Title != "Tomatoes" || !strings.Contains(string(posts[0].Title), "<mark>Tomatoes</mark>") || posts[0].Post.User.Username != "bob" {
+		t.Fatalf("result = %+v, want the title highlighted", posts[0])
+	}
+
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+	for _, tt := range []struct {
+		input searchdomain.SearchInput
+		want  []int
+	}{
+		{searchdomain.SearchInput{Match: `"gard"*`}, []int{inContent}},
+		{searchdomain.SearchInput{Match: `"tomatoes"`, Author: "ALICE"}, []int{inContent}},
+		{searchdomain.SearchInput{Match: `"tomatoes"`, Category: "music"}, []int{}},
+		{searchdomain.SearchInput{Match: `"tomatoes"`, After: tomorrow}, []int{}},
+		{searchdomain.SearchInput{Match: `"tomatoes"`, Before: tomorrow}, []int{inTitle, inContent}},
+		{searchdomain.SearchInput{Match: `"potatoes"`}, []int{}},
+	} {
+		if ids, _ := search(tt.input); fmt.Sprint(ids) != fmt.Sprint(tt.want) {
+			t.Fatalf("search %+v = %v, want %v", tt.input, ids, tt.want)
+		}
+	}
+}
+
+func testSessions(t *testing.T, b *Backend) {
+	seed(t, b)
+
+	request := func(sessionId, userAgent string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("User-Agent", userAgent)
+		if len(sessionId) != 0 {
+			r.AddCookie(&http.Cookie{Name: sesm.SessionId, Value: sessionId})
+		}
+
+		return r
+	}
+
+	create := func(userId int, userAgent string) *http.Request {
+		t.Helper()
+
+		sessionId, err := b.Sessions.CreateSession(request("", userAgent), userId)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return request(sessionId, userAgent)
+	}
+
+	laptop, phone, bobs := create(alice, "laptop"), create(alice, "phone"), create(bob, "desktop")
+
+	if _, err := b.Sessions.CurrentSessionID(laptop); err != nil {
+		t.Fatal(err)
+	}
+	_, err := b.Sessions.CurrentSessionID(request("", "laptop"))
+	wantErr(t, "no cookie", err, sesm.ErrSessionNotFound)
+	_, err = b.Sessions.CurrentSessionID(request("forged", "laptop"))
+	wantErr(t, "unknown session", err, sesm.ErrSessionNotFound)
+
+	userId, err := b.Sessions.GetSessionData(laptop, sesm.UserId)
+	if err != nil || userId != alice {
+		t.Fatalf("user = %v, %v, want %d", userId, err, alice)
+	}
+
+	if err := b.Sessions.AddOrUpdateSessionData(laptop, map[string]interface{}{sesm.FeedSort: "top"}); err != nil {
+		t.Fatal(err)
+	}
+	if feedSort, err := b.Sessions.GetSessionData(laptop, sesm.FeedSort); err != nil || feedSort != "top" {
+		t.Fatalf("feed sort = %v, %v, want top", feedSort, err)
+	}
+	if err := b.Sessions.DeleteSessionData(laptop, []string{sesm.FeedSort}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.Sessions.GetSessionData(laptop, sesm.FeedSort)
+	wantErr(t, "get deleted data", err, sesm.ErrDataNotFound)
+
+	if err := b.Sessions.UpdateSessionFlash(laptop, "Saved"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Saved", ""} {
+		if flash, err := b.Sessions.PopSessionFlash(laptop); err != nil || flash != want {
+			t.Fatalf("flash = %q, %v, want %q", flash, err, want)
+		}
+	}
+
+	sessions, err := b.Sessions.GetUserSessions(alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userAgents := make([]string, len(sessions))
+	for i, session := range sessions {
+		userAgents[i] = session.UserAgent
+	}
+	sort.Strings(userAgents)
+	if got := fmt.Sprint(userAgents); got != "[laptop phone]" {
+		t.Fatalf("alice's sessions = %s, want [laptop phone]", got)
+	}
+
+	phoneId, err := b.Sessions.CurrentSessionID(phone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantErr(t, "delete someone else's", b.Sessions.DeleteUserSession(bob, sesm.SessionKey(phoneId)), sesm.ErrSessionNotFound)
+	if err := b.Sessions.DeleteUserSession(alice, sesm.SessionKey(phoneId)); err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.Sessions.CurrentSessionID(phone)
+	wantErr(t, "deleted session", err, sesm.ErrSessionNotFound)
+
+	if err := b.Sessions.DeleteAllUserSessions(bob); err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.Sessions.CurrentSessionID(bobs)
+	wantErr(t, "signed out everywhere", err, sesm.ErrSessionNotFound)
+
+	if err := b.Sessions.DeleteCurrentSession(laptop); err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.Sessions.CurrentSessionID(laptop)
+	wantErr(t, "signed out", err, sesm.ErrSessionNotFound)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import "github.com/itelman/forum/pkg/pagination"
+
+// The hot score is Reddit's: the order of magnitude of the net votes plus the
+// post age, so a post needs ten times the votes to outrank one 12.5 hours newer.
+var postScores = map[string]string{
+	pagination.SortTop:      "posts.likes - posts.dislikes",
+	pagination.SortHot:      "ROUND(SIGN(posts.likes - posts.dislikes) * LOG10(MAX(ABS(posts.likes - posts.dislikes), 1)) + (UNIXEPOCH(posts.created) - 1134028003) / 45000.0, 7)",
+	pagination.SortComments: "(SELECT COUNT(*) FROM comments WHERE comments.post_id = posts.id)",
+}
+
+var periodClauses = map[string]string{
+	pagination.PeriodDay:  "posts.created >= DATETIME('now', '-1 day')",
+	pagination.PeriodWeek: "posts.created >= DATETIME('now', '-7 days')",
+}
+
+var postScoresPostgres = map[string]string{
+	pagination.SortTop:      postScores[pagination.SortTop],
+	pagination.SortHot:      "ROUND((SIGN(posts.likes - posts.dislikes) * LOG(GREATEST(ABS(posts.likes - posts.dislikes), 1)) + (EXTRACT(EPOCH FROM posts.created) - 1134028003) / 45000.0)::NUMERIC, 7)",
+	pagination.SortComments: postScores[pagination.SortComments],
+}
+
+var periodClausesPostgres = map[string]string{
+	pagination.PeriodDay:  "posts.created >= " + nowUTC + " - INTERVAL '1 day'",
+	pagination.PeriodWeek: "posts.created >= " + nowUTC + " - INTERVAL '7 days'",
+}
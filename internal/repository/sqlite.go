@@ -0,0 +1,24 @@
+package repository
+
+import "strings"
+
+// TimeLayout matches the format sqlite uses for CURRENT_TIMESTAMP, so bound
+// times compare correctly with stored ones.
+const TimeLayout = "2006-01-02 15:04:05"
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func idArgs(ids []int) []interface{} {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	return args
+}
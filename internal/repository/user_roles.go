@@ -0,0 +1,17 @@
+package repository
+
+import "errors"
+
+type CreateUserRoleInput struct {
+	UserID int
+	Role   string
+}
+
+type GetUserRoleInput struct {
+	UserID int
+}
+
+var (
+	ErrUserRoleNotFound = errors.New("DATABASE: User role not found")
+	ErrRoleNotFound     = errors.New("DATABASE: Role not found")
+)
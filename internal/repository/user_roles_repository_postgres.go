@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+)
+
+type UserRolesRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewUserRolesRepositoryPostgres(db *sql.DB) *UserRolesRepositoryPostgres {
+	return &UserRolesRepositoryPostgres{db}
+}
+
+func (r *UserRolesRepositoryPostgres) Create(tx *sql.Tx, input CreateUserRoleInput) error {
+	query := "INSERT INTO user_roles (user_id, role_id) SELECT $1::INTEGER, id FROM roles WHERE name = $2"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(input.UserID, input.Role)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return ErrRoleNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRolesRepositoryPostgres) Get(input GetUserRoleInput) (string, error) {
+	query := "SELECT roles.name FROM user_roles INNER JOIN roles ON user_roles.role_id = roles.id WHERE user_roles.user_id = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return "", err
+	}
+	defer stmt.Close()
+
+	var role string
+	if err := stmt.QueryRow(input.UserID).Scan(&role); errors.Is(err, sql.ErrNoRows) {
+		return "", ErrUserRoleNotFound
+	} else if err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
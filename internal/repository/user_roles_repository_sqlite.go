@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+)
+
+type UserRolesRepositorySqlite struct {
+	db *sql.DB
+}
+
+func NewUserRolesRepositorySqlite(db *sql.DB) *UserRolesRepositorySqlite {
+	return &UserRolesRepositorySqlite{db}
+}
+
+func (r *UserRolesRepositorySqlite) Create(tx *sql.Tx, input CreateUserRoleInput) error {
+	query := "INSERT INTO user_roles (user_id, role_id) SELECT ?, id FROM roles WHERE name = ?"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(input.UserID, input.Role)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return ErrRoleNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRolesRepositorySqlite) Get(input GetUserRoleInput) (string, error) {
+	query := "SELECT roles.name FROM user_roles INNER JOIN roles ON user_roles.role_id = roles.id WHERE user_roles.user_id = ?"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return "", err
+	}
+	defer stmt.Close()
+
+	var role string
+	if err := stmt.QueryRow(input.UserID).Scan(&role); errors.Is(err, sql.ErrNoRows) {
+		return "", ErrUserRoleNotFound
+	} else if err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
@@ -0,0 +1,30 @@
+package repository
+
+import "errors"
+
+type RegisterUserInput struct {
+	Username string
+	Email    string
+	Password string
+}
+
+// GetUserInput looks a user up by the value of a unique column, e.g. "id",
+// "username" or "email". Key is never user input.
+type GetUserInput struct {
+	Key   string
+	Value interface{}
+}
+
+type GetUsersByUsernamesInput struct {
+	Usernames []string
+}
+
+type AuthUserInput struct {
+	Username string
+	Password string
+}
+
+var (
+	ErrUserNotFound       = errors.New("DATABASE: User not found")
+	ErrInvalidCredentials = errors.New("DATABASE: Invalid credentials")
+)
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/itelman/forum/internal/dto"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UsersRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewUsersRepositoryPostgres(db *sql.DB) *UsersRepositoryPostgres {
+	return &UsersRepositoryPostgres{db}
+}
+
+func (r *UsersRepositoryPostgres) Create(input RegisterUserInput) error {
+	pwdHashed, err := bcrypt.GenerateFromPassword([]byte(input.Password), 12)
+	if err != nil {
+		return err
+	}
+
+	query := "INSERT INTO users (username, email, hashed_password) VALUES ($1, $2, $3)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.Username, input.Email, string(pwdHashed)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *UsersRepositoryPostgres) Get(input GetUserInput) (*dto.User, error) {
+	query := fmt.Sprintf("SELECT id, username, email, created FROM users WHERE %s = $1", input.Key)
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	user := &dto.User{}
+	var emailSql sql.NullString
+	if err := stmt.QueryRow(input.Value).Scan(
+		&user.ID,
+		&user.Username,
+		&emailSql,
+		&user.Created,
+	); errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUserNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if emailSql.Valid {
+		user.Email = emailSql.String
+	}
+
+	return user, nil
+}
+
+func (r *UsersRepositoryPostgres) GetAllByUsernames(input GetUsersByUsernamesInput) ([]*dto.User, error) {
+	users := []*dto.User{}
+	if len(input.Usernames) == 0 {
+		return users, nil
+	}
+
+	query := Rebind("SELECT id, username FROM users WHERE username IN (" + placeholders(len(input.Usernames)) + ")")
+	args := make([]interface{}, len(input.Usernames))
+	for i, username := range input.Usernames {
+		args[i] = username
+	}
+
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user := &dto.User{}
+		if err := rows.Scan(&user.ID, &user.Username); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *UsersRepositoryPostgres) Authenticate(input AuthUserInput) (int, error) {
+	var id int
+	var pwdSql sql.NullString
+
+	query := "SELECT id, hashed_password FROM users WHERE username = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return -1, err
+	}
+	defer stmt.Close()
+
+	if err := stmt.QueryRow(input.Username).Scan(&id, &pwdSql); errors.Is(err, sql.ErrNoRows) {
+		return -1, ErrUserNotFound
+	} else if err != nil {
+		return -1, err
+	}
+
+	if !pwdSql.Valid {
+		return -1, ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(pwdSql.String), []byte(input.Password)); errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return -1, ErrInvalidCredentials
+	} else if err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
@@ -1,11 +1,11 @@
-package adapters
+package repository
 
 import (
 	"database/sql"
 	"errors"
 	"fmt"
+
 	"github.com/itelman/forum/internal/dto"
-	"github.com/itelman/forum/internal/service/users/domain"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -17,7 +17,7 @@ func NewUsersRepositorySqlite(db *sql.DB) *UsersRepositorySqlite {
 	return &UsersRepositorySqlite{db}
 }
 
-func (r *UsersRepositorySqlite) Create(input domain.RegisterUserInput) error {
+func (r *UsersRepositorySqlite) Create(input RegisterUserInput) error {
 	pwdHashed, err := bcrypt.GenerateFromPassword([]byte(input.Password), 12)
 	if err != nil {
 		return err
@@ -38,7 +38,7 @@ func (r *UsersRepositorySqlite) Create(input domain.RegisterUserInput) error {
 	return nil
 }
 
-func (r *UsersRepositorySqlite) Get(input domain.GetUserInput) (*dto.User, error) {
+func (r *UsersRepositorySqlite) Get(input GetUserInput) (*dto.User, error) {
 	query := fmt.Sprintf("SELECT id, username, email, created FROM users WHERE %s = ?", input.Key)
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
@@ -54,7 +54,7 @@ func (r *UsersRepositorySqlite) Get(input domain.GetUserInput) (*dto.User, error
 		&emailSql,
 		&user.Created,
 	); errors.Is(err, sql.ErrNoRows) {
-		return nil, domain.ErrUserNotFound
+		return nil, ErrUserNotFound
 	} else if err != nil {
 		return nil, err
 	}
@@ -66,7 +66,46 @@ func (r *UsersRepositorySqlite) Get(input domain.GetUserInput) (*dto.User, error
 	return user, nil
 }
 
-func (r *UsersRepositorySqlite) Authenticate(input domain.AuthUserInput) (int, error) {
+func (r *UsersRepositorySqlite) GetAllByUsernames(input GetUsersByUsernamesInput) ([]*dto.User, error) {
+	users := []*dto.User{}
+	if len(input.Usernames) == 0 {
+		return users, nil
+	}
+
+	query := "SELECT id, username FROM users WHERE username IN (" + placeholders(len(input.Usernames)) + ")"
+	args := make([]interface{}, len(input.Usernames))
+	for i, username := range input.Usernames {
+		args[i] = username
+	}
+
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user := &dto.User{}
+		if err := rows.Scan(&user.ID, &user.Username); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *UsersRepositorySqlite) Authenticate(input AuthUserInput) (int, error) {
 	var id int
 	var pwd_hashed []byte
 	var pwd_db sql.NullString
@@ -79,7 +118,7 @@ func (r *UsersRepositorySqlite) Authenticate(input domain.AuthUserInput) (int, e
 	defer stmt.Close()
 
 	if err := stmt.QueryRow(input.Username).Scan(&id, &pwd_db); errors.Is(err, sql.ErrNoRows) {
-		return -1, domain.ErrUserNotFound
+		return -1, ErrUserNotFound
 	} else if err != nil {
 		return -1, err
 	}
@@ -87,11 +126,11 @@ func (r *UsersRepositorySqlite) Authenticate(input domain.AuthUserInput) (int, e
 	if pwd_db.Valid {
 		pwd_hashed = []byte(pwd_db.String)
 	} else {
-		return -1, domain.ErrInvalidCredentials
+		return -1, ErrInvalidCredentials
 	}
 
 	if err := bcrypt.CompareHashAndPassword(pwd_hashed, []byte(input.Password)); errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
-		return -1, domain.ErrInvalidCredentials
+		return -1, ErrInvalidCredentials
 	} else if err != nil {
 		return -1, err
 	}
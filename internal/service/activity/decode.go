@@ -2,17 +2,34 @@ package activity
 
 import (
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/activity/domain"
+	"github.com/itelman/forum/pkg/pagination"
 	"net/http"
 )
 
-func DecodeGetAllCreatedPosts(r *http.Request) interface{} {
-	return &GetAllCreatedPostsInput{dto.GetAuthUser(r).ID}
+func DecodeGetAllCreatedPosts(r *http.Request) (interface{}, error) {
+	cursor, err := pagination.ParseCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		return nil, domain.ErrActivityBadRequest
+	}
+
+	return &GetAllCreatedPostsInput{dto.GetAuthUser(r).ID, cursor}, nil
 }
 
-func DecodeGetAllReactedPosts(r *http.Request) interface{} {
-	return &GetAllReactedPostsInput{dto.GetAuthUser(r).ID}
+func DecodeGetAllReactedPosts(r *http.Request) (interface{}, error) {
+	cursor, err := pagination.ParseCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		return nil, domain.ErrActivityBadRequest
+	}
+
+	return &GetAllReactedPostsInput{dto.GetAuthUser(r).ID, cursor}, nil
 }
 
-func DecodeGetAllCommentedPosts(r *http.Request) interface{} {
-	return &GetAllCommentedPostsInput{dto.GetAuthUser(r).ID}
+func DecodeGetAllCommentedPosts(r *http.Request) (interface{}, error) {
+	cursor, err := pagination.ParseCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		return nil, domain.ErrActivityBadRequest
+	}
+
+	return &GetAllCommentedPostsInput{dto.GetAuthUser(r).ID, cursor}, nil
 }
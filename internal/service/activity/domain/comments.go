@@ -2,14 +2,11 @@ package domain
 
 import (
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
 )
 
 type CommentsRepository interface {
 	GetAllForPostByUser(input GetAllCommentsForPostByUserInput) ([]*dto.Comment, error)
 }
 
-type GetAllCommentsForPostByUserInput struct {
-	PostID         int
-	AuthUserID     int
-	SortedByNewest bool
-}
+type GetAllCommentsForPostByUserInput = repository.GetAllCommentsForPostByUserInput
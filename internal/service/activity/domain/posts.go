@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"errors"
+
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
 )
 
 type PostsRepository interface {
@@ -10,17 +13,12 @@ type PostsRepository interface {
 	GetAllCommented(input GetAllCommentedPostsInput) ([]*dto.Post, error)
 }
 
-type GetAllCreatedPostsInput struct {
-	AuthUserID     int
-	SortedByNewest bool
-}
-
-type GetAllReactedPostsInput struct {
-	AuthUserID     int
-	SortedByNewest bool
-}
+type (
+	GetAllCreatedPostsInput   = repository.GetAllCreatedPostsInput
+	GetAllReactedPostsInput   = repository.GetAllReactedPostsInput
+	GetAllCommentedPostsInput = repository.GetAllCommentedPostsInput
+)
 
-type GetAllCommentedPostsInput struct {
-	AuthUserID     int
-	SortedByNewest bool
-}
+var (
+	ErrActivityBadRequest = errors.New("ACTIVITY: bad request")
+)
@@ -4,8 +4,10 @@ import (
 	"database/sql"
 
 	"github.com/itelman/forum/internal/dto"
-	"github.com/itelman/forum/internal/service/activity/adapters"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
 	"github.com/itelman/forum/internal/service/activity/domain"
+	"github.com/itelman/forum/pkg/pagination"
 )
 
 type Service interface {
@@ -32,56 +34,91 @@ type Option func(*service)
 
 func WithSqlite(db *sql.DB) Option {
 	return func(s *service) {
-		s.posts = adapters.NewPostsRepositorySqlite(db)
-		s.comments = adapters.NewCommentsRepositorySqlite(db)
+		s.posts = repository.NewPostsRepositorySqlite(db)
+		s.comments = repository.NewCommentsRepositorySqlite(db)
+	}
+}
+
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.posts = repository.NewPostsRepositoryPostgres(db)
+		s.comments = repository.NewCommentsRepositoryPostgres(db)
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.posts = memory.NewPostsRepository(store)
+		s.comments = memory.NewCommentsRepository(store)
 	}
 }
 
 type GetAllCreatedPostsResponse struct {
-	Posts []*dto.Post
+	Posts      []*dto.Post
+	NextCursor string
 }
 
 func (s *service) GetAllCreatedPosts(input *GetAllCreatedPostsInput) (*GetAllCreatedPostsResponse, error) {
 	posts, err := s.posts.GetAllCreated(domain.GetAllCreatedPostsInput{
 		AuthUserID:     input.AuthUserID,
 		SortedByNewest: true,
+		Cursor:         input.Cursor,
+		Limit:          pagination.DefaultLimit + 1,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &GetAllCreatedPostsResponse{Posts: posts}, nil
+	posts, nextCursor := pagination.Paginate(posts, pagination.DefaultLimit, postCursor)
+
+	return &GetAllCreatedPostsResponse{Posts: posts, NextCursor: nextCursor}, nil
 }
 
 type GetAllReactedPostsResponse struct {
-	Posts []*dto.Post
+	Posts      []*dto.Post
+	NextCursor string
 }
 
 func (s *service) GetAllReactedPosts(input *GetAllReactedPostsInput) (*GetAllReactedPostsResponse, error) {
 	posts, err := s.posts.GetAllReacted(domain.GetAllReactedPostsInput{
 		AuthUserID:     input.AuthUserID,
 		SortedByNewest: true,
+		Cursor:         input.Cursor,
+		Limit:          pagination.DefaultLimit + 1,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &GetAllReactedPostsResponse{Posts: posts}, nil
+	posts, nextCursor := pagination.Paginate(posts, pagination.DefaultLimit, postCursor)
+
+	return &GetAllReactedPostsResponse{Posts: posts, NextCursor: nextCursor}, nil
 }
 
 type GetAllCommentedPostsResponse struct {
-	Posts []*dto.Post
+	Posts      []*dto.Post
+	NextCursor string
 }
 
 func (s *service) GetAllCommentedPosts(input *GetAllCommentedPostsInput) (*GetAllCommentedPostsResponse, error) {
 	posts, err := s.posts.GetAllCommented(domain.GetAllCommentedPostsInput{
 		AuthUserID:     input.AuthUserID,
 		SortedByNewest: true,
+		Cursor:         input.Cursor,
+		Limit:          pagination.DefaultLimit + 1,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	posts, nextCursor := pagination.Paginate(posts, pagination.DefaultLimit, postCursor)
+
 	for _, post := range posts {
 		comments, err := s.comments.GetAllForPostByUser(domain.GetAllCommentsForPostByUserInput{
 			PostID:         post.ID,
@@ -95,5 +132,9 @@ func (s *service) GetAllCommentedPosts(input *GetAllCommentedPostsInput) (*GetAl
 		post.Comments = comments
 	}
 
-	return &GetAllCommentedPostsResponse{Posts: posts}, nil
+	return &GetAllCommentedPostsResponse{Posts: posts, NextCursor: nextCursor}, nil
+}
+
+func postCursor(post *dto.Post) *pagination.Cursor {
+	return &pagination.Cursor{Created: post.Created, ID: post.ID}
 }
@@ -0,0 +1,175 @@
+package activity
+
+import (
+	"testing"
+
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	"github.com/itelman/forum/pkg/pagination"
+)
+
+const (
+	alice = 1
+	bob   = 2
+	carol = 3
+)
+
+// newTestService seeds posts 1 by alice and 2 and 3 by bob. Alice likes post
+// 2, bob dislikes post 1, and alice comments twice and bob once on post 3.
+func newTestService(t *testing.T) (*service, *memory.Store) {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice", "bob", "carol")
+
+	posts := memory.NewPostsRepository(store)
+	for _, userId := range []int{alice, bob, bob} {
+		memorytest.Post(t, store, userId, "Hello world")
+	}
+
+	reactions := memory.NewPostReactionsRepository(store)
+	for _, r := range []repository.CreatePostReactionInput{{PostID: 2, UserID: alice, IsLike: 1}, {PostID: 1, UserID: bob, IsLike: 0}} {
+		if _, err := reactions.Insert(nil, r); err != nil {
+			t.Fatal(err)
+		}
+		if err := posts.UpdateReactionsCount(nil, repository.UpdatePostReactionsCountInput{PostID: r.PostID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	comments := memory.NewCommentsRepository(store)
+	for _, userId := range []int{alice, bob, alice} {
+		if _, err := comments.Create(nil, repository.CreateCommentInput{PostID: 3, UserID: userId, Content: "Nice"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return NewService(WithMemoryStore(store)), store
+}
+
+func TestGetAllCreatedPosts(t *testing.T) {
+	tests := []struct {
+		name   string
+		userId int
+		want   string
+	}{
+		{"alice", alice, "[1]"},
+		{"bob", bob, "[3 2]"},
+		{"carol", carol, "[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+
+			resp, err := svc.GetAllCreatedPosts(&GetAllCreatedPostsInput{AuthUserID: tt.userId})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := memorytest.PostIDs(resp.Posts); got != tt.want || resp.NextCursor != "" {
+				t.Errorf("got %s and cursor %q, want %s", got, resp.NextCursor, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAllReactedPosts(t *testing.T) {
+	tests := []struct {
+		name         string
+		userId       int
+		want         string
+		wantReaction int
+	}{
+		{"alice", alice, "[2]", 1},
+		{"bob", bob, "[1]", 0},
+		{"carol", carol, "[]", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+
+			resp, err := svc.GetAllReactedPosts(&GetAllReactedPostsInput{AuthUserID: tt.userId})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := memorytest.PostIDs(resp.Posts); got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+			if len(resp.Posts) == 1 && resp.Posts[0].AuthUserReaction != tt.wantReaction {
+				t.Errorf("got reaction %d, want %d", resp.Posts[0].AuthUserReaction, tt.wantReaction)
+			}
+		})
+	}
+}
+
+func TestGetAllCommentedPosts(t *testing.T) {
+	tests := []struct {
+		name         string
+		userId       int
+		want         string
+		wantComments int
+	}{
+		{"alice", alice, "[3]", 2},
+		{"bob", bob, "[3]", 1},
+		{"carol", carol, "[]", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+
+			resp, err := svc.GetAllCommentedPosts(&GetAllCommentedPostsInput{AuthUserID: tt.userId})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := memorytest.PostIDs(resp.Posts); got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+			if len(resp.Posts) == 0 {
+				return
+			}
+
+			comments := resp.Posts[0].Comments
+			if len(comments) != tt.wantComments {
+				t.Fatalf("got %d comments, want %d", len(comments), tt.wantComments)
+			}
+			for _, comment := range comments {
+				if comment.User.ID != tt.userId {
+					t.Errorf("got a comment by %q", comment.User.Username)
+				}
+			}
+		})
+	}
+}
+
+func TestGetAllCreatedPostsCursor(t *testing.T) {
+	svc, store := newTestService(t)
+
+	for i := 0; i < pagination.DefaultLimit; i++ {
+		memorytest.Post(t, store, alice, "Hello again")
+	}
+
+	first, err := svc.GetAllCreatedPosts(&GetAllCreatedPostsInput{AuthUserID: alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cursor, err := pagination.ParseCursor(first.NextCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := svc.GetAllCreatedPosts(&GetAllCreatedPostsInput{AuthUserID: alice, Cursor: cursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(first.Posts) != pagination.DefaultLimit || memorytest.PostIDs(second.Posts) != "[1]" || second.NextCursor != "" {
+		t.Errorf("got pages of %d and %s, cursor %q", len(first.Posts), memorytest.PostIDs(second.Posts), second.NextCursor)
+	}
+}
@@ -1,13 +1,18 @@
 package activity
 
+import "github.com/itelman/forum/pkg/pagination"
+
 type GetAllCreatedPostsInput struct {
 	AuthUserID int
+	Cursor     *pagination.Cursor
 }
 
 type GetAllReactedPostsInput struct {
 	AuthUserID int
+	Cursor     *pagination.Cursor
 }
 
 type GetAllCommentedPostsInput struct {
 	AuthUserID int
+	Cursor     *pagination.Cursor
 }
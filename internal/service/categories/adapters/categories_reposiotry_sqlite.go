@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/itelman/forum/internal/dto"
 	"github.com/itelman/forum/internal/service/categories/domain"
+	"github.com/mattn/go-sqlite3"
 )
 
 type CategoriesRepositorySqlite struct {
@@ -23,7 +24,12 @@ func (r *CategoriesRepositorySqlite) Create(input domain.CreateCategoryInput) er
 	}
 	defer stmt.Close()
 
-	if _, err := stmt.Exec(input.Name); err != nil {
+	_, err = stmt.Exec(input.Name)
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && errors.Is(sqliteErr.Code, sqlite3.ErrConstraint) && errors.Is(sqliteErr.ExtendedCode, sqlite3.ErrConstraintUnique) {
+		return domain.ErrCategoryExists
+	} else if err != nil {
 		return err
 	}
 
@@ -53,7 +59,7 @@ func (r *CategoriesRepositorySqlite) Get(input domain.GetCategoryInput) (*dto.Ca
 }
 
 func (r *CategoriesRepositorySqlite) GetAll(input domain.GetAllCategoriesInput) ([]*dto.Category, error) {
-	query := "SELECT id, name, created FROM categories"
+	query := "SELECT id, name, created, (SELECT COUNT(*) FROM post_categories WHERE post_categories.category_id = categories.id) AS posts_count FROM categories"
 	if input.SortedByNewest {
 		query += " ORDER BY categories.created DESC"
 	}
@@ -77,6 +83,7 @@ func (r *CategoriesRepositorySqlite) GetAll(input domain.GetAllCategoriesInput)
 			&category.ID,
 			&category.Name,
 			&category.Created,
+			&category.PostsCount,
 		); err != nil {
 			return nil, err
 		}
@@ -91,9 +98,9 @@ func (r *CategoriesRepositorySqlite) GetAll(input domain.GetAllCategoriesInput)
 	return categories, nil
 }
 
-func (r *CategoriesRepositorySqlite) Delete(input domain.DeleteCategoryInput) error {
+func (r *CategoriesRepositorySqlite) Delete(tx *sql.Tx, input domain.DeleteCategoryInput) error {
 	query := "DELETE FROM categories WHERE id = ?"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := tx.Prepare(query)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,114 @@
+package adapters
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/categories/domain"
+	"github.com/lib/pq"
+)
+
+type CategoriesRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewCategoriesRepositoryPostgres(db *sql.DB) *CategoriesRepositoryPostgres {
+	return &CategoriesRepositoryPostgres{db}
+}
+
+func (r *CategoriesRepositoryPostgres) Create(input domain.CreateCategoryInput) error {
+	query := "INSERT INTO categories (name) VALUES($1)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(input.Name)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+		return domain.ErrCategoryExists
+	} else if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *CategoriesRepositoryPostgres) Get(input domain.GetCategoryInput) (*dto.Category, error) {
+	query := "SELECT id, name, created FROM categories WHERE id = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	category := &dto.Category{}
+	if err := stmt.QueryRow(input.ID).Scan(
+		&category.ID,
+		&category.Name,
+		&category.Created,
+	); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrCategoryNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (r *CategoriesRepositoryPostgres) GetAll(input domain.GetAllCategoriesInput) ([]*dto.Category, error) {
+	query := "SELECT id, name, created, (SELECT COUNT(*) FROM post_categories WHERE post_categories.category_id = categories.id) AS posts_count FROM categories"
+	if input.SortedByNewest {
+		query += " ORDER BY categories.created DESC"
+	}
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []*dto.Category{}
+	for rows.Next() {
+		category := &dto.Category{}
+
+		if err := rows.Scan(
+			&category.ID,
+			&category.Name,
+			&category.Created,
+			&category.PostsCount,
+		); err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, category)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *CategoriesRepositoryPostgres) Delete(tx *sql.Tx, input domain.DeleteCategoryInput) error {
+	query := "DELETE FROM categories WHERE id = $1"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
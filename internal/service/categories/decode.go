@@ -24,5 +24,10 @@ func DecodeDeleteCategory(r *http.Request) (interface{}, error) {
 		return nil, domain.ErrCategoriesBadRequest
 	}
 
-	return &DeleteCategoryInput{ID: id}, nil
+	fallbackId, err := strconv.Atoi(r.URL.Query().Get("fallback_id"))
+	if err != nil {
+		fallbackId = -1
+	}
+
+	return &DeleteCategoryInput{ID: id, FallbackID: fallbackId}, nil
 }
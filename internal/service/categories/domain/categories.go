@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"database/sql"
 	"errors"
 	"github.com/itelman/forum/internal/dto"
 )
@@ -9,7 +10,7 @@ type CategoriesRepository interface {
 	Create(input CreateCategoryInput) error
 	Get(input GetCategoryInput) (*dto.Category, error)
 	GetAll(input GetAllCategoriesInput) ([]*dto.Category, error)
-	Delete(input DeleteCategoryInput) error
+	Delete(tx *sql.Tx, input DeleteCategoryInput) error
 }
 
 type CreateCategoryInput struct {
@@ -31,4 +32,5 @@ type DeleteCategoryInput struct {
 var (
 	ErrCategoriesBadRequest = errors.New("CATEGORIES: bad request")
 	ErrCategoryNotFound     = errors.New("DATABASE: Category not found")
+	ErrCategoryExists       = errors.New("DATABASE: Category exists")
 )
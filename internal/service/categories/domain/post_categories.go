@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/repository"
+)
+
+type PostCategoriesRepository interface {
+	Reassign(tx *sql.Tx, input ReassignPostCategoriesInput) error
+}
+
+type ReassignPostCategoriesInput = repository.ReassignPostCategoriesInput
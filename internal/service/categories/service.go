@@ -2,7 +2,10 @@ package categories
 
 import (
 	"database/sql"
+	"errors"
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
 	"github.com/itelman/forum/internal/service/categories/adapters"
 	"github.com/itelman/forum/internal/service/categories/domain"
 )
@@ -14,7 +17,9 @@ type Service interface {
 }
 
 type service struct {
-	categories domain.CategoriesRepository
+	categories     domain.CategoriesRepository
+	postCategories domain.PostCategoriesRepository
+	db             *sql.DB
 }
 
 func NewService(opts ...Option) *service {
@@ -31,6 +36,30 @@ type Option func(*service)
 func WithSqlite(db *sql.DB) Option {
 	return func(s *service) {
 		s.categories = adapters.NewCategoriesRepositorySqlite(db)
+		s.postCategories = repository.NewPostCategoriesRepositorySqlite(db)
+		s.db = db
+	}
+}
+
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.categories = adapters.NewCategoriesRepositoryPostgres(db)
+		s.postCategories = repository.NewPostCategoriesRepositoryPostgres(db)
+		s.db = db
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.categories = memory.NewCategoriesRepository(store)
+		s.postCategories = memory.NewPostCategoriesRepository(store)
+		s.db = store.DB()
 	}
 }
 
@@ -39,7 +68,10 @@ func (s *service) CreateCategory(input *CreateCategoryInput) error {
 		return err
 	}
 
-	if err := s.categories.Create(domain.CreateCategoryInput{Name: input.Name}); err != nil {
+	if err := s.categories.Create(domain.CreateCategoryInput{Name: input.Name}); errors.Is(err, domain.ErrCategoryExists) {
+		input.Errors.Add("name", "A category with such name already exists")
+		return err
+	} else if err != nil {
 		return err
 	}
 
@@ -60,11 +92,43 @@ func (s *service) GetAllCategories() (*GetAllCategoriesResponse, error) {
 }
 
 func (s *service) DeleteCategory(input *DeleteCategoryInput) error {
+	if err := input.validate(); err != nil {
+		return err
+	}
+
 	if _, err := s.categories.Get(domain.GetCategoryInput{ID: input.ID}); err != nil {
 		return err
 	}
 
-	if err := s.categories.Delete(domain.DeleteCategoryInput{ID: input.ID}); err != nil {
+	if input.FallbackID != -1 {
+		if _, err := s.categories.Get(domain.GetCategoryInput{ID: input.FallbackID}); errors.Is(err, domain.ErrCategoryNotFound) {
+			return domain.ErrCategoriesBadRequest
+		} else if err != nil {
+			return err
+		}
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if input.FallbackID != -1 {
+		if err := s.postCategories.Reassign(tx, domain.ReassignPostCategoriesInput{
+			FromCategoryID: input.ID,
+			ToCategoryID:   input.FallbackID,
+		}); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := s.categories.Delete(tx, domain.DeleteCategoryInput{ID: input.ID}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
 		return err
 	}
 
@@ -0,0 +1,152 @@
+package categories
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	"github.com/itelman/forum/internal/service/categories/domain"
+	postsdomain "github.com/itelman/forum/internal/service/posts/domain"
+	"github.com/itelman/forum/pkg/validator"
+)
+
+const (
+	music       = 1
+	books       = 2
+	programming = 5
+)
+
+// newTestService seeds a post in Music and Books and one in Books only, next
+// to the five initial categories.
+func newTestService(t *testing.T) (*service, *memory.Store) {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice")
+
+	postCategories := memory.NewPostCategoriesRepository(store)
+	for _, catgsId := range [][]int{{music, books}, {books}} {
+		postId := memorytest.Post(t, store, 1, "Hello world")
+
+		if err := postCategories.Create(nil, postsdomain.CreatePostCategoriesInput{PostID: postId, CategoriesID: catgsId}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return NewService(WithMemoryStore(store)), store
+}
+
+// postsCounts returns the number of posts in each category by name.
+func postsCounts(t *testing.T, svc *service) map[string]int {
+	t.Helper()
+
+	resp, err := svc.GetAllCategories()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counts := make(map[string]int, len(resp.Categories))
+	for _, category := range resp.Categories {
+		counts[category.Name] = category.PostsCount
+	}
+
+	return counts
+}
+
+func TestCreateCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantErr  error
+		wantName string
+	}{
+		{"valid", "movies", nil, "Movies"},
+		{"mixed case", "sCIENCE fiction", nil, "Science fiction"},
+		{"multibyte first letter", "ёЛКА", nil, "Ёлка"},
+		{"exists", "MUSIC", domain.ErrCategoryExists, ""},
+		{"blank", "  ", domain.ErrCategoriesBadRequest, ""},
+		{"padded", " Movies", domain.ErrCategoriesBadRequest, ""},
+		{"short", "M", domain.ErrCategoriesBadRequest, ""},
+		{"long", "Abcdefghijklmnopqrstuvwxyzabcde", domain.ErrCategoriesBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+
+			input := &CreateCategoryInput{Name: tt.input, Errors: make(validator.Errors)}
+			if err := svc.CreateCategory(input); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if (tt.wantErr != nil) != (input.Errors.Get("name") != "") {
+				t.Errorf("got errors %v", input.Errors)
+			}
+
+			counts := postsCounts(t, svc)
+			if tt.wantErr != nil {
+				if len(counts) != 5 {
+					t.Errorf("got %d categories after a failed create, want 5", len(counts))
+				}
+				return
+			}
+
+			if count, ok := counts[tt.wantName]; !ok || count != 0 || len(counts) != 6 {
+				t.Errorf("got categories %v, want an empty %q", counts, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestGetAllCategories(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	want := map[string]int{"Music": 1, "Books": 2, "Hobbies": 0, "Games": 0, "Programming": 0}
+	got := postsCounts(t, svc)
+
+	if len(got) != len(want) {
+		t.Fatalf("got categories %v, want %v", got, want)
+	}
+	for name, count := range want {
+		if got[name] != count {
+			t.Errorf("%s: got %d posts, want %d", name, got[name], count)
+		}
+	}
+}
+
+func TestDeleteCategory(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   DeleteCategoryInput
+		wantErr error
+		want    map[string]int
+	}{
+		{"without fallback", DeleteCategoryInput{ID: books, FallbackID: -1}, nil, map[string]int{"Music": 1, "Programming": 0}},
+		{"with fallback", DeleteCategoryInput{ID: books, FallbackID: programming}, nil, map[string]int{"Music": 1, "Programming": 2}},
+		{"fallback shared by a post", DeleteCategoryInput{ID: books, FallbackID: music}, nil, map[string]int{"Music": 2, "Programming": 0}},
+		{"fallback to itself", DeleteCategoryInput{ID: books, FallbackID: books}, domain.ErrCategoriesBadRequest, map[string]int{"Books": 2}},
+		{"missing fallback", DeleteCategoryInput{ID: books, FallbackID: 9}, domain.ErrCategoriesBadRequest, map[string]int{"Books": 2}},
+		{"missing category", DeleteCategoryInput{ID: 9, FallbackID: -1}, domain.ErrCategoryNotFound, map[string]int{"Books": 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+
+			if err := svc.DeleteCategory(&tt.input); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			counts := postsCounts(t, svc)
+			if _, ok := counts["Books"]; ok == (tt.wantErr == nil) {
+				t.Errorf("got categories %v", counts)
+			}
+			for name, count := range tt.want {
+				if counts[name] != count {
+					t.Errorf("%s: got %d posts, want %d", name, counts[name], count)
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,13 @@ import (
 	"github.com/itelman/forum/internal/service/categories/domain"
 	"github.com/itelman/forum/pkg/validator"
 	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	nameMinLen = 2
+	nameMaxLen = 30
 )
 
 type CreateCategoryInput struct {
@@ -18,7 +25,8 @@ func (i *CreateCategoryInput) validate() error {
 		return domain.ErrCategoriesBadRequest
 	}
 
-	i.Name = strings.ToTitle(strings.ToLower(i.Name))
+	first, size := utf8.DecodeRuneInString(i.Name)
+	i.Name = string(unicode.ToUpper(first)) + strings.ToLower(i.Name[size:])
 
 	return nil
 }
@@ -31,9 +39,23 @@ func (i *CreateCategoryInput) validateName() {
 
 	if i.Name != strings.TrimSpace(i.Name) {
 		i.Errors.Add("name", validator.ErrInputRequired("name"))
+		return
+	}
+
+	if !(len(i.Name) >= nameMinLen && len(i.Name) <= nameMaxLen) {
+		i.Errors.Add("name", validator.ErrInputLength(nameMinLen, nameMaxLen))
 	}
 }
 
 type DeleteCategoryInput struct {
-	ID int
+	ID         int
+	FallbackID int
+}
+
+func (i *DeleteCategoryInput) validate() error {
+	if i.ID == i.FallbackID {
+		return domain.ErrCategoriesBadRequest
+	}
+
+	return nil
 }
@@ -1,59 +0,0 @@
-package adapters
-
-import (
-	"database/sql"
-	"errors"
-
-	"github.com/itelman/forum/internal/dto"
-	"github.com/itelman/forum/internal/service/comment_reactions/domain"
-)
-
-type CommentsRepositorySqlite struct {
-	db *sql.DB
-}
-
-func NewCommentsRepositorySqlite(db *sql.DB) *CommentsRepositorySqlite {
-	return &CommentsRepositorySqlite{db}
-}
-
-func (r *CommentsRepositorySqlite) Get(input domain.GetCommentInput) (*dto.Comment, error) {
-	query := "SELECT comments.id, comments.post_id, users.id, users.username, comments.content, comments.likes, comments.dislikes, comments.created FROM comments INNER JOIN users ON comments.user_id = users.id WHERE comments.id = ?"
-	stmt, err := r.db.Prepare(query)
-	if err != nil {
-		return nil, err
-	}
-	defer stmt.Close()
-
-	comment := &dto.Comment{User: &dto.User{}}
-	if err := stmt.QueryRow(input.ID).Scan(
-		&comment.ID,
-		&comment.PostID,
-		&comment.User.ID,
-		&comment.User.Username,
-		&comment.Content,
-		&comment.Likes,
-		&comment.Dislikes,
-		&comment.Created,
-	); errors.Is(err, sql.ErrNoRows) {
-		return nil, domain.ErrCommentNotFound
-	} else if err != nil {
-		return nil, err
-	}
-
-	return comment, nil
-}
-
-func (r *CommentsRepositorySqlite) UpdateReactionsCount(tx *sql.Tx, input domain.UpdateCommentReactionsCountInput) error {
-	query := "UPDATE comments SET likes = (SELECT COUNT(*) FROM comment_reactions WHERE comment_id = comments.id AND is_like = 1), dislikes = (SELECT COUNT(*) FROM comment_reactions WHERE comment_id = comments.id AND is_like = 0) WHERE id = ?"
-	stmt, err := tx.Prepare(query)
-	if err != nil {
-		return err
-	}
-	defer stmt.Close()
-
-	if _, err := stmt.Exec(input.CommentID); err != nil {
-		return err
-	}
-
-	return nil
-}
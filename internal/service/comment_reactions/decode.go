@@ -29,6 +29,8 @@ func DecodeCreateCommentReaction(r *http.Request) (interface{}, error) {
 	return &CreateCommentReactionInput{
 		CommentID: commentId,
 		UserID:    dto.GetAuthUser(r).ID,
+		UserRole:  dto.GetUserRole(r),
+		Username:  dto.GetAuthUser(r).Username,
 		IsLike:    isLike,
 	}, nil
 }
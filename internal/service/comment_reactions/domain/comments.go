@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
 )
 
 type CommentsRepository interface {
@@ -12,15 +13,12 @@ type CommentsRepository interface {
 	UpdateReactionsCount(tx *sql.Tx, input UpdateCommentReactionsCountInput) error
 }
 
-type UpdateCommentReactionsCountInput struct {
-	CommentID int
-}
-
-type GetCommentInput struct {
-	ID int
-}
+type (
+	GetCommentInput                  = repository.GetCommentInput
+	UpdateCommentReactionsCountInput = repository.UpdateCommentReactionsCountInput
+)
 
 var (
 	ErrCommentsBadRequest = errors.New("COMMENTS: bad request")
-	ErrCommentNotFound    = errors.New("DATABASE: Comment not found")
+	ErrCommentNotFound    = repository.ErrCommentNotFound
 )
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type PostsRepository interface {
+	Get(input GetPostInput) (*dto.Post, error)
+}
+
+type GetPostInput = repository.GetPostInput
+
+var ErrPostNotFound = repository.ErrPostNotFound
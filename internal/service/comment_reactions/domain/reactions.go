@@ -3,31 +3,24 @@ package domain
 import (
 	"database/sql"
 	"errors"
+
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
 )
 
 type CommentReactionsRepository interface {
 	Get(input GetCommentReactionInput) (*dto.CommentReaction, error)
-	Insert(tx *sql.Tx, input CreateCommentReactionInput) error
+	Insert(tx *sql.Tx, input CreateCommentReactionInput) (int, error)
 	Delete(tx *sql.Tx, input DeleteCommentReactionInput) error
 }
 
-type CreateCommentReactionInput struct {
-	CommentID int
-	UserID    int
-	IsLike    int
-}
-
-type GetCommentReactionInput struct {
-	CommentID int
-	UserID    int
-}
-
-type DeleteCommentReactionInput struct {
-	ID int
-}
+type (
+	CreateCommentReactionInput = repository.CreateCommentReactionInput
+	GetCommentReactionInput    = repository.GetCommentReactionInput
+	DeleteCommentReactionInput = repository.DeleteCommentReactionInput
+)
 
 var (
 	ErrCommentReactionsBadRequest = errors.New("COMMENT REACTIONS: bad request")
-	ErrCommentReactionNotFound    = errors.New("DATABASE: Post reaction not found")
+	ErrCommentReactionNotFound    = repository.ErrCommentReactionNotFound
 )
@@ -3,9 +3,13 @@ package comment_reactions
 import (
 	"database/sql"
 	"errors"
+	"time"
 
-	"github.com/itelman/forum/internal/service/comment_reactions/adapters"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
 	"github.com/itelman/forum/internal/service/comment_reactions/domain"
+	"github.com/itelman/forum/pkg/events"
 )
 
 type Service interface {
@@ -15,11 +19,14 @@ type Service interface {
 type service struct {
 	commentReactions domain.CommentReactionsRepository
 	comments         domain.CommentsRepository
+	posts            domain.PostsRepository
+	notifications    domain.NotificationsRepository
+	events           events.Publisher
 	db               *sql.DB
 }
 
 func NewService(opts ...Option) *service {
-	svc := &service{}
+	svc := &service{events: events.Discard}
 	for _, opt := range opts {
 		opt(svc)
 	}
@@ -31,18 +38,53 @@ type Option func(*service)
 
 func WithSqlite(db *sql.DB) Option {
 	return func(s *service) {
-		s.commentReactions = adapters.NewCommentReactionsRepositorySqlite(db)
-		s.comments = adapters.NewCommentsRepositorySqlite(db)
+		s.commentReactions = repository.NewCommentReactionsRepositorySqlite(db)
+		s.comments = repository.NewCommentsRepositorySqlite(db)
+		s.posts = repository.NewPostsRepositorySqlite(db)
+		s.notifications = repository.NewNotificationsRepositorySqlite(db)
 		s.db = db
 	}
 }
 
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.commentReactions = repository.NewCommentReactionsRepositoryPostgres(db)
+		s.comments = repository.NewCommentsRepositoryPostgres(db)
+		s.posts = repository.NewPostsRepositoryPostgres(db)
+		s.notifications = repository.NewNotificationsRepositoryPostgres(db)
+		s.db = db
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.commentReactions = memory.NewCommentReactionsRepository(store)
+		s.comments = memory.NewCommentsRepository(store)
+		s.posts = memory.NewPostsRepository(store)
+		s.notifications = memory.NewNotificationsRepository(store)
+		s.db = store.DB()
+	}
+}
+
+func WithEvents(publisher events.Publisher) Option {
+	return func(s *service) {
+		s.events = publisher
+	}
+}
+
 type CreateCommentReactionResponse struct {
 	PostID int
 }
 
 func (s *service) CreateCommentReaction(input *CreateCommentReactionInput) (*CreateCommentReactionResponse, error) {
 	makeInsertion := true
+	var notification *dto.Notification
 
 	comment, err := s.comments.Get(domain.GetCommentInput{ID: input.CommentID})
 	if errors.Is(err, domain.ErrCommentNotFound) {
@@ -51,6 +93,15 @@ func (s *service) CreateCommentReaction(input *CreateCommentReactionInput) (*Cre
 		return nil, err
 	}
 
+	post, err := s.posts.Get(domain.GetPostInput{ID: comment.PostID})
+	if err != nil {
+		return nil, err
+	}
+
+	if !post.VisibleTo(input.UserID, input.UserRole) {
+		return nil, domain.ErrPostNotFound
+	}
+
 	reaction, err := s.commentReactions.Get(domain.GetCommentReactionInput{
 		CommentID: input.CommentID,
 		UserID:    input.UserID,
@@ -78,14 +129,44 @@ func (s *service) CreateCommentReaction(input *CreateCommentReactionInput) (*Cre
 	}
 
 	if makeInsertion {
-		if err := s.commentReactions.Insert(tx, domain.CreateCommentReactionInput{
+		reactionId, err := s.commentReactions.Insert(tx, domain.CreateCommentReactionInput{
 			CommentID: input.CommentID,
 			UserID:    input.UserID,
 			IsLike:    input.IsLike,
-		}); err != nil {
+		})
+		if err != nil {
 			tx.Rollback()
 			return nil, err
 		}
+
+		if comment.User.ID != input.UserID {
+			notificationType := dto.NotificationCommentDislike
+			if input.IsLike == 1 {
+				notificationType = dto.NotificationCommentLike
+			}
+
+			notificationId, err := s.notifications.Create(tx, domain.CreateNotificationInput{
+				UserID:            comment.User.ID,
+				ActorID:           input.UserID,
+				Type:              notificationType,
+				PostID:            comment.PostID,
+				CommentID:         comment.ID,
+				CommentReactionID: reactionId,
+			})
+			if err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+
+			notification = &dto.Notification{
+				ID:        notificationId,
+				Type:      notificationType,
+				Actor:     &dto.User{ID: input.UserID, Username: input.Username},
+				PostID:    comment.PostID,
+				CommentID: comment.ID,
+				Created:   time.Now(),
+			}
+		}
 	}
 
 	if err := s.comments.UpdateReactionsCount(tx, domain.UpdateCommentReactionsCountInput{CommentID: input.CommentID}); err != nil {
@@ -97,5 +178,9 @@ func (s *service) CreateCommentReaction(input *CreateCommentReactionInput) (*Cre
 		return nil, err
 	}
 
+	if notification != nil {
+		s.events.Publish(events.UserTopic(comment.User.ID), events.Event{Name: events.Notification, Data: notification})
+	}
+
 	return &CreateCommentReactionResponse{PostID: comment.PostID}, nil
 }
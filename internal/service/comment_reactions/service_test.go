@@ -0,0 +1,147 @@
+package comment_reactions
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	"github.com/itelman/forum/internal/service/comment_reactions/domain"
+	postsdomain "github.com/itelman/forum/internal/service/posts/domain"
+)
+
+const (
+	alice = 1
+	bob   = 2
+	carol = 3
+)
+
+// newTestService seeds three users and a comment by alice on her own post.
+func newTestService(t *testing.T, opts ...Option) (*service, *memory.Store) {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice", "bob", "carol")
+
+	memorytest.Post(t, store, alice, "First post")
+	if _, err := memory.NewCommentsRepository(store).Create(nil, repository.CreateCommentInput{PostID: 1, UserID: alice, Content: "Welcome"}); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewService(append([]Option{WithMemoryStore(store)}, opts...)...), store
+}
+
+type reaction struct {
+	userId int
+	isLike int
+}
+
+func TestCreateCommentReaction(t *testing.T) {
+	tests := []struct {
+		name         string
+		reactions    []reaction
+		wantLikes    int
+		wantDislikes int
+		wantBob      int
+		wantAlice    string
+		wantEvents   int
+	}{
+		{"like", []reaction{{bob, 1}}, 1, 0, 1, dto.NotificationCommentLike, 1},
+		{"dislike", []reaction{{bob, 0}}, 0, 1, 0, dto.NotificationCommentDislike, 1},
+		{"undo dislike", []reaction{{bob, 0}, {bob, 0}}, 0, 0, -1, "", 1},
+		{"dislike to like", []reaction{{bob, 0}, {bob, 1}}, 1, 0, 1, dto.NotificationCommentLike, 2},
+		{"two users", []reaction{{bob, 1}, {carol, 1}}, 2, 0, 1, dto.NotificationCommentLike + "," + dto.NotificationCommentLike, 2},
+		{"own comment", []reaction{{alice, 0}}, 0, 1, -1, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &memorytest.Recorder{}
+			svc, store := newTestService(t, WithEvents(rec))
+
+			for _, r := range tt.reactions {
+				resp, err := svc.CreateCommentReaction(&CreateCommentReactionInput{CommentID: 1, UserID: r.userId, IsLike: r.isLike})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if resp.PostID != 1 {
+					t.Errorf("got post %d, want 1", resp.PostID)
+				}
+			}
+
+			comment, err := memory.NewCommentsRepository(store).Get(repository.GetCommentInput{ID: 1, AuthUserID: bob})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if comment.Likes != tt.wantLikes || comment.Dislikes != tt.wantDislikes {
+				t.Errorf("got %d likes and %d dislikes, want %d and %d", comment.Likes, comment.Dislikes, tt.wantLikes, tt.wantDislikes)
+			}
+			if comment.AuthUserReaction != tt.wantBob {
+				t.Errorf("got bob's reaction %d, want %d", comment.AuthUserReaction, tt.wantBob)
+			}
+
+			notifications, err := memory.NewNotificationsRepository(store).GetAll(repository.GetAllNotificationsInput{UserID: alice})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			types := []string{}
+			for _, notification := range notifications {
+				types = append(types, notification.Type)
+			}
+			if got := strings.Join(types, ","); got != tt.wantAlice {
+				t.Errorf("got notifications %q, want %q", got, tt.wantAlice)
+			}
+
+			if len(rec.Topics) != tt.wantEvents {
+				t.Errorf("got events %v, want %d", rec.Topics, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestCreateCommentReactionMissingComment(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	if _, err := svc.CreateCommentReaction(&CreateCommentReactionInput{CommentID: 2, UserID: bob, IsLike: 1}); !errors.Is(err, domain.ErrCommentReactionsBadRequest) {
+		t.Errorf("got error %v, want %v", err, domain.ErrCommentReactionsBadRequest)
+	}
+}
+
+func TestCreateCommentReactionPendingPost(t *testing.T) {
+	tests := []struct {
+		name    string
+		userId  int
+		role    string
+		wantErr error
+	}{
+		{"author", alice, "", nil},
+		{"moderator", bob, dto.RoleModerator, nil},
+		{"admin", bob, dto.RoleAdmin, nil},
+		{"user", bob, "", domain.ErrPostNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &memorytest.Recorder{}
+			svc, store := newTestService(t, WithEvents(rec))
+			if err := memory.NewPendingPostsRepository(store).Create(nil, postsdomain.CreatePendingPostInput{PostID: 1}); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := svc.CreateCommentReaction(&CreateCommentReactionInput{CommentID: 1, UserID: tt.userId, UserRole: tt.role, IsLike: 1})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil && len(rec.Topics) != 0 {
+				t.Errorf("got events %v for a rejected reaction", rec.Topics)
+			}
+		})
+	}
+}
@@ -3,5 +3,7 @@ package comment_reactions
 type CreateCommentReactionInput struct {
 	CommentID int
 	UserID    int
+	UserRole  string
+	Username  string
 	IsLike    int
 }
@@ -0,0 +1,30 @@
+package adapters
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/service/comments/domain"
+)
+
+type CommentRepliesRepositorySqlite struct {
+	db *sql.DB
+}
+
+func NewCommentRepliesRepositorySqlite(db *sql.DB) *CommentRepliesRepositorySqlite {
+	return &CommentRepliesRepositorySqlite{db}
+}
+
+func (r *CommentRepliesRepositorySqlite) Create(tx *sql.Tx, input domain.CreateCommentReplyInput) error {
+	query := "INSERT INTO comment_replies (comment_id, parent_id) VALUES (?, ?)"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.CommentID, input.ParentID); err != nil {
+		return err
+	}
+
+	return nil
+}
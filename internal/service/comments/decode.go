@@ -18,11 +18,21 @@ func DecodeCreateComment(r *http.Request) (interface{}, error) {
 		return nil, domain.ErrCommentsBadRequest
 	}
 
+	parentId := 0
+	if r.PostForm.Has("parent_id") {
+		parentId, err = strconv.Atoi(r.PostForm.Get("parent_id"))
+		if err != nil || parentId < 1 {
+			return nil, domain.ErrCommentsBadRequest
+		}
+	}
+
 	return &CreateCommentInput{
-		PostID:  postId,
-		UserID:  dto.GetAuthUser(r).ID,
-		Content: r.PostForm.Get("content"),
-		Errors:  make(validator.Errors),
+		PostID:   postId,
+		ParentID: parentId,
+		UserID:   dto.GetAuthUser(r).ID,
+		UserRole: dto.GetUserRole(r),
+		Content:  r.PostForm.Get("content"),
+		Errors:   make(validator.Errors),
 	}, nil
 }
 
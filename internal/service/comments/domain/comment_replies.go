@@ -0,0 +1,12 @@
+package domain
+
+import "database/sql"
+
+type CommentRepliesRepository interface {
+	Create(tx *sql.Tx, input CreateCommentReplyInput) error
+}
+
+type CreateCommentReplyInput struct {
+	CommentID int
+	ParentID  int
+}
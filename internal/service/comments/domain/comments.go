@@ -1,43 +1,28 @@
 package domain
 
 import (
+	"database/sql"
 	"errors"
+
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
 )
 
 type CommentsRepository interface {
-	Create(input CreateCommentInput) error
+	Create(tx *sql.Tx, input CreateCommentInput) (int, error)
 	Get(input GetCommentInput) (*dto.Comment, error)
-	Update(input UpdateCommentInput) error
+	Update(tx *sql.Tx, input UpdateCommentInput) error
 	Delete(input DeleteCommentInput) error
 }
 
-type CreateCommentInput struct {
-	PostID  int
-	UserID  int
-	Content string
-}
-
-type GetCommentInput struct {
-	ID int
-}
-
-type GetAllCommentsForPostInput struct {
-	PostID         int
-	AuthUserID     int
-	SortedByNewest bool
-}
-
-type UpdateCommentInput struct {
-	ID      int
-	Content string
-}
-
-type DeleteCommentInput struct {
-	ID int
-}
+type (
+	CreateCommentInput = repository.CreateCommentInput
+	GetCommentInput    = repository.GetCommentInput
+	UpdateCommentInput = repository.UpdateCommentInput
+	DeleteCommentInput = repository.DeleteCommentInput
+)
 
 var (
 	ErrCommentsBadRequest = errors.New("COMMENTS: bad request")
-	ErrCommentNotFound    = errors.New("DATABASE: Comment not found")
+	ErrCommentNotFound    = repository.ErrCommentNotFound
 )
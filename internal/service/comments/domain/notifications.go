@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/repository"
+)
+
+type NotificationsRepository interface {
+	Create(tx *sql.Tx, input CreateNotificationInput) (int, error)
+}
+
+type CreateNotificationInput = repository.CreateNotificationInput
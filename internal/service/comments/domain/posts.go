@@ -4,17 +4,16 @@ import (
 	"errors"
 
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
 )
 
 type PostsRepository interface {
 	Get(input GetPostInput) (*dto.Post, error)
 }
 
-type GetPostInput struct {
-	ID int
-}
+type GetPostInput = repository.GetPostInput
 
 var (
 	ErrPostsBadRequest = errors.New("POSTS: bad request")
-	ErrPostNotFound    = errors.New("DATABASE: Post not found")
+	ErrPostNotFound    = repository.ErrPostNotFound
 )
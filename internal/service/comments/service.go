@@ -5,24 +5,37 @@ import (
 	"errors"
 
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
 	"github.com/itelman/forum/internal/service/comments/adapters"
 	"github.com/itelman/forum/internal/service/comments/domain"
+	"github.com/itelman/forum/pkg/events"
+	"github.com/itelman/forum/pkg/mentions"
 )
 
 type Service interface {
-	CreateComment(input *CreateCommentInput) error
+	CreateComment(input *CreateCommentInput) (*CreateCommentResponse, error)
 	GetComment(input *GetCommentInput) (*GetCommentResponse, error)
 	UpdateComment(input *UpdateCommentInput, comment *dto.Comment) error
 	DeleteComment(input *DeleteCommentInput) error
 }
 
 type service struct {
-	comments domain.CommentsRepository
-	posts    domain.PostsRepository
+	comments       domain.CommentsRepository
+	commentReplies domain.CommentRepliesRepository
+	posts          domain.PostsRepository
+	notifications  domain.NotificationsRepository
+	mentions       domain.MentionsRepository
+	users          domain.UsersRepository
+	events         events.Publisher
+	maxDepth       int
+	db             *sql.DB
 }
 
+const defaultMaxDepth = 3
+
 func NewService(opts ...Option) *service {
-	svc := &service{}
+	svc := &service{maxDepth: defaultMaxDepth, events: events.Discard}
 	for _, opt := range opts {
 		opt(svc)
 	}
@@ -34,31 +47,223 @@ type Option func(*service)
 
 func WithSqlite(db *sql.DB) Option {
 	return func(s *service) {
-		s.comments = adapters.NewCommentsRepositorySqlite(db)
-		s.posts = adapters.NewPostsRepositorySqlite(db)
+		s.comments = repository.NewCommentsRepositorySqlite(db)
+		s.commentReplies = adapters.NewCommentRepliesRepositorySqlite(db)
+		s.posts = repository.NewPostsRepositorySqlite(db)
+		s.notifications = repository.NewNotificationsRepositorySqlite(db)
+		s.mentions = repository.NewCommentMentionsRepositorySqlite(db)
+		s.users = repository.NewUsersRepositorySqlite(db)
+		s.db = db
+	}
+}
+
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.comments = repository.NewCommentsRepositoryPostgres(db)
+		s.commentReplies = adapters.NewCommentRepliesRepositoryPostgres(db)
+		s.posts = repository.NewPostsRepositoryPostgres(db)
+		s.notifications = repository.NewNotificationsRepositoryPostgres(db)
+		s.mentions = repository.NewCommentMentionsRepositoryPostgres(db)
+		s.users = repository.NewUsersRepositoryPostgres(db)
+		s.db = db
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.comments = memory.NewCommentsRepository(store)
+		s.commentReplies = memory.NewCommentRepliesRepository(store)
+		s.posts = memory.NewPostsRepository(store)
+		s.notifications = memory.NewNotificationsRepository(store)
+		s.mentions = memory.NewCommentMentionsRepository(store)
+		s.users = memory.NewUsersRepository(store)
+		s.db = store.DB()
+	}
+}
+
+func WithMaxDepth(depth int) Option {
+	return func(s *service) {
+		s.maxDepth = depth
 	}
 }
 
-func (s *service) CreateComment(input *CreateCommentInput) error {
+func WithEvents(publisher events.Publisher) Option {
+	return func(s *service) {
+		s.events = publisher
+	}
+}
+
+type CreateCommentResponse struct {
+	CommentID int
+}
+
+func (s *service) CreateComment(input *CreateCommentInput) (*CreateCommentResponse, error) {
 	if err := input.validate(); err != nil {
-		return err
+		return nil, err
 	}
 
-	if _, err := s.posts.Get(domain.GetPostInput{ID: input.PostID}); errors.Is(err, domain.ErrPostNotFound) {
-		return domain.ErrCommentsBadRequest
+	post, err := s.posts.Get(domain.GetPostInput{ID: input.PostID})
+	if errors.Is(err, domain.ErrPostNotFound) {
+		return nil, domain.ErrCommentsBadRequest
 	} else if err != nil {
-		return err
+		return nil, err
+	}
+
+	if !post.VisibleTo(input.UserID, input.UserRole) {
+		return nil, domain.ErrPostNotFound
+	}
+
+	parent, err := s.getReplyParent(input)
+	if err != nil {
+		return nil, err
+	}
+
+	mentioned, err := s.users.GetAllByUsernames(domain.GetUsersByUsernamesInput{Usernames: mentions.Parse(input.Content)})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.comments.Create(domain.CreateCommentInput{
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	commentId, err := s.comments.Create(tx, domain.CreateCommentInput{
 		PostID:  input.PostID,
 		UserID:  input.UserID,
 		Content: input.Content,
+	})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	notification := domain.CreateNotificationInput{
+		UserID:    post.User.ID,
+		ActorID:   input.UserID,
+		Type:      dto.NotificationComment,
+		PostID:    input.PostID,
+		CommentID: commentId,
+	}
+
+	if parent != nil {
+		if err := s.commentReplies.Create(tx, domain.CreateCommentReplyInput{
+			CommentID: commentId,
+			ParentID:  parent.ID,
+		}); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		notification.UserID = parent.User.ID
+		notification.Type = dto.NotificationReply
+	}
+
+	notificationId := 0
+	if notification.UserID != notification.ActorID {
+		notificationId, err = s.notifications.Create(tx, notification)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := s.mentions.Create(tx, domain.CreateMentionsInput{
+		CommentID: commentId,
+		UserIDs:   mentions.UserIDs(mentioned),
 	}); err != nil {
-		return err
+		tx.Rollback()
+		return nil, err
 	}
 
-	return nil
+	// The recipient of the comment or reply notification is not notified
+	// a second time for being mentioned in it.
+	notified, err := mentions.Notify(tx, s.notifications, domain.CreateNotificationInput{ActorID: input.UserID, PostID: input.PostID, CommentID: commentId}, mentions.Diff([]*dto.User{{ID: notification.UserID}}, mentioned))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	s.publishComment(commentId, notificationId, notification, notified)
+
+	return &CreateCommentResponse{CommentID: commentId}, nil
+}
+
+// publishComment notifies live subscribers about a stored comment. The comment is
+// already committed at this point, so a failed lookup only costs the live update.
+func (s *service) publishComment(commentId, notificationId int, notification domain.CreateNotificationInput, notified map[int]int) {
+	comment, err := s.comments.Get(domain.GetCommentInput{ID: commentId})
+	if err != nil {
+		return
+	}
+
+	s.events.Publish(events.PostTopic(comment.PostID), events.Event{Name: events.Comment, Data: comment})
+
+	if notificationId != 0 {
+		s.events.Publish(events.UserTopic(notification.UserID), events.Event{Name: events.Notification, Data: &dto.Notification{
+			ID:        notificationId,
+			Type:      notification.Type,
+			Actor:     comment.User,
+			PostID:    notification.PostID,
+			CommentID: notification.CommentID,
+			Created:   comment.Created,
+		}})
+	}
+
+	s.publishMentions(comment, notified)
+}
+
+func (s *service) publishMentions(comment *dto.Comment, notified map[int]int) {
+	for userId, notificationId := range notified {
+		s.events.Publish(events.UserTopic(userId), events.Event{Name: events.Notification, Data: &dto.Notification{
+			ID:        notificationId,
+			Type:      dto.NotificationMention,
+			Actor:     comment.User,
+			PostID:    comment.PostID,
+			CommentID: comment.ID,
+			Created:   comment.Created,
+		}})
+	}
+}
+
+// Replies nested deeper than maxDepth are attached to the deepest allowed ancestor.
+func (s *service) getReplyParent(input *CreateCommentInput) (*dto.Comment, error) {
+	if input.ParentID == 0 || s.maxDepth <= 0 {
+		return nil, nil
+	}
+
+	thread := []*dto.Comment{}
+	for id := input.ParentID; id != 0; {
+		comment, err := s.comments.Get(domain.GetCommentInput{ID: id})
+		if errors.Is(err, domain.ErrCommentNotFound) {
+			return nil, domain.ErrCommentsBadRequest
+		} else if err != nil {
+			return nil, err
+		}
+
+		if comment.PostID != input.PostID {
+			return nil, domain.ErrCommentsBadRequest
+		}
+
+		thread = append(thread, comment)
+		id = comment.ParentID
+	}
+
+	if len(thread) <= s.maxDepth {
+		return thread[0], nil
+	}
+
+	return thread[len(thread)-s.maxDepth], nil
 }
 
 type GetCommentResponse struct {
@@ -85,13 +290,59 @@ func (s *service) UpdateComment(input *UpdateCommentInput, comment *dto.Comment)
 		return err
 	}
 
-	if err := s.comments.Update(domain.UpdateCommentInput{
+	mentioned, err := s.users.GetAllByUsernames(domain.GetUsersByUsernamesInput{Usernames: mentions.Parse(input.Content)})
+	if err != nil {
+		return err
+	}
+
+	previous, err := s.mentions.GetAllForComment(domain.GetMentionsInput{CommentID: input.ID})
+	if err != nil {
+		return err
+	}
+	added, removed := mentions.Diff(previous, mentioned), mentions.Diff(mentioned, previous)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := s.comments.Update(tx, domain.UpdateCommentInput{
 		ID:      input.ID,
 		Content: input.Content,
 	}); err != nil {
+		tx.Rollback()
 		return err
 	}
 
+	if err := s.mentions.Delete(tx, domain.DeleteMentionsInput{
+		CommentID: input.ID,
+		UserIDs:   mentions.UserIDs(removed),
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := s.mentions.Create(tx, domain.CreateMentionsInput{
+		CommentID: input.ID,
+		UserIDs:   mentions.UserIDs(added),
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Only users newly mentioned by this edit are notified.
+	notified, err := mentions.Notify(tx, s.notifications, domain.CreateNotificationInput{ActorID: comment.User.ID, PostID: comment.PostID, CommentID: input.ID}, added)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	s.publishMentions(comment, notified)
+
 	return nil
 }
 
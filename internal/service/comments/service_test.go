@@ -0,0 +1,393 @@
+package comments
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	"github.com/itelman/forum/internal/service/comments/domain"
+	postsdomain "github.com/itelman/forum/internal/service/posts/domain"
+	"github.com/itelman/forum/pkg/events"
+	"github.com/itelman/forum/pkg/validator"
+)
+
+const (
+	alice = 1
+	bob   = 2
+	carol = 3
+)
+
+// newTestService seeds three users and two posts by alice.
+func newTestService(t *testing.T, opts ...Option) (*service, *memory.Store) {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice", "bob", "carol")
+
+	for _, title := range []string{"First post", "Second post"} {
+		memorytest.Post(t, store, alice, title)
+	}
+
+	return NewService(append([]Option{WithMemoryStore(store)}, opts...)...), store
+}
+
+func createComment(t *testing.T, svc *service, postId, parentId, userId int, content string) int {
+	t.Helper()
+
+	resp, err := svc.CreateComment(&CreateCommentInput{
+		PostID:   postId,
+		ParentID: parentId,
+		UserID:   userId,
+		Content:  content,
+		Errors:   make(validator.Errors),
+	})
+	if err != nil {
+		t.Fatalf("create comment %q: %v", content, err)
+	}
+
+	return resp.CommentID
+}
+
+// notificationTypes returns the types of a user's notifications, oldest first.
+func notificationTypes(t *testing.T, store *memory.Store, userId int) string {
+	t.Helper()
+
+	notifications, err := memory.NewNotificationsRepository(store).GetAll(repository.GetAllNotificationsInput{UserID: userId})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := make([]string, len(notifications))
+	for i, notification := range notifications {
+		types[i] = notification.Type
+	}
+
+	return strings.Join(types, ",")
+}
+
+func TestCreateComment(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      CreateCommentInput
+		wantErr    error
+		wantFields []string
+		wantParent int
+		wantAlice  string
+		wantBob    string
+	}{
+		{"comment", CreateCommentInput{PostID: 1, UserID: bob, Content: "Nice"}, nil, nil, 0, dto.NotificationComment, ""},
+		{"own post", CreateCommentInput{PostID: 1, UserID: alice, Content: "Thanks"}, nil, nil, 0, "", ""},
+		{"reply", CreateCommentInput{PostID: 1, ParentID: 1, UserID: carol, Content: "Agreed"}, nil, nil, 1, "", dto.NotificationReply},
+		{"own reply", CreateCommentInput{PostID: 1, ParentID: 1, UserID: bob, Content: "Also"}, nil, nil, 1, "", ""},
+		{"mention", CreateCommentInput{PostID: 1, UserID: carol, Content: "Hi @bob"}, nil, nil, 0, dto.NotificationComment, dto.NotificationMention},
+		{"mention of the post author", CreateCommentInput{PostID: 1, UserID: bob, Content: "Hi @alice"}, nil, nil, 0, dto.NotificationComment, ""},
+		{"blank content", CreateCommentInput{PostID: 1, UserID: bob, Content: " "}, domain.ErrCommentsBadRequest, []string{"content"}, 0, "", ""},
+		{"padded content", CreateCommentInput{PostID: 1, UserID: bob, Content: "Nice "}, domain.ErrCommentsBadRequest, []string{"content"}, 0, "", ""},
+		{"missing post", CreateCommentInput{PostID: 3, UserID: bob, Content: "Nice"}, domain.ErrCommentsBadRequest, nil, 0, "", ""},
+		{"missing parent", CreateCommentInput{PostID: 1, ParentID: 9, UserID: bob, Content: "Nice"}, domain.ErrCommentsBadRequest, nil, 0, "", ""},
+		{"parent on another post", CreateCommentInput{PostID: 2, ParentID: 1, UserID: bob, Content: "Nice"}, domain.ErrCommentsBadRequest, nil, 0, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store := newTestService(t)
+			// bob's comment to reply to; its notification to alice is not counted.
+			createComment(t, svc, 1, 0, bob, "Hello")
+			seen := notificationTypes(t, store, alice)
+
+			input := tt.input
+			input.Errors = make(validator.Errors)
+
+			resp, err := svc.CreateComment(&input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if len(input.Errors) != len(tt.wantFields) {
+				t.Errorf("got errors %v, want fields %v", input.Errors, tt.wantFields)
+			}
+			for _, field := range tt.wantFields {
+				if input.Errors.Get(field) == "" {
+					t.Errorf("missing error for %q", field)
+				}
+			}
+
+			got := strings.TrimPrefix(strings.TrimPrefix(notificationTypes(t, store, alice), seen), ",")
+			if got != tt.wantAlice {
+				t.Errorf("got new notifications for alice %q, want %q", got, tt.wantAlice)
+			}
+			if got := notificationTypes(t, store, bob); got != tt.wantBob {
+				t.Errorf("got notifications for bob %q, want %q", got, tt.wantBob)
+			}
+
+			if err != nil {
+				return
+			}
+
+			comment, err := svc.GetComment(&GetCommentInput{ID: resp.CommentID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if comment.Comment.Content != tt.input.Content || comment.Comment.ParentID != tt.wantParent {
+				t.Errorf("got %q with parent %d, want %q with parent %d", comment.Comment.Content, comment.Comment.ParentID, tt.input.Content, tt.wantParent)
+			}
+		})
+	}
+}
+
+func TestCreateCommentPendingPost(t *testing.T) {
+	tests := []struct {
+		name    string
+		userId  int
+		role    string
+		wantErr error
+	}{
+		{"author", alice, "", nil},
+		{"moderator", bob, dto.RoleModerator, nil},
+		{"admin", bob, dto.RoleAdmin, nil},
+		{"user", bob, "", domain.ErrPostNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store := newTestService(t)
+			if err := memory.NewPendingPostsRepository(store).Create(nil, postsdomain.CreatePendingPostInput{PostID: 1}); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := svc.CreateComment(&CreateCommentInput{PostID: 1, UserID: tt.userId, UserRole: tt.role, Content: "Nice", Errors: make(validator.Errors)})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil && notificationTypes(t, store, alice) != "" {
+				t.Error("a rejected comment notified the post author")
+			}
+		})
+	}
+}
+
+func TestCreateCommentMaxDepth(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxDepth int
+		want     []int
+	}{
+		{"default", defaultMaxDepth, []int{0, 1, 2, 3, 3}},
+		{"one level", 1, []int{0, 1, 1, 1, 1}},
+		{"flat", 0, []int{0, 0, 0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t, WithMaxDepth(tt.maxDepth))
+
+			// Each comment replies to the one before it.
+			for i, want := range tt.want {
+				id := createComment(t, svc, 1, i, bob, "Reply")
+
+				comment, err := svc.GetComment(&GetCommentInput{ID: id})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if comment.Comment.ParentID != want {
+					t.Errorf("comment %d got parent %d, want %d", id, comment.Comment.ParentID, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateCommentEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   CreateCommentInput
+		wantErr error
+		want    []string
+	}{
+		{"comment", CreateCommentInput{PostID: 1, UserID: bob, Content: "Nice"}, nil, []string{
+			events.PostTopic(1) + ":" + events.Comment,
+			events.UserTopic(alice) + ":" + events.Notification,
+		}},
+		{"own post", CreateCommentInput{PostID: 1, UserID: alice, Content: "Thanks"}, nil, []string{
+			events.PostTopic(1) + ":" + events.Comment,
+		}},
+		{"mention", CreateCommentInput{PostID: 1, UserID: alice, Content: "Hi @bob"}, nil, []string{
+			events.PostTopic(1) + ":" + events.Comment,
+			events.UserTopic(bob) + ":" + events.Notification,
+		}},
+		{"invalid", CreateCommentInput{PostID: 1, UserID: bob}, domain.ErrCommentsBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &memorytest.Recorder{}
+			svc, _ := newTestService(t, WithEvents(rec))
+
+			input := tt.input
+			input.Errors = make(validator.Errors)
+
+			if _, err := svc.CreateComment(&input); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if strings.Join(rec.Topics, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("got events %v, want %v", rec.Topics, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetComment(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		wantErr error
+	}{
+		{"existing", 1, nil},
+		{"missing", 2, domain.ErrCommentNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+			createComment(t, svc, 1, 0, bob, "Hi @carol")
+
+			resp, err := svc.GetComment(&GetCommentInput{ID: tt.id})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if resp.Comment.User.Username != "bob" || strings.Join(resp.Comment.Mentions, ",") != "carol" {
+				t.Errorf("got comment by %q mentioning %v", resp.Comment.User.Username, resp.Comment.Mentions)
+			}
+		})
+	}
+}
+
+func TestUpdateComment(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantErr      error
+		wantMentions string
+		wantCarol    string
+	}{
+		{"new content", "Nice one", nil, "", ""},
+		{"new mention", "Nice, @carol", nil, "carol", dto.NotificationMention},
+		{"unchanged", "Nice", domain.ErrCommentsBadRequest, "", ""},
+		{"blank", "", domain.ErrCommentsBadRequest, "", ""},
+		{"padded", " Nice one", domain.ErrCommentsBadRequest, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store := newTestService(t)
+			id := createComment(t, svc, 1, 0, bob, "Nice")
+
+			before, err := svc.GetComment(&GetCommentInput{ID: id})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			input := &UpdateCommentInput{ID: id, Content: tt.content, Errors: make(validator.Errors)}
+			if err := svc.UpdateComment(input, before.Comment); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if (tt.wantErr != nil) != (input.Errors.Get("content") != "") {
+				t.Errorf("got errors %v", input.Errors)
+			}
+
+			after, err := svc.GetComment(&GetCommentInput{ID: id})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantErr == nil && after.Comment.Content != tt.content {
+				t.Errorf("got content %q, want %q", after.Comment.Content, tt.content)
+			}
+			if tt.wantErr != nil && after.Comment.Content != before.Comment.Content {
+				t.Errorf("content changed to %q by a rejected update", after.Comment.Content)
+			}
+			if got := strings.Join(after.Comment.Mentions, ","); got != tt.wantMentions {
+				t.Errorf("got mentions %q, want %q", got, tt.wantMentions)
+			}
+			if got := notificationTypes(t, store, carol); got != tt.wantCarol {
+				t.Errorf("got notifications for carol %q, want %q", got, tt.wantCarol)
+			}
+		})
+	}
+}
+
+func TestUpdateCommentKeepsMentionNotifications(t *testing.T) {
+	svc, store := newTestService(t)
+	id := createComment(t, svc, 1, 0, bob, "Hi @carol")
+
+	for _, content := range []string{"Hi again @carol", "Hi all"} {
+		comment, err := svc.GetComment(&GetCommentInput{ID: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := svc.UpdateComment(&UpdateCommentInput{ID: id, Content: content, Errors: make(validator.Errors)}, comment.Comment); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := notificationTypes(t, store, carol); got != dto.NotificationMention {
+		t.Errorf("got notifications for carol %q, want a single mention", got)
+	}
+}
+
+func TestDeleteComment(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        int
+		wantGone  []int
+		wantKept  []int
+		wantAlice string
+	}{
+		{"leaf", 3, []int{3}, []int{1, 2}, dto.NotificationComment},
+		{"thread", 1, []int{1, 2, 3}, []int{}, ""},
+		{"missing", 9, []int{}, []int{1, 2, 3}, dto.NotificationComment},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store := newTestService(t)
+			createComment(t, svc, 1, 0, bob, "Nice")
+			createComment(t, svc, 1, 1, alice, "Thanks")
+			createComment(t, svc, 1, 2, alice, "Really")
+
+			if err := svc.DeleteComment(&DeleteCommentInput{ID: tt.id}); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, id := range tt.wantGone {
+				if _, err := svc.GetComment(&GetCommentInput{ID: id}); !errors.Is(err, domain.ErrCommentNotFound) {
+					t.Errorf("comment %d: got error %v, want %v", id, err, domain.ErrCommentNotFound)
+				}
+			}
+			for _, id := range tt.wantKept {
+				if _, err := svc.GetComment(&GetCommentInput{ID: id}); err != nil {
+					t.Errorf("comment %d: %v", id, err)
+				}
+			}
+
+			if got := notificationTypes(t, store, alice); got != tt.wantAlice {
+				t.Errorf("got notifications for alice %q, want %q", got, tt.wantAlice)
+			}
+		})
+	}
+}
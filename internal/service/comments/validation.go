@@ -8,10 +8,12 @@ import (
 )
 
 type CreateCommentInput struct {
-	PostID  int
-	UserID  int
-	Content string
-	Errors  validator.Errors
+	PostID   int
+	ParentID int
+	UserID   int
+	UserRole string
+	Content  string
+	Errors   validator.Errors
 }
 
 func (i *CreateCommentInput) validate() error {
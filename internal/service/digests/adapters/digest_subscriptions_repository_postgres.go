@@ -0,0 +1,117 @@
+package adapters
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/service/digests/domain"
+)
+
+type DigestSubscriptionsRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewDigestSubscriptionsRepositoryPostgres(db *sql.DB) *DigestSubscriptionsRepositoryPostgres {
+	return &DigestSubscriptionsRepositoryPostgres{db}
+}
+
+func (r *DigestSubscriptionsRepositoryPostgres) Get(input domain.GetDigestSubscriptionInput) (*dto.DigestSubscription, error) {
+	query := "SELECT u.id, u.username, COALESCE(u.email, ''), d.frequency, d.last_sent FROM digest_subscriptions d INNER JOIN users u ON d.user_id = u.id WHERE d.user_id = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	subscription, err := scanDigestSubscription(stmt.QueryRow(input.UserID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrDigestSubscriptionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (r *DigestSubscriptionsRepositoryPostgres) GetAllDue(input domain.GetAllDueDigestSubscriptionsInput) ([]*dto.DigestSubscription, error) {
+	query := "SELECT u.id, u.username, COALESCE(u.email, ''), d.frequency, d.last_sent FROM digest_subscriptions d INNER JOIN users u ON d.user_id = u.id WHERE d.last_sent IS NULL OR (d.frequency = $1 AND d.last_sent <= $2) OR (d.frequency = $3 AND d.last_sent <= $4)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(
+		dto.DigestDaily, input.DailyBefore.UTC().Format(repository.TimeLayout),
+		dto.DigestWeekly, input.WeeklyBefore.UTC().Format(repository.TimeLayout),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := []*dto.DigestSubscription{}
+	for rows.Next() {
+		subscription, err := scanDigestSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// Upsert keeps last_sent when only the frequency changes, so switching from
+// weekly to daily does not resend what was already mailed.
+func (r *DigestSubscriptionsRepositoryPostgres) Upsert(input domain.UpsertDigestSubscriptionInput) error {
+	query := "INSERT INTO digest_subscriptions (user_id, frequency) VALUES ($1, $2) ON CONFLICT (user_id) DO UPDATE SET frequency = excluded.frequency"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.UserID, input.Frequency); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *DigestSubscriptionsRepositoryPostgres) MarkSent(input domain.MarkDigestSentInput) error {
+	query := "UPDATE digest_subscriptions SET last_sent = $1 WHERE user_id = $2"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.Sent.UTC().Format(repository.TimeLayout), input.UserID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *DigestSubscriptionsRepositoryPostgres) Delete(input domain.DeleteDigestSubscriptionInput) error {
+	query := "DELETE FROM digest_subscriptions WHERE user_id = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.UserID); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package adapters
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/service/digests/domain"
+)
+
+type DigestSubscriptionsRepositorySqlite struct {
+	db *sql.DB
+}
+
+func NewDigestSubscriptionsRepositorySqlite(db *sql.DB) *DigestSubscriptionsRepositorySqlite {
+	return &DigestSubscriptionsRepositorySqlite{db}
+}
+
+func (r *DigestSubscriptionsRepositorySqlite) Get(input domain.GetDigestSubscriptionInput) (*dto.DigestSubscription, error) {
+	query := "SELECT u.id, u.username, COALESCE(u.email, ''), d.frequency, d.last_sent FROM digest_subscriptions d INNER JOIN users u ON d.user_id = u.id WHERE d.user_id = ?"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	subscription, err := scanDigestSubscription(stmt.QueryRow(input.UserID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrDigestSubscriptionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (r *DigestSubscriptionsRepositorySqlite) GetAllDue(input domain.GetAllDueDigestSubscriptionsInput) ([]*dto.DigestSubscription, error) {
+	query := "SELECT u.id, u.username, COALESCE(u.email, ''), d.frequency, d.last_sent FROM digest_subscriptions d INNER JOIN users u ON d.user_id = u.id WHERE d.last_sent IS NULL OR (d.frequency = ? AND d.last_sent <= ?) OR (d.frequency = ? AND d.last_sent <= ?)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(
+		dto.DigestDaily, input.DailyBefore.UTC().Format(repository.TimeLayout),
+		dto.DigestWeekly, input.WeeklyBefore.UTC().Format(repository.TimeLayout),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := []*dto.DigestSubscription{}
+	for rows.Next() {
+		subscription, err := scanDigestSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// Upsert keeps last_sent when only the frequency changes, so switching from
+// weekly to daily does not resend what was already mailed.
+func (r *DigestSubscriptionsRepositorySqlite) Upsert(input domain.UpsertDigestSubscriptionInput) error {
+	query := "INSERT INTO digest_subscriptions (user_id, frequency) VALUES (?, ?) ON CONFLICT (user_id) DO UPDATE SET frequency = excluded.frequency"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.UserID, input.Frequency); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *DigestSubscriptionsRepositorySqlite) MarkSent(input domain.MarkDigestSentInput) error {
+	query := "UPDATE digest_subscriptions SET last_sent = ? WHERE user_id = ?"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.Sent.UTC().Format(repository.TimeLayout), input.UserID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *DigestSubscriptionsRepositorySqlite) Delete(input domain.DeleteDigestSubscriptionInput) error {
+	query := "DELETE FROM digest_subscriptions WHERE user_id = ?"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.UserID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanDigestSubscription(row rowScanner) (*dto.DigestSubscription, error) {
+	subscription := &dto.DigestSubscription{User: &dto.User{}}
+	var lastSent sql.NullTime
+
+	if err := row.Scan(
+		&subscription.User.ID,
+		&subscription.User.Username,
+		&subscription.User.Email,
+		&subscription.Frequency,
+		&lastSent,
+	); err != nil {
+		return nil, err
+	}
+
+	if lastSent.Valid {
+		subscription.LastSent = lastSent.Time
+	}
+
+	return subscription, nil
+}
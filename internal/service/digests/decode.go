@@ -0,0 +1,28 @@
+package digests
+
+import (
+	"net/http"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/digests/domain"
+	"github.com/itelman/forum/pkg/validator"
+)
+
+func DecodeGetDigestSettings(r *http.Request) interface{} {
+	return &GetDigestSettingsInput{dto.GetAuthUser(r).ID}
+}
+
+func DecodeUpdateDigestSettings(r *http.Request) (interface{}, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, domain.ErrDigestsBadRequest
+	}
+
+	user := dto.GetAuthUser(r)
+
+	return &UpdateDigestSettingsInput{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Frequency: r.PostForm.Get("frequency"),
+		Errors:    make(validator.Errors),
+	}, nil
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+)
+
+type DigestSubscriptionsRepository interface {
+	Get(input GetDigestSubscriptionInput) (*dto.DigestSubscription, error)
+	GetAllDue(input GetAllDueDigestSubscriptionsInput) ([]*dto.DigestSubscription, error)
+	Upsert(input UpsertDigestSubscriptionInput) error
+	MarkSent(input MarkDigestSentInput) error
+	Delete(input DeleteDigestSubscriptionInput) error
+}
+
+type GetDigestSubscriptionInput struct {
+	UserID int
+}
+
+type GetAllDueDigestSubscriptionsInput struct {
+	DailyBefore  time.Time
+	WeeklyBefore time.Time
+}
+
+type UpsertDigestSubscriptionInput struct {
+	UserID    int
+	Frequency string
+}
+
+type MarkDigestSentInput struct {
+	UserID int
+	Sent   time.Time
+}
+
+type DeleteDigestSubscriptionInput struct {
+	UserID int
+}
+
+var (
+	ErrDigestsBadRequest          = errors.New("DIGESTS: bad request")
+	ErrDigestSubscriptionNotFound = errors.New("DATABASE: Digest subscription not found")
+)
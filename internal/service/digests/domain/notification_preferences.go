@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type NotificationPreferencesRepository interface {
+	GetAll(input GetAllNotificationPreferencesInput) ([]*dto.NotificationPreference, error)
+}
+
+type GetAllNotificationPreferencesInput = repository.GetAllNotificationPreferencesInput
@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type NotificationsRepository interface {
+	GetAllUnreadSince(input GetAllUnreadNotificationsSinceInput) ([]*dto.Notification, error)
+}
+
+type GetAllUnreadNotificationsSinceInput = repository.GetAllUnreadNotificationsSinceInput
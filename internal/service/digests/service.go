@@ -0,0 +1,217 @@
+package digests
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/service/digests/adapters"
+	"github.com/itelman/forum/internal/service/digests/domain"
+	"github.com/itelman/forum/pkg/mailer"
+	"github.com/itelman/forum/pkg/templates"
+)
+
+const (
+	digestTemplate = "digest_email"
+	maxDigestItems = 50
+)
+
+var periods = map[string]time.Duration{
+	dto.DigestDaily:  24 * time.Hour,
+	dto.DigestWeekly: 7 * 24 * time.Hour,
+}
+
+type Service interface {
+	GetDigestSettings(input *GetDigestSettingsInput) (*GetDigestSettingsResponse, error)
+	UpdateDigestSettings(input *UpdateDigestSettingsInput) error
+	SendDueDigests() error
+}
+
+type service struct {
+	subscriptions domain.DigestSubscriptionsRepository
+	notifications domain.NotificationsRepository
+	preferences   domain.NotificationPreferencesRepository
+	mailer        mailer.Mailer
+	emails        templates.EmailRender
+	host          string
+}
+
+func NewService(opts ...Option) *service {
+	svc := &service{}
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	return svc
+}
+
+type Option func(*service)
+
+func WithSqlite(db *sql.DB) Option {
+	return func(s *service) {
+		s.subscriptions = adapters.NewDigestSubscriptionsRepositorySqlite(db)
+		s.notifications = repository.NewNotificationsRepositorySqlite(db)
+		s.preferences = repository.NewNotificationPreferencesRepositorySqlite(db)
+	}
+}
+
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.subscriptions = adapters.NewDigestSubscriptionsRepositoryPostgres(db)
+		s.notifications = repository.NewNotificationsRepositoryPostgres(db)
+		s.preferences = repository.NewNotificationPreferencesRepositoryPostgres(db)
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.subscriptions = memory.NewDigestSubscriptionsRepository(store)
+		s.notifications = memory.NewNotificationsRepository(store)
+		s.preferences = memory.NewNotificationPreferencesRepository(store)
+	}
+}
+
+// WithMailer sets how digests are delivered. host is the public address of
+// the forum and is used for links in the emails.
+func WithMailer(m mailer.Mailer, emails templates.EmailRender, host string) Option {
+	return func(s *service) {
+		s.mailer = m
+		s.emails = emails
+		s.host = host
+	}
+}
+
+type GetDigestSettingsResponse struct {
+	Frequency string
+}
+
+func (s *service) GetDigestSettings(input *GetDigestSettingsInput) (*GetDigestSettingsResponse, error) {
+	subscription, err := s.subscriptions.Get(domain.GetDigestSubscriptionInput{UserID: input.UserID})
+	if errors.Is(err, domain.ErrDigestSubscriptionNotFound) {
+		return &GetDigestSettingsResponse{dto.DigestNever}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &GetDigestSettingsResponse{subscription.Frequency}, nil
+}
+
+func (s *service) UpdateDigestSettings(input *UpdateDigestSettingsInput) error {
+	if err := input.validate(); err != nil {
+		return err
+	}
+
+	if input.Frequency == dto.DigestNever {
+		return s.subscriptions.Delete(domain.DeleteDigestSubscriptionInput{UserID: input.UserID})
+	}
+
+	return s.subscriptions.Upsert(domain.UpsertDigestSubscriptionInput{
+		UserID:    input.UserID,
+		Frequency: input.Frequency,
+	})
+}
+
+// SendDueDigests mails every subscriber whose period has passed the unread
+// notifications they got since their last digest. A failed delivery is retried
+// on the next run; the other subscribers are still processed.
+func (s *service) SendDueDigests() error {
+	if s.mailer == nil {
+		return nil
+	}
+
+	now := time.Now()
+	subscriptions, err := s.subscriptions.GetAllDue(domain.GetAllDueDigestSubscriptionsInput{
+		DailyBefore:  now.Add(-periods[dto.DigestDaily]),
+		WeeklyBefore: now.Add(-periods[dto.DigestWeekly]),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Notifications created during the current second are left for the next
+	// digest, since stored times have second precision.
+	until := now.Truncate(time.Second).Add(-time.Second)
+
+	var errs []error
+	for _, subscription := range subscriptions {
+		if err := s.sendDigest(subscription, until); err != nil {
+			errs = append(errs, fmt.Errorf("digest for user %d: %w", subscription.User.ID, err))
+			continue
+		}
+
+		if err := s.subscriptions.MarkSent(domain.MarkDigestSentInput{
+			UserID: subscription.User.ID,
+			Sent:   until,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *service) sendDigest(subscription *dto.DigestSubscription, until time.Time) error {
+	since := subscription.LastSent
+	if since.IsZero() {
+		since = until.Add(-periods[subscription.Frequency])
+	}
+
+	preferences, err := s.preferences.GetAll(domain.GetAllNotificationPreferencesInput{UserID: subscription.User.ID})
+	if err != nil {
+		return err
+	}
+
+	muted := []string{}
+	for _, preference := range preferences {
+		if !preference.Email {
+			muted = append(muted, dto.PreferenceTypes(preference.Event)...)
+		}
+	}
+
+	notifications, err := s.notifications.GetAllUnreadSince(domain.GetAllUnreadNotificationsSinceInput{
+		UserID:       subscription.User.ID,
+		ExcludeTypes: muted,
+		Since:        since,
+		Until:        until,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(notifications) == 0 || len(subscription.User.Email) == 0 {
+		return nil
+	}
+
+	count := len(notifications)
+	if count > maxDigestItems {
+		notifications = notifications[:maxDigestItems]
+	}
+
+	subject, text, body, err := s.emails.RenderEmail(digestTemplate, templates.TemplateData{
+		templates.Recipient:     subscription.User,
+		templates.Notifications: notifications,
+		templates.UnreadCount:   count,
+		templates.Period:        subscription.Frequency,
+		templates.Host:          s.host,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(mailer.Message{
+		To:      subscription.User.Email,
+		Subject: subject,
+		Body:    body,
+		Text:    text,
+	})
+}
@@ -0,0 +1,172 @@
+package digests
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	"github.com/itelman/forum/internal/service/digests/domain"
+	"github.com/itelman/forum/pkg/mailer"
+	"github.com/itelman/forum/pkg/templates"
+	"github.com/itelman/forum/pkg/validator"
+)
+
+const (
+	alice = 1
+	bob   = 2
+	carol = 3
+)
+
+type outbox struct {
+	sent []mailer.Message
+}
+
+func (o *outbox) Send(msg mailer.Message) error {
+	o.sent = append(o.sent, msg)
+	return nil
+}
+
+// emailRender renders the number of notifications and their ids, which is
+// all the tests look at.
+type emailRender struct{}
+
+func (emailRender) RenderEmail(tmplName string, td templates.TemplateData) (string, string, string, error) {
+	ids := []int{}
+	for _, notification := range td[templates.Notifications].([]*dto.Notification) {
+		ids = append(ids, notification.ID)
+	}
+
+	return fmt.Sprintf("%d unread", td[templates.UnreadCount]), "", fmt.Sprint(ids), nil
+}
+
+func newTestService(t *testing.T) (*service, *memory.Store, *outbox) {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice", "bob", "carol")
+	memorytest.Post(t, store, alice, "Hello world")
+
+	mail := &outbox{}
+	return NewService(WithMemoryStore(store), WithMailer(mail, emailRender{}, "http://localhost")), store, mail
+}
+
+func subscribe(t *testing.T, svc *service, userId int, frequency string) {
+	t.Helper()
+
+	if err := svc.UpdateDigestSettings(&UpdateDigestSettingsInput{UserID: userId, Email: "user@example.com", Frequency: frequency, Errors: make(validator.Errors)}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func notify(t *testing.T, store *memory.Store, userId int, notificationType string) {
+	t.Helper()
+
+	if _, err := memory.NewNotificationsRepository(store).Create(nil, repository.CreateNotificationInput{UserID: userId, ActorID: carol, Type: notificationType, PostID: 1}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateDigestSettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		email     string
+		frequency string
+		wantErr   error
+		want      string
+	}{
+		{"daily", "alice@example.com", dto.DigestDaily, nil, dto.DigestDaily},
+		{"weekly", "alice@example.com", dto.DigestWeekly, nil, dto.DigestWeekly},
+		{"never", "alice@example.com", dto.DigestNever, nil, dto.DigestNever},
+		{"no email", "", dto.DigestDaily, domain.ErrDigestsBadRequest, dto.DigestNever},
+		{"no email never", "", dto.DigestNever, nil, dto.DigestNever},
+		{"unknown frequency", "alice@example.com", "hourly", domain.ErrDigestsBadRequest, dto.DigestNever},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _ := newTestService(t)
+
+			input := &UpdateDigestSettingsInput{UserID: alice, Email: tt.email, Frequency: tt.frequency, Errors: make(validator.Errors)}
+			if err := svc.UpdateDigestSettings(input); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			resp, err := svc.GetDigestSettings(&GetDigestSettingsInput{UserID: alice})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Frequency != tt.want {
+				t.Fatalf("frequency = %q, want %q", resp.Frequency, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendDueDigests(t *testing.T) {
+	svc, store, mail := newTestService(t)
+	subscribe(t, svc, alice, dto.DigestDaily)
+	subscribe(t, svc, bob, dto.DigestWeekly)
+
+	// bob only gets mentions, which are muted for email.
+	if err := memory.NewNotificationPreferencesRepository(store).Upsert(nil, repository.UpsertNotificationPreferenceInput{
+		UserID:     bob,
+		Preference: &dto.NotificationPreference{Event: dto.PreferenceMentions, InApp: true, Live: true},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	notify(t, store, alice, dto.NotificationComment) // 1
+	notify(t, store, alice, dto.NotificationMention) // 2
+	notify(t, store, bob, dto.NotificationMention)   // 3
+	store.Age(time.Hour)
+
+	if err := svc.SendDueDigests(); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(mail.sent); got != "[{alice@example.com 2 unread [2 1] }]" {
+		t.Fatalf("sent = %s", got)
+	}
+
+	// Neither subscription is due again yet.
+	notify(t, store, alice, dto.NotificationReply) // 4
+	mail.sent = nil
+	if err := svc.SendDueDigests(); err != nil {
+		t.Fatal(err)
+	}
+	if len(mail.sent) != 0 {
+		t.Fatalf("sent = %v, want nothing", mail.sent)
+	}
+
+	// A day later alice gets what arrived since the last digest.
+	store.Age(25 * time.Hour)
+	if err := svc.SendDueDigests(); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(mail.sent); got != "[{alice@example.com 1 unread [4] }]" {
+		t.Fatalf("sent = %s", got)
+	}
+}
+
+func TestSendDueDigestsWithoutMailer(t *testing.T) {
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice")
+	svc := NewService(WithMemoryStore(store))
+	subscribe(t, svc, alice, dto.DigestDaily)
+
+	if err := svc.SendDueDigests(); err != nil {
+		t.Fatal(err)
+	}
+
+	subscription, err := svc.subscriptions.Get(domain.GetDigestSubscriptionInput{UserID: alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !subscription.LastSent.IsZero() {
+		t.Fatalf("last sent = %v, want never", subscription.LastSent)
+	}
+}
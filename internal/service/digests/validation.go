@@ -0,0 +1,36 @@
+package digests
+
+import (
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/digests/domain"
+	"github.com/itelman/forum/pkg/validator"
+)
+
+type GetDigestSettingsInput struct {
+	UserID int
+}
+
+type UpdateDigestSettingsInput struct {
+	UserID    int
+	Email     string
+	Frequency string
+	Errors    validator.Errors
+}
+
+func (i *UpdateDigestSettingsInput) validate() error {
+	switch i.Frequency {
+	case dto.DigestNever:
+	case dto.DigestDaily, dto.DigestWeekly:
+		if len(i.Email) == 0 {
+			i.Errors.Add("frequency", "Your account has no email address to send digests to")
+		}
+	default:
+		i.Errors.Add("frequency", validator.ErrInputRequired("frequency"))
+	}
+
+	if len(i.Errors) != 0 {
+		return domain.ErrDigestsBadRequest
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package filters
 import (
 	"github.com/itelman/forum/internal/dto"
 	"github.com/itelman/forum/internal/service/filters/domain"
+	"github.com/itelman/forum/pkg/pagination"
 	"github.com/itelman/forum/pkg/validator"
 	"net/http"
 	"strconv"
@@ -13,9 +14,19 @@ func DecodeGetPostsByFilters(r *http.Request) (interface{}, error) {
 		return nil, domain.ErrFiltersBadRequest
 	}
 
-	catgId, err := strconv.Atoi(r.PostForm.Get("category_id"))
+	catgIds, err := decodeIDs(r.Form["category_id"])
 	if err != nil {
-		catgId = -1
+		return nil, domain.ErrFiltersBadRequest
+	}
+
+	excludedIds, err := decodeIDs(r.Form["exclude_id"])
+	if err != nil {
+		return nil, domain.ErrFiltersBadRequest
+	}
+
+	cursor, err := pagination.ParseCursor(r.Form.Get("cursor"))
+	if err != nil {
+		return nil, domain.ErrFiltersBadRequest
 	}
 
 	userId := -1
@@ -25,10 +36,33 @@ func DecodeGetPostsByFilters(r *http.Request) (interface{}, error) {
 	}
 
 	return &GetPostsByFiltersInput{
-		CategoryID: catgId,
-		Created:    r.PostForm.Get("created") == "1",
-		Liked:      r.PostForm.Get("liked") == "1",
-		AuthUserID: userId,
-		Errors:     make(validator.Errors),
+		CategoryIDs: catgIds,
+		Match:       r.Form.Get("match"),
+		ExcludedIDs: excludedIds,
+		Created:     r.Form.Get("created") == "1",
+		Liked:       r.Form.Get("liked") == "1",
+		AuthUserID:  userId,
+		Sort:        r.Form.Get("sort"),
+		Period:      r.Form.Get("t"),
+		Cursor:      cursor,
+		Errors:      make(validator.Errors),
 	}, nil
 }
+
+func decodeIDs(values []string) ([]int, error) {
+	ids := []int{}
+	for _, value := range values {
+		if len(value) == 0 {
+			continue
+		}
+
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
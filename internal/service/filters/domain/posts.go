@@ -2,20 +2,16 @@ package domain
 
 import (
 	"errors"
+
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
 )
 
 type PostsRepository interface {
 	GetManyByFilters(input GetPostsByFiltersInput) ([]*dto.Post, error)
 }
 
-type GetPostsByFiltersInput struct {
-	CategoryID     int
-	Created        bool
-	Liked          bool
-	AuthUserID     int
-	SortedByNewest bool
-}
+type GetPostsByFiltersInput = repository.GetPostsByFiltersInput
 
 var (
 	ErrFiltersBadRequest   = errors.New("FILTERS: bad request")
@@ -3,8 +3,10 @@ package filters
 import (
 	"database/sql"
 	"github.com/itelman/forum/internal/dto"
-	"github.com/itelman/forum/internal/service/filters/adapters"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
 	"github.com/itelman/forum/internal/service/filters/domain"
+	"github.com/itelman/forum/pkg/pagination"
 )
 
 type Service interface {
@@ -28,12 +30,31 @@ type Option func(*service)
 
 func WithSqlite(db *sql.DB) Option {
 	return func(s *service) {
-		s.posts = adapters.NewPostsRepositorySqlite(db)
+		s.posts = repository.NewPostsRepositorySqlite(db)
+	}
+}
+
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.posts = repository.NewPostsRepositoryPostgres(db)
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.posts = memory.NewPostsRepository(store)
 	}
 }
 
 type GetManyPostsResponse struct {
-	Posts []*dto.Post
+	Posts      []*dto.Post
+	NextCursor string
 }
 
 func (s *service) GetPostsByFilters(input *GetPostsByFiltersInput) (*GetManyPostsResponse, error) {
@@ -42,15 +63,27 @@ func (s *service) GetPostsByFilters(input *GetPostsByFiltersInput) (*GetManyPost
 	}
 	
 	posts, err := s.posts.GetManyByFilters(domain.GetPostsByFiltersInput{
-		CategoryID:     input.CategoryID,
+		CategoryIDs:    input.CategoryIDs,
+		MatchAll:       input.Match == MatchAll,
+		ExcludedIDs:    input.ExcludedIDs,
 		Created:        input.Created,
 		Liked:          input.Liked,
 		AuthUserID:     input.AuthUserID,
 		SortedByNewest: true,
+		Sort:           input.Sort,
+		Period:         input.Period,
+		Cursor:         input.Cursor,
+		Limit:          pagination.DefaultLimit + 1,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &GetManyPostsResponse{Posts: posts}, nil
+	posts, nextCursor := pagination.Paginate(posts, pagination.DefaultLimit, postCursor)
+
+	return &GetManyPostsResponse{Posts: posts, NextCursor: nextCursor}, nil
+}
+
+func postCursor(post *dto.Post) *pagination.Cursor {
+	return &pagination.Cursor{Created: post.Created, Score: post.Score, ID: post.ID}
 }
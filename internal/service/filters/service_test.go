@@ -0,0 +1,114 @@
+package filters
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	"github.com/itelman/forum/internal/service/filters/domain"
+	postsdomain "github.com/itelman/forum/internal/service/posts/domain"
+	"github.com/itelman/forum/pkg/pagination"
+)
+
+const (
+	alice = 1
+	bob   = 2
+)
+
+const (
+	music = iota + 1
+	books
+	hobbies
+	games
+)
+
+// newTestService seeds posts in these categories, by these users:
+//
+//	1: Music         alice, liked by bob
+//	2: Music, Books  bob, liked by alice
+//	3: Books         alice, disliked by bob
+//	4: Hobbies       bob, pending
+func newTestService(t *testing.T) *service {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice", "bob")
+
+	posts := memory.NewPostsRepository(store)
+	postCategories := memory.NewPostCategoriesRepository(store)
+	for _, p := range []struct {
+		userId  int
+		catgsId []int
+	}{{alice, []int{music}}, {bob, []int{music, books}}, {alice, []int{books}}, {bob, []int{hobbies}}} {
+		postId := memorytest.Post(t, store, p.userId, "Hello world")
+
+		if err := postCategories.Create(nil, postsdomain.CreatePostCategoriesInput{PostID: postId, CategoriesID: p.catgsId}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	memorytest.Pending(t, store, 4)
+
+	reactions := memory.NewPostReactionsRepository(store)
+	for _, r := range []repository.CreatePostReactionInput{{PostID: 1, UserID: bob, IsLike: 1}, {PostID: 2, UserID: alice, IsLike: 1}, {PostID: 3, UserID: bob, IsLike: 0}} {
+		if _, err := reactions.Insert(nil, r); err != nil {
+			t.Fatal(err)
+		}
+		if err := posts.UpdateReactionsCount(nil, repository.UpdatePostReactionsCountInput{PostID: r.PostID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return NewService(WithMemoryStore(store))
+}
+
+func TestGetPostsByFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   GetPostsByFiltersInput
+		wantErr error
+		want    string
+	}{
+		{"any category", GetPostsByFiltersInput{CategoryIDs: []int{music, books}, AuthUserID: -1}, nil, "[3 2 1]"},
+		{"all categories", GetPostsByFiltersInput{CategoryIDs: []int{music, books}, Match: MatchAll, AuthUserID: -1}, nil, "[2]"},
+		{"duplicate categories", GetPostsByFiltersInput{CategoryIDs: []int{books, books}, Match: MatchAll, AuthUserID: -1}, nil, "[3 2]"},
+		{"excluded category", GetPostsByFiltersInput{ExcludedIDs: []int{books}, AuthUserID: -1}, nil, "[1]"},
+		{"included and excluded", GetPostsByFiltersInput{CategoryIDs: []int{music}, ExcludedIDs: []int{books}, AuthUserID: -1}, nil, "[1]"},
+		{"pending category", GetPostsByFiltersInput{CategoryIDs: []int{hobbies}, AuthUserID: -1}, nil, "[]"},
+		{"empty category", GetPostsByFiltersInput{CategoryIDs: []int{games}, AuthUserID: -1}, nil, "[]"},
+		{"created", GetPostsByFiltersInput{Created: true, AuthUserID: alice}, nil, "[3 1]"},
+		{"liked", GetPostsByFiltersInput{Liked: true, AuthUserID: bob}, nil, "[1]"},
+		{"created and liked", GetPostsByFiltersInput{Created: true, Liked: true, AuthUserID: bob}, nil, "[]"},
+		{"created in category", GetPostsByFiltersInput{CategoryIDs: []int{books}, Created: true, AuthUserID: alice}, nil, "[3]"},
+		{"top", GetPostsByFiltersInput{CategoryIDs: []int{music, books}, Sort: pagination.SortTop, AuthUserID: -1}, nil, "[2 1 3]"},
+		{"nothing selected", GetPostsByFiltersInput{AuthUserID: alice}, domain.ErrFiltersNoneSelected, ""},
+		{"created anonymously", GetPostsByFiltersInput{Created: true, AuthUserID: -1}, domain.ErrUserUnauthorized, ""},
+		{"liked anonymously", GetPostsByFiltersInput{Liked: true, AuthUserID: -1}, domain.ErrUserUnauthorized, ""},
+		{"unknown match", GetPostsByFiltersInput{CategoryIDs: []int{music}, Match: "some", AuthUserID: -1}, domain.ErrFiltersBadRequest, ""},
+		{"invalid category", GetPostsByFiltersInput{CategoryIDs: []int{0}, AuthUserID: -1}, domain.ErrFiltersBadRequest, ""},
+		{"invalid excluded category", GetPostsByFiltersInput{ExcludedIDs: []int{-1}, AuthUserID: -1}, domain.ErrFiltersBadRequest, ""},
+		{"category included and excluded", GetPostsByFiltersInput{CategoryIDs: []int{music}, ExcludedIDs: []int{music}, AuthUserID: -1}, domain.ErrFiltersBadRequest, ""},
+		{"unknown sort", GetPostsByFiltersInput{CategoryIDs: []int{music}, Sort: "best", AuthUserID: -1}, domain.ErrFiltersBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(t)
+
+			resp, err := svc.GetPostsByFilters(&tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got := memorytest.PostIDs(resp.Posts); got != tt.want || resp.NextCursor != "" {
+				t.Errorf("got %s and cursor %q, want %s", got, resp.NextCursor, tt.want)
+			}
+		})
+	}
+}
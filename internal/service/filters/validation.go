@@ -2,19 +2,30 @@ package filters
 
 import (
 	"github.com/itelman/forum/internal/service/filters/domain"
+	"github.com/itelman/forum/pkg/pagination"
 	"github.com/itelman/forum/pkg/validator"
 )
 
+const (
+	MatchAny = "any"
+	MatchAll = "all"
+)
+
 type GetPostsByFiltersInput struct {
-	CategoryID int
-	Created    bool
-	Liked      bool
-	AuthUserID int
-	Errors     validator.Errors
+	CategoryIDs []int
+	Match       string
+	ExcludedIDs []int
+	Created     bool
+	Liked       bool
+	AuthUserID  int
+	Sort        string
+	Period      string
+	Cursor      *pagination.Cursor
+	Errors      validator.Errors
 }
 
 func (i *GetPostsByFiltersInput) validate() error {
-	if i.CategoryID == -1 && !i.Created && !i.Liked {
+	if len(i.CategoryIDs) == 0 && len(i.ExcludedIDs) == 0 && !i.Created && !i.Liked {
 		return domain.ErrFiltersNoneSelected
 
 		//i.Errors.Add("generic", "Please select at least one filter")
@@ -25,5 +36,47 @@ func (i *GetPostsByFiltersInput) validate() error {
 		return domain.ErrUserUnauthorized
 	}
 
+	if len(i.Match) == 0 {
+		i.Match = MatchAny
+	}
+
+	if i.Match != MatchAny && i.Match != MatchAll {
+		return domain.ErrFiltersBadRequest
+	}
+
+	for _, ids := range [][]int{i.CategoryIDs, i.ExcludedIDs} {
+		for _, id := range ids {
+			if id < 1 {
+				return domain.ErrFiltersBadRequest
+			}
+		}
+	}
+
+	included := make(map[int]bool)
+	i.CategoryIDs = uniqueIDs(i.CategoryIDs, included)
+	i.ExcludedIDs = uniqueIDs(i.ExcludedIDs, make(map[int]bool))
+
+	for _, id := range i.ExcludedIDs {
+		if included[id] {
+			return domain.ErrFiltersBadRequest
+		}
+	}
+
+	if !pagination.ValidSort(i.Sort, i.Period) {
+		return domain.ErrFiltersBadRequest
+	}
+
 	return nil
 }
+
+func uniqueIDs(ids []int, seen map[int]bool) []int {
+	unique := []int{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique
+}
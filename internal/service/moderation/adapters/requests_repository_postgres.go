@@ -0,0 +1,115 @@
+package adapters
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/moderation/domain"
+	"github.com/lib/pq"
+)
+
+type RequestsRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewRequestsRepositoryPostgres(db *sql.DB) *RequestsRepositoryPostgres {
+	return &RequestsRepositoryPostgres{db}
+}
+
+func (r *RequestsRepositoryPostgres) Create(input domain.CreateRequestInput) error {
+	query := "INSERT INTO requests (user_id) VALUES ($1)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(input.UserID)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+		return domain.ErrRequestExists
+	} else if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *RequestsRepositoryPostgres) Get(input domain.GetRequestInput) (*dto.Request, error) {
+	query := "SELECT requests.id, users.id, users.username, requests.created FROM requests INNER JOIN users ON requests.user_id = users.id WHERE requests.id = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	request := &dto.Request{User: &dto.User{}}
+	if err := stmt.QueryRow(input.ID).Scan(
+		&request.ID,
+		&request.User.ID,
+		&request.User.Username,
+		&request.Created,
+	); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrRequestNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+func (r *RequestsRepositoryPostgres) GetAll(input domain.GetAllRequestsInput) ([]*dto.Request, error) {
+	query := "SELECT requests.id, users.id, users.username, requests.created FROM requests INNER JOIN users ON requests.user_id = users.id"
+	if input.SortedByNewest {
+		query += " ORDER BY requests.created DESC"
+	}
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := []*dto.Request{}
+	for rows.Next() {
+		request := &dto.Request{User: &dto.User{}}
+
+		if err := rows.Scan(
+			&request.ID,
+			&request.User.ID,
+			&request.User.Username,
+			&request.Created,
+		); err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, request)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+func (r *RequestsRepositoryPostgres) Delete(tx *sql.Tx, input domain.DeleteRequestInput) error {
+	query := "DELETE FROM requests WHERE id = $1"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package moderation
+
+import (
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/moderation/domain"
+	"net/http"
+	"strconv"
+)
+
+func DecodeCreateRequest(r *http.Request) interface{} {
+	return &CreateRequestInput{dto.GetAuthUser(r).ID}
+}
+
+func DecodeApproveRequest(r *http.Request) (interface{}, error) {
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		return nil, domain.ErrModerationBadRequest
+	}
+
+	return &ApproveRequestInput{ID: id}, nil
+}
+
+func DecodeDeclineRequest(r *http.Request) (interface{}, error) {
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		return nil, domain.ErrModerationBadRequest
+	}
+
+	return &DeclineRequestInput{ID: id}, nil
+}
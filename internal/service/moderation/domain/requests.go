@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/itelman/forum/internal/dto"
+)
+
+type RequestsRepository interface {
+	Create(input CreateRequestInput) error
+	Get(input GetRequestInput) (*dto.Request, error)
+	GetAll(input GetAllRequestsInput) ([]*dto.Request, error)
+	Delete(tx *sql.Tx, input DeleteRequestInput) error
+}
+
+type CreateRequestInput struct {
+	UserID int
+}
+
+type GetRequestInput struct {
+	ID int
+}
+
+type GetAllRequestsInput struct {
+	SortedByNewest bool
+}
+
+type DeleteRequestInput struct {
+	ID int
+}
+
+var (
+	ErrModerationBadRequest = errors.New("MODERATION: bad request")
+	ErrRequestNotFound      = errors.New("DATABASE: Request not found")
+	ErrRequestExists        = errors.New("DATABASE: Request exists")
+)
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/repository"
+)
+
+type UserRolesRepository interface {
+	Create(tx *sql.Tx, input CreateUserRoleInput) error
+	Get(input GetUserRoleInput) (string, error)
+}
+
+type (
+	CreateUserRoleInput = repository.CreateUserRoleInput
+	GetUserRoleInput    = repository.GetUserRoleInput
+)
+
+var (
+	ErrUserRoleNotFound = repository.ErrUserRoleNotFound
+	ErrRoleNotFound     = repository.ErrRoleNotFound
+)
@@ -0,0 +1,150 @@
+package moderation
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/service/moderation/adapters"
+	"github.com/itelman/forum/internal/service/moderation/domain"
+)
+
+type Service interface {
+	CreateRequest(input *CreateRequestInput) error
+	GetAllRequests() (*GetAllRequestsResponse, error)
+	ApproveRequest(input *ApproveRequestInput) error
+	DeclineRequest(input *DeclineRequestInput) error
+}
+
+type service struct {
+	requests  domain.RequestsRepository
+	userRoles domain.UserRolesRepository
+	db        *sql.DB
+}
+
+func NewService(opts ...Option) *service {
+	svc := &service{}
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	return svc
+}
+
+type Option func(*service)
+
+func WithSqlite(db *sql.DB) Option {
+	return func(s *service) {
+		s.requests = adapters.NewRequestsRepositorySqlite(db)
+		s.userRoles = repository.NewUserRolesRepositorySqlite(db)
+		s.db = db
+	}
+}
+
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.requests = adapters.NewRequestsRepositoryPostgres(db)
+		s.userRoles = repository.NewUserRolesRepositoryPostgres(db)
+		s.db = db
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.requests = memory.NewRequestsRepository(store)
+		s.userRoles = memory.NewUserRolesRepository(store)
+		s.db = store.DB()
+	}
+}
+
+func (s *service) CreateRequest(input *CreateRequestInput) error {
+	if _, err := s.userRoles.Get(domain.GetUserRoleInput{UserID: input.UserID}); err == nil {
+		return domain.ErrModerationBadRequest
+	} else if !errors.Is(err, domain.ErrUserRoleNotFound) {
+		return err
+	}
+
+	if err := s.requests.Create(domain.CreateRequestInput{UserID: input.UserID}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type GetAllRequestsResponse struct {
+	Requests []*dto.Request
+}
+
+func (s *service) GetAllRequests() (*GetAllRequestsResponse, error) {
+	requests, err := s.requests.GetAll(domain.GetAllRequestsInput{SortedByNewest: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetAllRequestsResponse{Requests: requests}, nil
+}
+
+func (s *service) ApproveRequest(input *ApproveRequestInput) error {
+	request, err := s.requests.Get(domain.GetRequestInput{ID: input.ID})
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRoles.Create(tx, domain.CreateUserRoleInput{
+		UserID: request.User.ID,
+		Role:   dto.RoleModerator,
+	}); errors.Is(err, domain.ErrRoleNotFound) {
+		tx.Rollback()
+		return domain.ErrModerationBadRequest
+	} else if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := s.requests.Delete(tx, domain.DeleteRequestInput{ID: request.ID}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) DeclineRequest(input *DeclineRequestInput) error {
+	request, err := s.requests.Get(domain.GetRequestInput{ID: input.ID})
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := s.requests.Delete(tx, domain.DeleteRequestInput{ID: request.ID}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package moderation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	"github.com/itelman/forum/internal/service/moderation/domain"
+)
+
+const (
+	alice = 1
+	bob   = 2
+	carol = 3
+)
+
+// newTestService seeds alice as a moderator, and open requests from bob
+// (id 1) and carol (id 2).
+func newTestService(t *testing.T) *service {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice", "bob", "carol")
+	store.SetUserRole(alice, dto.RoleModerator)
+
+	svc := NewService(WithMemoryStore(store))
+	for _, userId := range []int{bob, carol} {
+		if err := svc.CreateRequest(&CreateRequestInput{UserID: userId}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return svc
+}
+
+func TestCreateRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		userId  int
+		wantErr error
+	}{
+		{"moderator", alice, domain.ErrModerationBadRequest},
+		{"already requested", bob, domain.ErrRequestExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(t)
+
+			if err := svc.CreateRequest(&CreateRequestInput{UserID: tt.userId}); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetAllRequests(t *testing.T) {
+	svc := newTestService(t)
+
+	resp, err := svc.GetAllRequests()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Requests) != 2 || resp.Requests[0].User.ID != carol || resp.Requests[1].User.ID != bob {
+		t.Fatalf("requests = %+v, want carol's then bob's", resp.Requests)
+	}
+}
+
+func TestApproveRequest(t *testing.T) {
+	svc := newTestService(t)
+
+	if err := svc.ApproveRequest(&ApproveRequestInput{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	role, err := svc.userRoles.Get(domain.GetUserRoleInput{UserID: bob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role != dto.RoleModerator {
+		t.Fatalf("role = %q, want %q", role, dto.RoleModerator)
+	}
+
+	if err := svc.ApproveRequest(&ApproveRequestInput{ID: 1}); !errors.Is(err, domain.ErrRequestNotFound) {
+		t.Fatalf("approve again: err = %v, want %v", err, domain.ErrRequestNotFound)
+	}
+	if err := svc.CreateRequest(&CreateRequestInput{UserID: bob}); !errors.Is(err, domain.ErrModerationBadRequest) {
+		t.Fatalf("request again: err = %v, want %v", err, domain.ErrModerationBadRequest)
+	}
+}
+
+func TestDeclineRequest(t *testing.T) {
+	svc := newTestService(t)
+
+	if err := svc.DeclineRequest(&DeclineRequestInput{ID: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.userRoles.Get(domain.GetUserRoleInput{UserID: carol}); !errors.Is(err, domain.ErrUserRoleNotFound) {
+		t.Fatalf("role: err = %v, want %v", err, domain.ErrUserRoleNotFound)
+	}
+
+	if err := svc.DeclineRequest(&DeclineRequestInput{ID: 2}); !errors.Is(err, domain.ErrRequestNotFound) {
+		t.Fatalf("decline again: err = %v, want %v", err, domain.ErrRequestNotFound)
+	}
+	if err := svc.CreateRequest(&CreateRequestInput{UserID: carol}); err != nil {
+		t.Fatalf("request again: %v", err)
+	}
+}
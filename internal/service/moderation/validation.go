@@ -0,0 +1,13 @@
+package moderation
+
+type CreateRequestInput struct {
+	UserID int
+}
+
+type ApproveRequestInput struct {
+	ID int
+}
+
+type DeclineRequestInput struct {
+	ID int
+}
@@ -2,13 +2,63 @@ package notifications
 
 import (
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/notifications/domain"
+	"github.com/itelman/forum/pkg/pagination"
 	"net/http"
+	"strconv"
 )
 
-func DecodeGetAllCommentNotifications(r *http.Request) interface{} {
-	return &GetAllCommentNotificationsInput{dto.GetAuthUser(r).ID}
+func DecodeGetAllNotifications(r *http.Request) (interface{}, error) {
+	return decodeGetAll(r, nil)
 }
 
-func DecodeGetAllPostReactionNotifications(r *http.Request) interface{} {
-	return &GetAllPostReactionNotificationsInput{dto.GetAuthUser(r).ID}
+func DecodeGetAllCommentNotifications(r *http.Request) (interface{}, error) {
+	return decodeGetAll(r, commentTypes)
+}
+
+func DecodeGetAllReactionNotifications(r *http.Request) (interface{}, error) {
+	return decodeGetAll(r, reactionTypes)
+}
+
+func decodeGetAll(r *http.Request, types []string) (interface{}, error) {
+	cursor, err := pagination.ParseCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		return nil, domain.ErrNotificationsBadRequest
+	}
+
+	return &GetAllNotificationsInput{dto.GetAuthUser(r).ID, types, cursor}, nil
+}
+
+func DecodeNotification(r *http.Request) (interface{}, error) {
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		return nil, domain.ErrNotificationsBadRequest
+	}
+
+	return &NotificationInput{id, dto.GetAuthUser(r).ID}, nil
+}
+
+func DecodeCountUnreadNotifications(r *http.Request) interface{} {
+	return &CountUnreadNotificationsInput{dto.GetAuthUser(r).ID}
+}
+
+func DecodeReadAllNotifications(r *http.Request) interface{} {
+	return &ReadAllNotificationsInput{dto.GetAuthUser(r).ID}
+}
+
+func DecodeGetNotificationPreferences(r *http.Request) interface{} {
+	return &GetNotificationPreferencesInput{dto.GetAuthUser(r).ID}
+}
+
+func DecodeUpdateNotificationPreferences(r *http.Request) (interface{}, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, domain.ErrNotificationsBadRequest
+	}
+
+	return &UpdateNotificationPreferencesInput{
+		AuthUserID: dto.GetAuthUser(r).ID,
+		InApp:      r.PostForm["in_app"],
+		Live:       r.PostForm["live"],
+		Email:      r.PostForm["email"],
+	}, nil
 }
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type NotificationPreferencesRepository interface {
+	GetAll(input GetAllNotificationPreferencesInput) ([]*dto.NotificationPreference, error)
+	Upsert(tx *sql.Tx, input UpsertNotificationPreferenceInput) error
+}
+
+type (
+	GetAllNotificationPreferencesInput = repository.GetAllNotificationPreferencesInput
+	UpsertNotificationPreferenceInput  = repository.UpsertNotificationPreferenceInput
+)
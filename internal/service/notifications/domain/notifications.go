@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type NotificationsRepository interface {
+	Get(input GetNotificationInput) (*dto.Notification, error)
+	GetAll(input GetAllNotificationsInput) ([]*dto.Notification, error)
+	CountUnread(input CountUnreadNotificationsInput) (int, error)
+	MarkRead(input MarkNotificationReadInput) error
+	MarkAllRead(input MarkAllNotificationsReadInput) error
+	Delete(input DeleteNotificationInput) error
+}
+
+type (
+	GetNotificationInput          = repository.GetNotificationInput
+	GetAllNotificationsInput      = repository.GetAllNotificationsInput
+	CountUnreadNotificationsInput = repository.CountUnreadNotificationsInput
+	MarkNotificationReadInput     = repository.MarkNotificationReadInput
+	MarkAllNotificationsReadInput = repository.MarkAllNotificationsReadInput
+	DeleteNotificationInput       = repository.DeleteNotificationInput
+)
+
+var (
+	ErrNotificationsBadRequest = errors.New("NOTIFICATIONS: bad request")
+	ErrNotificationNotFound    = repository.ErrNotificationNotFound
+)
@@ -3,22 +3,32 @@ package notifications
 import (
 	"database/sql"
 	"github.com/itelman/forum/internal/dto"
-	"github.com/itelman/forum/internal/service/notifications/adapters"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
 	"github.com/itelman/forum/internal/service/notifications/domain"
+	"github.com/itelman/forum/pkg/events"
+	"github.com/itelman/forum/pkg/pagination"
 )
 
 type Service interface {
-	GetAllCommentNotifications(input *GetAllCommentNotificationsInput) (*GetAllCommentNotificationsResponse, error)
-	GetAllPostReactionNotifications(input *GetAllPostReactionNotificationsInput) (*GetAllPostReactionNotificationsResponse, error)
+	GetAllNotifications(input *GetAllNotificationsInput) (*GetAllNotificationsResponse, error)
+	CountUnreadNotifications(input *CountUnreadNotificationsInput) (*CountUnreadNotificationsResponse, error)
+	ReadNotification(input *NotificationInput) (*ReadNotificationResponse, error)
+	ReadAllNotifications(input *ReadAllNotificationsInput) error
+	DeleteNotification(input *NotificationInput) error
+	GetNotificationPreferences(input *GetNotificationPreferencesInput) (*GetNotificationPreferencesResponse, error)
+	UpdateNotificationPreferences(input *UpdateNotificationPreferencesInput) error
 }
 
 type service struct {
-	comments      domain.CommentsRepository
-	postReactions domain.PostReactionsRepository
+	notifications domain.NotificationsRepository
+	preferences   domain.NotificationPreferencesRepository
+	events        events.Publisher
+	db            *sql.DB
 }
 
 func NewService(opts ...Option) *service {
-	svc := &service{}
+	svc := &service{events: events.Discard}
 	for _, opt := range opts {
 		opt(svc)
 	}
@@ -30,39 +40,245 @@ type Option func(*service)
 
 func WithSqlite(db *sql.DB) Option {
 	return func(s *service) {
-		s.comments = adapters.NewCommentsRepositorySqlite(db)
-		s.postReactions = adapters.NewPostReactionsRepositorySqlite(db)
+		s.notifications = repository.NewNotificationsRepositorySqlite(db)
+		s.preferences = repository.NewNotificationPreferencesRepositorySqlite(db)
+		s.db = db
 	}
 }
 
-type GetAllCommentNotificationsResponse struct {
-	Comments []*dto.Comment
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.notifications = repository.NewNotificationsRepositoryPostgres(db)
+		s.preferences = repository.NewNotificationPreferencesRepositoryPostgres(db)
+		s.db = db
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.notifications = memory.NewNotificationsRepository(store)
+		s.preferences = memory.NewNotificationPreferencesRepository(store)
+		s.db = store.DB()
+	}
+}
+
+func WithEvents(publisher events.Publisher) Option {
+	return func(s *service) {
+		s.events = publisher
+	}
 }
 
-func (s *service) GetAllCommentNotifications(input *GetAllCommentNotificationsInput) (*GetAllCommentNotificationsResponse, error) {
-	comments, err := s.comments.GetAllNotifications(domain.GetAllCommentNotificationsInput{
-		AuthUserID:     input.AuthUserID,
+type GetAllNotificationsResponse struct {
+	Notifications []*dto.Notification
+	NextCursor    string
+}
+
+func (s *service) GetAllNotifications(input *GetAllNotificationsInput) (*GetAllNotificationsResponse, error) {
+	muted, err := s.mutedInAppTypes(input.AuthUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := s.notifications.GetAll(domain.GetAllNotificationsInput{
+		UserID:         input.AuthUserID,
+		Types:          input.Types,
+		ExcludeTypes:   muted,
 		SortedByNewest: true,
+		Cursor:         input.Cursor,
+		Limit:          pagination.DefaultLimit + 1,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &GetAllCommentNotificationsResponse{comments}, nil
+	notifications, nextCursor := pagination.Paginate(notifications, pagination.DefaultLimit, func(notification *dto.Notification) *pagination.Cursor {
+		return &pagination.Cursor{Created: notification.Created, ID: notification.ID}
+	})
+
+	return &GetAllNotificationsResponse{notifications, nextCursor}, nil
 }
 
-type GetAllPostReactionNotificationsResponse struct {
-	PostReactions []*dto.PostReaction
+type CountUnreadNotificationsResponse struct {
+	Count int
 }
 
-func (s *service) GetAllPostReactionNotifications(input *GetAllPostReactionNotificationsInput) (*GetAllPostReactionNotificationsResponse, error) {
-	reactions, err := s.postReactions.GetAllNotifications(domain.GetAllPostReactionNotificationsInput{
-		AuthUserID:     input.AuthUserID,
-		SortedByNewest: true,
+func (s *service) CountUnreadNotifications(input *CountUnreadNotificationsInput) (*CountUnreadNotificationsResponse, error) {
+	count, err := s.countUnread(input.AuthUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CountUnreadNotificationsResponse{count}, nil
+}
+
+func (s *service) countUnread(userId int) (int, error) {
+	muted, err := s.mutedInAppTypes(userId)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.notifications.CountUnread(domain.CountUnreadNotificationsInput{
+		UserID:       userId,
+		ExcludeTypes: muted,
+	})
+}
+
+type ReadNotificationResponse struct {
+	Notification *dto.Notification
+}
+
+func (s *service) ReadNotification(input *NotificationInput) (*ReadNotificationResponse, error) {
+	notification, err := s.notifications.Get(domain.GetNotificationInput{
+		ID:     input.ID,
+		UserID: input.AuthUserID,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &GetAllPostReactionNotificationsResponse{reactions}, nil
+	if err := s.notifications.MarkRead(domain.MarkNotificationReadInput{
+		ID:     input.ID,
+		UserID: input.AuthUserID,
+	}); err != nil {
+		return nil, err
+	}
+	notification.Read = true
+	s.publishUnread(input.AuthUserID)
+
+	return &ReadNotificationResponse{notification}, nil
+}
+
+func (s *service) ReadAllNotifications(input *ReadAllNotificationsInput) error {
+	if err := s.notifications.MarkAllRead(domain.MarkAllNotificationsReadInput{UserID: input.AuthUserID}); err != nil {
+		return err
+	}
+	s.publishUnread(input.AuthUserID)
+
+	return nil
+}
+
+func (s *service) DeleteNotification(input *NotificationInput) error {
+	if err := s.notifications.Delete(domain.DeleteNotificationInput{
+		ID:     input.ID,
+		UserID: input.AuthUserID,
+	}); err != nil {
+		return err
+	}
+	s.publishUnread(input.AuthUserID)
+
+	return nil
+}
+
+// publishUnread keeps the unread badge of the user's other open pages in sync.
+func (s *service) publishUnread(userId int) {
+	count, err := s.countUnread(userId)
+	if err != nil {
+		return
+	}
+
+	s.events.Publish(events.UserTopic(userId), events.Event{Name: events.Unread, Data: map[string]int{"count": count}})
+}
+
+type GetNotificationPreferencesResponse struct {
+	Preferences []*dto.NotificationPreference
+}
+
+func (s *service) GetNotificationPreferences(input *GetNotificationPreferencesInput) (*GetNotificationPreferencesResponse, error) {
+	preferences, err := s.getPreferences(input.AuthUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetNotificationPreferencesResponse{preferences}, nil
+}
+
+func (s *service) UpdateNotificationPreferences(input *UpdateNotificationPreferencesInput) error {
+	if err := input.validate(); err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, event := range dto.PreferenceEvents {
+		if err := s.preferences.Upsert(tx, domain.UpsertNotificationPreferenceInput{
+			UserID: input.AuthUserID,
+			Preference: &dto.NotificationPreference{
+				Event: event,
+				InApp: contains(input.InApp, event),
+				Live:  contains(input.Live, event),
+				Email: contains(input.Email, event),
+			},
+		}); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.publishUnread(input.AuthUserID)
+
+	return nil
+}
+
+// getPreferences returns a preference for every event. Events the user never
+// configured are enabled on all channels.
+func (s *service) getPreferences(userId int) ([]*dto.NotificationPreference, error) {
+	stored, err := s.preferences.GetAll(domain.GetAllNotificationPreferencesInput{UserID: userId})
+	if err != nil {
+		return nil, err
+	}
+
+	byEvent := make(map[string]*dto.NotificationPreference, len(stored))
+	for _, preference := range stored {
+		byEvent[preference.Event] = preference
+	}
+
+	preferences := make([]*dto.NotificationPreference, 0, len(dto.PreferenceEvents))
+	for _, event := range dto.PreferenceEvents {
+		preference, ok := byEvent[event]
+		if !ok {
+			preference = &dto.NotificationPreference{Event: event, InApp: true, Live: true, Email: true}
+		}
+
+		preferences = append(preferences, preference)
+	}
+
+	return preferences, nil
+}
+
+func (s *service) mutedInAppTypes(userId int) ([]string, error) {
+	preferences, err := s.preferences.GetAll(domain.GetAllNotificationPreferencesInput{UserID: userId})
+	if err != nil {
+		return nil, err
+	}
+
+	muted := []string{}
+	for _, preference := range preferences {
+		if !preference.InApp {
+			muted = append(muted, dto.PreferenceTypes(preference.Event)...)
+		}
+	}
+
+	return muted, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,341 @@
+package notifications
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	"github.com/itelman/forum/internal/service/notifications/domain"
+	"github.com/itelman/forum/pkg/events"
+	"github.com/itelman/forum/pkg/pagination"
+)
+
+const (
+	alice = 1
+	bob   = 2
+)
+
+// newTestService seeds a post by alice and one notification of each given
+// type for alice, from bob, plus a comment notification for bob.
+func newTestService(t *testing.T, types []string, opts ...Option) (*service, *memory.Store) {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice", "bob")
+
+	memorytest.Post(t, store, alice, "First post")
+
+	notifications := memory.NewNotificationsRepository(store)
+	for _, notificationType := range types {
+		if _, err := notifications.Create(nil, repository.CreateNotificationInput{UserID: alice, ActorID: bob, Type: notificationType, PostID: 1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := notifications.Create(nil, repository.CreateNotificationInput{UserID: bob, ActorID: alice, Type: dto.NotificationComment, PostID: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewService(append([]Option{WithMemoryStore(store)}, opts...)...), store
+}
+
+var allTypes = []string{dto.NotificationComment, dto.NotificationReply, dto.NotificationPostLike, dto.NotificationMention}
+
+// unreadCounts returns the counts sent to alice in unread events.
+func unreadCounts(rec *memorytest.Recorder) []int {
+	counts := []int{}
+	for i, event := range rec.Events {
+		if rec.Topics[i] == events.UserTopic(alice)+":"+events.Unread {
+			counts = append(counts, event.Data.(map[string]int)["count"])
+		}
+	}
+
+	return counts
+}
+
+func notificationTypes(notifications []*dto.Notification) string {
+	types := make([]string, len(notifications))
+	for i, notification := range notifications {
+		types[i] = notification.Type
+	}
+
+	return strings.Join(types, ",")
+}
+
+func TestGetAllNotifications(t *testing.T) {
+	tests := []struct {
+		name  string
+		input GetAllNotificationsInput
+		muted []string
+		want  string
+	}{
+		{"all", GetAllNotificationsInput{AuthUserID: alice}, nil, "mention,post_like,reply,comment"},
+		{"comments", GetAllNotificationsInput{AuthUserID: alice, Types: commentTypes}, nil, "mention,reply,comment"},
+		{"reactions", GetAllNotificationsInput{AuthUserID: alice, Types: reactionTypes}, nil, "post_like"},
+		{"muted mentions", GetAllNotificationsInput{AuthUserID: alice}, []string{dto.PreferenceMentions}, "post_like,reply,comment"},
+		{"muted comments and replies", GetAllNotificationsInput{AuthUserID: alice, Types: commentTypes}, []string{dto.PreferenceComments, dto.PreferenceReplies}, "mention"},
+		{"other user", GetAllNotificationsInput{AuthUserID: bob}, nil, "comment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t, allTypes)
+
+			inApp := []string{}
+			for _, event := range dto.PreferenceEvents {
+				if !contains(tt.muted, event) {
+					inApp = append(inApp, event)
+				}
+			}
+			if err := svc.UpdateNotificationPreferences(&UpdateNotificationPreferencesInput{AuthUserID: tt.input.AuthUserID, InApp: inApp}); err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := svc.GetAllNotifications(&tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := notificationTypes(resp.Notifications); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if resp.NextCursor != "" {
+				t.Errorf("got cursor %q on the only page", resp.NextCursor)
+			}
+		})
+	}
+}
+
+func TestGetAllNotificationsCursor(t *testing.T) {
+	types := make([]string, pagination.DefaultLimit+3)
+	for i := range types {
+		types[i] = dto.NotificationPostLike
+	}
+	svc, _ := newTestService(t, types)
+
+	first, err := svc.GetAllNotifications(&GetAllNotificationsInput{AuthUserID: alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cursor, err := pagination.ParseCursor(first.NextCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := svc.GetAllNotifications(&GetAllNotificationsInput{AuthUserID: alice, Cursor: cursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(first.Notifications) != pagination.DefaultLimit || len(second.Notifications) != 3 || second.NextCursor != "" {
+		t.Errorf("got pages of %d and %d, cursor %q", len(first.Notifications), len(second.Notifications), second.NextCursor)
+	}
+}
+
+func TestCountUnreadNotifications(t *testing.T) {
+	tests := []struct {
+		name  string
+		read  []int
+		muted bool
+		want  int
+	}{
+		{"none read", nil, false, 4},
+		{"some read", []int{1, 3}, false, 2},
+		{"reactions muted", nil, true, 3},
+		{"read and muted", []int{1}, true, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t, allTypes)
+
+			for _, id := range tt.read {
+				if _, err := svc.ReadNotification(&NotificationInput{ID: id, AuthUserID: alice}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if tt.muted {
+				if err := svc.UpdateNotificationPreferences(&UpdateNotificationPreferencesInput{
+					AuthUserID: alice,
+					InApp:      []string{dto.PreferenceComments, dto.PreferenceReplies, dto.PreferenceCommentReactions, dto.PreferenceMentions},
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			resp, err := svc.CountUnreadNotifications(&CountUnreadNotificationsInput{AuthUserID: alice})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.Count != tt.want {
+				t.Errorf("got %d unread, want %d", resp.Count, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadNotification(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      NotificationInput
+		wantErr    error
+		wantUnread int
+	}{
+		{"own", NotificationInput{ID: 2, AuthUserID: alice}, nil, 3},
+		{"other user's", NotificationInput{ID: 5, AuthUserID: alice}, domain.ErrNotificationNotFound, 4},
+		{"missing", NotificationInput{ID: 9, AuthUserID: alice}, domain.ErrNotificationNotFound, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &memorytest.Recorder{}
+			svc, _ := newTestService(t, allTypes, WithEvents(rec))
+
+			resp, err := svc.ReadNotification(&tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if err == nil && (!resp.Notification.Read || resp.Notification.Type != dto.NotificationReply || resp.Notification.Actor.Username != "bob") {
+				t.Errorf("got %+v, want bob's read reply", resp.Notification)
+			}
+
+			count, err := svc.CountUnreadNotifications(&CountUnreadNotificationsInput{AuthUserID: alice})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count.Count != tt.wantUnread {
+				t.Errorf("got %d unread, want %d", count.Count, tt.wantUnread)
+			}
+
+			if unread := unreadCounts(rec); tt.wantErr == nil && (len(unread) != 1 || unread[0] != tt.wantUnread) {
+				t.Errorf("got unread events %v, want [%d]", unread, tt.wantUnread)
+			}
+		})
+	}
+}
+
+func TestReadAllNotifications(t *testing.T) {
+	rec := &memorytest.Recorder{}
+	svc, _ := newTestService(t, allTypes, WithEvents(rec))
+
+	if err := svc.ReadAllNotifications(&ReadAllNotificationsInput{AuthUserID: alice}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, userId := range []int{alice, bob} {
+		resp, err := svc.CountUnreadNotifications(&CountUnreadNotificationsInput{AuthUserID: userId})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := map[int]int{alice: 0, bob: 1}[userId]; resp.Count != want {
+			t.Errorf("user %d: got %d unread, want %d", userId, resp.Count, want)
+		}
+	}
+
+	if unread := unreadCounts(rec); len(unread) != 1 || unread[0] != 0 {
+		t.Errorf("got unread events %v, want [0]", unread)
+	}
+}
+
+func TestDeleteNotification(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   NotificationInput
+		wantErr error
+		want    string
+	}{
+		{"own", NotificationInput{ID: 1, AuthUserID: alice}, nil, "mention,post_like,reply"},
+		{"other user's", NotificationInput{ID: 5, AuthUserID: alice}, domain.ErrNotificationNotFound, "mention,post_like,reply,comment"},
+		{"missing", NotificationInput{ID: 9, AuthUserID: alice}, domain.ErrNotificationNotFound, "mention,post_like,reply,comment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t, allTypes)
+
+			if err := svc.DeleteNotification(&tt.input); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			resp, err := svc.GetAllNotifications(&GetAllNotificationsInput{AuthUserID: alice})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := notificationTypes(resp.Notifications); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   UpdateNotificationPreferencesInput
+		wantErr error
+		want    map[string][3]bool
+	}{
+		{"defaults", UpdateNotificationPreferencesInput{}, nil, nil},
+		{"all off", UpdateNotificationPreferencesInput{AuthUserID: alice}, nil, map[string][3]bool{
+			dto.PreferenceComments: {}, dto.PreferenceReplies: {}, dto.PreferencePostReactions: {}, dto.PreferenceCommentReactions: {}, dto.PreferenceMentions: {},
+		}},
+		{"per channel", UpdateNotificationPreferencesInput{
+			AuthUserID: alice,
+			InApp:      []string{dto.PreferenceComments, dto.PreferenceMentions},
+			Live:       []string{dto.PreferenceMentions},
+			Email:      []string{dto.PreferenceReplies},
+		}, nil, map[string][3]bool{
+			dto.PreferenceComments:         {true, false, false},
+			dto.PreferenceReplies:          {false, false, true},
+			dto.PreferencePostReactions:    {},
+			dto.PreferenceCommentReactions: {},
+			dto.PreferenceMentions:         {true, true, false},
+		}},
+		{"unknown event", UpdateNotificationPreferencesInput{AuthUserID: alice, Live: []string{"likes"}}, domain.ErrNotificationsBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t, nil)
+
+			if tt.input.AuthUserID != 0 {
+				if err := svc.UpdateNotificationPreferences(&tt.input); !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+			}
+
+			resp, err := svc.GetNotificationPreferences(&GetNotificationPreferencesInput{AuthUserID: alice})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(resp.Preferences) != len(dto.PreferenceEvents) {
+				t.Fatalf("got %d preferences, want %d", len(resp.Preferences), len(dto.PreferenceEvents))
+			}
+
+			for i, preference := range resp.Preferences {
+				if preference.Event != dto.PreferenceEvents[i] {
+					t.Errorf("got event %q at %d, want %q", preference.Event, i, dto.PreferenceEvents[i])
+				}
+
+				want, ok := tt.want[preference.Event]
+				if !ok {
+					want = [3]bool{true, true, true}
+				}
+
+				if got := [3]bool{preference.InApp, preference.Live, preference.Email}; got != want {
+					t.Errorf("%s: got in-app, live, email %v, want %v", preference.Event, got, want)
+				}
+			}
+		})
+	}
+}
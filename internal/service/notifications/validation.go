@@ -1,9 +1,56 @@
 package notifications
 
-type GetAllCommentNotificationsInput struct {
+import (
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/notifications/domain"
+	"github.com/itelman/forum/pkg/pagination"
+)
+
+var (
+	commentTypes  = []string{dto.NotificationComment, dto.NotificationReply, dto.NotificationMention}
+	reactionTypes = []string{dto.NotificationPostLike, dto.NotificationPostDislike, dto.NotificationCommentLike, dto.NotificationCommentDislike}
+)
+
+type GetAllNotificationsInput struct {
+	AuthUserID int
+	Types      []string
+	Cursor     *pagination.Cursor
+}
+
+type CountUnreadNotificationsInput struct {
 	AuthUserID int
 }
 
-type GetAllPostReactionNotificationsInput struct {
+type NotificationInput struct {
+	ID         int
 	AuthUserID int
 }
+
+type ReadAllNotificationsInput struct {
+	AuthUserID int
+}
+
+type GetNotificationPreferencesInput struct {
+	AuthUserID int
+}
+
+// UpdateNotificationPreferencesInput lists, per channel, the events that stay
+// enabled. Events missing from a list are turned off for that channel.
+type UpdateNotificationPreferencesInput struct {
+	AuthUserID int
+	InApp      []string
+	Live       []string
+	Email      []string
+}
+
+func (i *UpdateNotificationPreferencesInput) validate() error {
+	for _, events := range [][]string{i.InApp, i.Live, i.Email} {
+		for _, event := range events {
+			if !contains(dto.PreferenceEvents, event) {
+				return domain.ErrNotificationsBadRequest
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package adapters
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/itelman/forum/internal/service/oauth/domain"
+)
+
+type UsersOAuthRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewUsersOAuthRepositoryPostgres(db *sql.DB) *UsersOAuthRepositoryPostgres {
+	return &UsersOAuthRepositoryPostgres{db}
+}
+
+func (r *UsersOAuthRepositoryPostgres) GetUserID(input domain.GetUserOAuthInput) (int, error) {
+	query := "SELECT user_id FROM users_oauth WHERE oauth_type_id = $1 AND account_id = $2"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return -1, err
+	}
+	defer stmt.Close()
+
+	var userID int
+	if err := stmt.QueryRow(input.AuthTypeID, input.AccountID).Scan(&userID); errors.Is(err, sql.ErrNoRows) {
+		return -1, domain.ErrOAuthUserNotFound
+	} else if err != nil {
+		return -1, err
+	}
+
+	return userID, nil
+}
+
+func (r *UsersOAuthRepositoryPostgres) Create(input domain.CreateUserOAuthInput) error {
+	query := "INSERT INTO users_oauth (user_id, oauth_type_id, account_id) VALUES ($1, $2, $3)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.UserID, input.AuthTypeID, input.AccountID); err != nil {
+		return err
+	}
+
+	return nil
+}
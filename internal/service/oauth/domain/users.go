@@ -2,13 +2,15 @@ package domain
 
 import (
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
 )
 
 type UsersRepository interface {
 	Get(input GetUserInput) (*dto.User, error)
 }
 
-type GetUserInput struct {
-	Key   string
-	Value interface{}
-}
+type GetUserInput = repository.GetUserInput
+
+var (
+	ErrUserNotFound = repository.ErrUserNotFound
+)
@@ -3,6 +3,8 @@ package oauth
 import (
 	"database/sql"
 	"errors"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
 	"github.com/itelman/forum/internal/service/oauth/adapters"
 	"github.com/itelman/forum/internal/service/oauth/domain"
 	"strings"
@@ -30,11 +32,31 @@ type Option func(*service)
 
 func WithSqlite(db *sql.DB) Option {
 	return func(s *service) {
-		s.users = adapters.NewUsersRepositorySqlite(db)
+		s.users = repository.NewUsersRepositorySqlite(db)
 		s.usersOAuth = adapters.NewUsersOAuthRepositorySqlite(db)
 	}
 }
 
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.users = repository.NewUsersRepositoryPostgres(db)
+		s.usersOAuth = adapters.NewUsersOAuthRepositoryPostgres(db)
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.users = memory.NewUsersRepository(store)
+		s.usersOAuth = memory.NewUsersOAuthRepository(store)
+	}
+}
+
 type GetAuthCodeInput struct {
 	Code string
 }
@@ -82,7 +104,9 @@ func (s *service) LoginUser(input *LoginUserInput) (*LoginUserResponse, error) {
 		Key:   "email",
 		Value: input.Email,
 	})
-	if err != nil {
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, domain.ErrOAuthUserNotFound
+	} else if err != nil {
 		return nil, err
 	}
 
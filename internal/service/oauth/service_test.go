@@ -0,0 +1,84 @@
+package oauth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	"github.com/itelman/forum/internal/service/oauth/domain"
+)
+
+const (
+	alice = 1
+	bob   = 2
+)
+
+// newTestService seeds alice, whose GitHub account "gh-alice" is linked,
+// and bob, who has no linked accounts.
+func newTestService(t *testing.T) *service {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice", "bob")
+
+	svc := NewService(WithMemoryStore(store))
+	if err := svc.usersOAuth.Create(domain.CreateUserOAuthInput{UserID: alice, AuthTypeID: 1, AccountID: "gh-alice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	return svc
+}
+
+func TestLoginUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      LoginUserInput
+		wantErr    error
+		wantUserID int
+	}{
+		{"linked account", LoginUserInput{AccountID: "gh-alice", Email: "changed@example.com", Provider: "github"}, nil, alice},
+		{"provider is case insensitive", LoginUserInput{AccountID: "gh-alice", Provider: "GitHub"}, nil, alice},
+		{"links by email", LoginUserInput{AccountID: "g-bob", Email: "bob@example.com", Provider: "google"}, nil, bob},
+		{"same account id on another provider", LoginUserInput{AccountID: "gh-alice", Email: "bob@example.com", Provider: "google"}, nil, bob},
+		{"unknown email", LoginUserInput{AccountID: "g-dave", Email: "dave@example.com", Provider: "google"}, domain.ErrOAuthUserNotFound, 0},
+		{"unknown provider", LoginUserInput{AccountID: "gh-alice", Email: "alice@example.com", Provider: "gitlab"}, domain.ErrOAuthFailed, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(t)
+
+			resp, err := svc.LoginUser(&tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if resp.UserID != tt.wantUserID {
+				t.Fatalf("user = %d, want %d", resp.UserID, tt.wantUserID)
+			}
+		})
+	}
+}
+
+func TestLoginUserLinksAccount(t *testing.T) {
+	svc := newTestService(t)
+
+	input := LoginUserInput{AccountID: "g-bob", Email: "bob@example.com", Provider: "google"}
+	if _, err := svc.LoginUser(&input); err != nil {
+		t.Fatal(err)
+	}
+
+	// Once linked, the account no longer depends on the email matching.
+	input.Email = "bobby@example.com"
+	resp, err := svc.LoginUser(&input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.UserID != bob {
+		t.Fatalf("user = %d, want %d", resp.UserID, bob)
+	}
+}
@@ -27,8 +27,10 @@ func DecodeCreatePostReaction(r *http.Request) (interface{}, error) {
 	}
 
 	return &CreatePostReactionInput{
-		PostID: postId,
-		UserID: dto.GetAuthUser(r).ID,
-		IsLike: isLike,
+		PostID:   postId,
+		UserID:   dto.GetAuthUser(r).ID,
+		UserRole: dto.GetUserRole(r),
+		Username: dto.GetAuthUser(r).Username,
+		IsLike:   isLike,
 	}, nil
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
 )
 
 type PostsRepository interface {
@@ -12,15 +13,12 @@ type PostsRepository interface {
 	UpdateReactionsCount(tx *sql.Tx, input UpdatePostReactionsCountInput) error
 }
 
-type UpdatePostReactionsCountInput struct {
-	PostID int
-}
-
-type GetPostInput struct {
-	ID int
-}
+type (
+	GetPostInput                  = repository.GetPostInput
+	UpdatePostReactionsCountInput = repository.UpdatePostReactionsCountInput
+)
 
 var (
 	ErrPostsBadRequest = errors.New("POSTS: bad request")
-	ErrPostNotFound    = errors.New("DATABASE: Post not found")
+	ErrPostNotFound    = repository.ErrPostNotFound
 )
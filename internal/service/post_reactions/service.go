@@ -3,9 +3,13 @@ package post_reactions
 import (
 	"database/sql"
 	"errors"
+	"time"
 
-	"github.com/itelman/forum/internal/service/post_reactions/adapters"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
 	"github.com/itelman/forum/internal/service/post_reactions/domain"
+	"github.com/itelman/forum/pkg/events"
 )
 
 type Service interface {
@@ -15,11 +19,13 @@ type Service interface {
 type service struct {
 	postReactions domain.PostReactionsRepository
 	posts         domain.PostsRepository
+	notifications domain.NotificationsRepository
+	events        events.Publisher
 	db            *sql.DB
 }
 
 func NewService(opts ...Option) *service {
-	svc := &service{}
+	svc := &service{events: events.Discard}
 	for _, opt := range opts {
 		opt(svc)
 	}
@@ -31,21 +37,58 @@ type Option func(*service)
 
 func WithSqlite(db *sql.DB) Option {
 	return func(s *service) {
-		s.postReactions = adapters.NewPostReactionsRepositorySqlite(db)
-		s.posts = adapters.NewPostsRepositorySqlite(db)
+		s.postReactions = repository.NewPostReactionsRepositorySqlite(db)
+		s.posts = repository.NewPostsRepositorySqlite(db)
+		s.notifications = repository.NewNotificationsRepositorySqlite(db)
 		s.db = db
 	}
 }
 
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.postReactions = repository.NewPostReactionsRepositoryPostgres(db)
+		s.posts = repository.NewPostsRepositoryPostgres(db)
+		s.notifications = repository.NewNotificationsRepositoryPostgres(db)
+		s.db = db
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.postReactions = memory.NewPostReactionsRepository(store)
+		s.posts = memory.NewPostsRepository(store)
+		s.notifications = memory.NewNotificationsRepository(store)
+		s.db = store.DB()
+	}
+}
+
+func WithEvents(publisher events.Publisher) Option {
+	return func(s *service) {
+		s.events = publisher
+	}
+}
+
 func (s *service) CreatePostReaction(input *CreatePostReactionInput) error {
 	makeInsertion := true
+	var notification *dto.Notification
 
-	if _, err := s.posts.Get(domain.GetPostInput{ID: input.PostID}); errors.Is(err, domain.ErrPostNotFound) {
+	post, err := s.posts.Get(domain.GetPostInput{ID: input.PostID})
+	if errors.Is(err, domain.ErrPostNotFound) {
 		return domain.ErrPostReactionsBadRequest
 	} else if err != nil {
 		return err
 	}
 
+	if !post.VisibleTo(input.UserID, input.UserRole) {
+		return domain.ErrPostNotFound
+	}
+
 	reaction, err := s.postReactions.Get(domain.GetPostReactionInput{
 		PostID: input.PostID,
 		UserID: input.UserID,
@@ -73,14 +116,42 @@ func (s *service) CreatePostReaction(input *CreatePostReactionInput) error {
 	}
 
 	if makeInsertion {
-		if err := s.postReactions.Insert(tx, domain.CreatePostReactionInput{
+		reactionId, err := s.postReactions.Insert(tx, domain.CreatePostReactionInput{
 			PostID: input.PostID,
 			UserID: input.UserID,
 			IsLike: input.IsLike,
-		}); err != nil {
+		})
+		if err != nil {
 			tx.Rollback()
 			return err
 		}
+
+		if post.User.ID != input.UserID {
+			notificationType := dto.NotificationPostDislike
+			if input.IsLike == 1 {
+				notificationType = dto.NotificationPostLike
+			}
+
+			notificationId, err := s.notifications.Create(tx, domain.CreateNotificationInput{
+				UserID:         post.User.ID,
+				ActorID:        input.UserID,
+				Type:           notificationType,
+				PostID:         input.PostID,
+				PostReactionID: reactionId,
+			})
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+
+			notification = &dto.Notification{
+				ID:      notificationId,
+				Type:    notificationType,
+				Actor:   &dto.User{ID: input.UserID, Username: input.Username},
+				PostID:  input.PostID,
+				Created: time.Now(),
+			}
+		}
 	}
 
 	if err := s.posts.UpdateReactionsCount(tx, domain.UpdatePostReactionsCountInput{PostID: input.PostID}); err != nil {
@@ -92,5 +163,9 @@ func (s *service) CreatePostReaction(input *CreatePostReactionInput) error {
 		return err
 	}
 
+	if notification != nil {
+		s.events.Publish(events.UserTopic(post.User.ID), events.Event{Name: events.Notification, Data: notification})
+	}
+
 	return nil
 }
@@ -0,0 +1,139 @@
+package post_reactions
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	"github.com/itelman/forum/internal/service/post_reactions/domain"
+	postsdomain "github.com/itelman/forum/internal/service/posts/domain"
+)
+
+const (
+	alice = 1
+	bob   = 2
+	carol = 3
+)
+
+// newTestService seeds three users and a post by alice.
+func newTestService(t *testing.T, opts ...Option) (*service, *memory.Store) {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice", "bob", "carol")
+
+	memorytest.Post(t, store, alice, "First post")
+
+	return NewService(append([]Option{WithMemoryStore(store)}, opts...)...), store
+}
+
+type reaction struct {
+	userId int
+	isLike int
+}
+
+func TestCreatePostReaction(t *testing.T) {
+	tests := []struct {
+		name         string
+		reactions    []reaction
+		wantLikes    int
+		wantDislikes int
+		wantBob      int
+		wantAlice    string
+		wantEvents   int
+	}{
+		{"like", []reaction{{bob, 1}}, 1, 0, 1, dto.NotificationPostLike, 1},
+		{"dislike", []reaction{{bob, 0}}, 0, 1, 0, dto.NotificationPostDislike, 1},
+		{"undo like", []reaction{{bob, 1}, {bob, 1}}, 0, 0, -1, "", 1},
+		{"like to dislike", []reaction{{bob, 1}, {bob, 0}}, 0, 1, 0, dto.NotificationPostDislike, 2},
+		{"two users", []reaction{{bob, 1}, {carol, 0}}, 1, 1, 1, dto.NotificationPostLike + "," + dto.NotificationPostDislike, 2},
+		{"own post", []reaction{{alice, 1}}, 1, 0, -1, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &memorytest.Recorder{}
+			svc, store := newTestService(t, WithEvents(rec))
+
+			for _, r := range tt.reactions {
+				if err := svc.CreatePostReaction(&CreatePostReactionInput{PostID: 1, UserID: r.userId, IsLike: r.isLike}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			post, err := memory.NewPostsRepository(store).Get(repository.GetPostInput{ID: 1, AuthUserID: bob})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if post.Likes != tt.wantLikes || post.Dislikes != tt.wantDislikes {
+				t.Errorf("got %d likes and %d dislikes, want %d and %d", post.Likes, post.Dislikes, tt.wantLikes, tt.wantDislikes)
+			}
+			if post.AuthUserReaction != tt.wantBob {
+				t.Errorf("got bob's reaction %d, want %d", post.AuthUserReaction, tt.wantBob)
+			}
+
+			notifications, err := memory.NewNotificationsRepository(store).GetAll(repository.GetAllNotificationsInput{UserID: alice})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			types := []string{}
+			for _, notification := range notifications {
+				types = append(types, notification.Type)
+			}
+			if got := strings.Join(types, ","); got != tt.wantAlice {
+				t.Errorf("got notifications %q, want %q", got, tt.wantAlice)
+			}
+
+			if len(rec.Topics) != tt.wantEvents {
+				t.Errorf("got events %v, want %d", rec.Topics, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestCreatePostReactionMissingPost(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	if err := svc.CreatePostReaction(&CreatePostReactionInput{PostID: 2, UserID: bob, IsLike: 1}); !errors.Is(err, domain.ErrPostReactionsBadRequest) {
+		t.Errorf("got error %v, want %v", err, domain.ErrPostReactionsBadRequest)
+	}
+}
+
+func TestCreatePostReactionPendingPost(t *testing.T) {
+	tests := []struct {
+		name    string
+		userId  int
+		role    string
+		wantErr error
+	}{
+		{"author", alice, "", nil},
+		{"moderator", bob, dto.RoleModerator, nil},
+		{"admin", bob, dto.RoleAdmin, nil},
+		{"user", bob, "", domain.ErrPostNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &memorytest.Recorder{}
+			svc, store := newTestService(t, WithEvents(rec))
+			if err := memory.NewPendingPostsRepository(store).Create(nil, postsdomain.CreatePendingPostInput{PostID: 1}); err != nil {
+				t.Fatal(err)
+			}
+
+			err := svc.CreatePostReaction(&CreatePostReactionInput{PostID: 1, UserID: tt.userId, UserRole: tt.role, IsLike: 1})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil && len(rec.Topics) != 0 {
+				t.Errorf("got events %v for a rejected reaction", rec.Topics)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package post_reactions
 
 type CreatePostReactionInput struct {
-	PostID int
-	UserID int
-	IsLike int
+	PostID   int
+	UserID   int
+	UserRole string
+	Username string
+	IsLike   int
 }
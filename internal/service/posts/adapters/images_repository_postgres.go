@@ -0,0 +1,53 @@
+package adapters
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/posts/domain"
+)
+
+type ImagesRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewImagesRepositoryPostgres(db *sql.DB) *ImagesRepositoryPostgres {
+	return &ImagesRepositoryPostgres{db}
+}
+
+func (r *ImagesRepositoryPostgres) Create(tx *sql.Tx, input domain.CreateImageInput) error {
+	query := "INSERT INTO images (post_id, path) VALUES ($1, $2)"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.PostID, input.Path); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ImagesRepositoryPostgres) Get(input domain.GetImageInput) (*dto.Image, error) {
+	query := "SELECT id, path, uploaded FROM images WHERE post_id = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	image := &dto.Image{}
+	if err := stmt.QueryRow(input.PostID).Scan(
+		&image.ID,
+		&image.Path,
+		&image.Uploaded,
+	); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrImageNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return image, nil
+}
@@ -0,0 +1,44 @@
+package adapters
+
+import (
+	"database/sql"
+	"github.com/itelman/forum/internal/service/posts/domain"
+)
+
+type PendingPostsRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewPendingPostsRepositoryPostgres(db *sql.DB) *PendingPostsRepositoryPostgres {
+	return &PendingPostsRepositoryPostgres{db}
+}
+
+func (r *PendingPostsRepositoryPostgres) Create(tx *sql.Tx, input domain.CreatePendingPostInput) error {
+	query := "INSERT INTO pending_posts (post_id) VALUES ($1)"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.PostID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PendingPostsRepositoryPostgres) Delete(tx *sql.Tx, input domain.DeletePendingPostInput) error {
+	query := "DELETE FROM pending_posts WHERE post_id = $1"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.PostID); err != nil {
+		return err
+	}
+
+	return nil
+}
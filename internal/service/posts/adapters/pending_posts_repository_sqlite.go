@@ -0,0 +1,44 @@
+package adapters
+
+import (
+	"database/sql"
+	"github.com/itelman/forum/internal/service/posts/domain"
+)
+
+type PendingPostsRepositorySqlite struct {
+	db *sql.DB
+}
+
+func NewPendingPostsRepositorySqlite(db *sql.DB) *PendingPostsRepositorySqlite {
+	return &PendingPostsRepositorySqlite{db}
+}
+
+func (r *PendingPostsRepositorySqlite) Create(tx *sql.Tx, input domain.CreatePendingPostInput) error {
+	query := "INSERT INTO pending_posts (post_id) VALUES(?)"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.PostID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PendingPostsRepositorySqlite) Delete(tx *sql.Tx, input domain.DeletePendingPostInput) error {
+	query := "DELETE FROM pending_posts WHERE post_id = ?"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.PostID); err != nil {
+		return err
+	}
+
+	return nil
+}
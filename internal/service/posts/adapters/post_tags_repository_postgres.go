@@ -0,0 +1,85 @@
+package adapters
+
+import (
+	"database/sql"
+	"github.com/itelman/forum/internal/service/posts/domain"
+)
+
+type PostTagsRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewPostTagsRepositoryPostgres(db *sql.DB) *PostTagsRepositoryPostgres {
+	return &PostTagsRepositoryPostgres{db}
+}
+
+func (r *PostTagsRepositoryPostgres) Create(tx *sql.Tx, input domain.CreatePostTagsInput) error {
+	tagStmt, err := tx.Prepare("INSERT INTO tags (name) VALUES ($1) ON CONFLICT DO NOTHING")
+	if err != nil {
+		return err
+	}
+	defer tagStmt.Close()
+
+	postTagStmt, err := tx.Prepare("INSERT INTO post_tags (post_id, tag_id) SELECT $1::INTEGER, id FROM tags WHERE name = $2")
+	if err != nil {
+		return err
+	}
+	defer postTagStmt.Close()
+
+	for _, tag := range input.Tags {
+		if _, err := tagStmt.Exec(tag); err != nil {
+			return err
+		}
+
+		if _, err := postTagStmt.Exec(input.PostID, tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *PostTagsRepositoryPostgres) DeleteAllForPost(tx *sql.Tx, input domain.DeletePostTagsInput) error {
+	query := "DELETE FROM post_tags WHERE post_id = $1"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.PostID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostTagsRepositoryPostgres) GetAllForPost(input domain.GetPostTagsInput) ([]string, error) {
+	query := "SELECT tags.name FROM post_tags INNER JOIN tags ON post_tags.tag_id = tags.id WHERE post_tags.post_id = $1 ORDER BY tags.name"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(input.PostID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []string{}
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
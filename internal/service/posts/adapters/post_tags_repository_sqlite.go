@@ -0,0 +1,85 @@
+package adapters
+
+import (
+	"database/sql"
+	"github.com/itelman/forum/internal/service/posts/domain"
+)
+
+type PostTagsRepositorySqlite struct {
+	db *sql.DB
+}
+
+func NewPostTagsRepositorySqlite(db *sql.DB) *PostTagsRepositorySqlite {
+	return &PostTagsRepositorySqlite{db}
+}
+
+func (r *PostTagsRepositorySqlite) Create(tx *sql.Tx, input domain.CreatePostTagsInput) error {
+	tagStmt, err := tx.Prepare("INSERT OR IGNORE INTO tags (name) VALUES(?)")
+	if err != nil {
+		return err
+	}
+	defer tagStmt.Close()
+
+	postTagStmt, err := tx.Prepare("INSERT INTO post_tags (post_id, tag_id) SELECT ?, id FROM tags WHERE name = ?")
+	if err != nil {
+		return err
+	}
+	defer postTagStmt.Close()
+
+	for _, tag := range input.Tags {
+		if _, err := tagStmt.Exec(tag); err != nil {
+			return err
+		}
+
+		if _, err := postTagStmt.Exec(input.PostID, tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *PostTagsRepositorySqlite) DeleteAllForPost(tx *sql.Tx, input domain.DeletePostTagsInput) error {
+	query := "DELETE FROM post_tags WHERE post_id = ?"
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.PostID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostTagsRepositorySqlite) GetAllForPost(input domain.GetPostTagsInput) ([]string, error) {
+	query := "SELECT tags.name FROM post_tags INNER JOIN tags ON post_tags.tag_id = tags.id WHERE post_tags.post_id = ? ORDER BY tags.name"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(input.PostID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []string{}
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/itelman/forum/internal/dto"
 	"github.com/itelman/forum/internal/service/posts/domain"
+	"github.com/itelman/forum/pkg/pagination"
 	"github.com/itelman/forum/pkg/validator"
 	"net/http"
 	"strconv"
@@ -24,6 +25,7 @@ func DecodeCreatePost(r *http.Request) (interface{}, error) {
 		Title:        r.PostForm.Get("title"),
 		Content:      r.PostForm.Get("content"),
 		CategoriesID: r.PostForm["categories_id"],
+		Tags:         r.PostForm.Get("tags"),
 		ImageFile:    file,
 		ImageHeader:  header,
 		Errors:       make(validator.Errors),
@@ -43,8 +45,22 @@ func DecodeGetPost(r *http.Request) (interface{}, error) {
 	}
 
 	return &GetPostInput{
-		ID:         id,
-		AuthUserID: userId,
+		ID:           id,
+		AuthUserID:   userId,
+		AuthUserRole: dto.GetUserRole(r),
+	}, nil
+}
+
+func DecodeGetAllLatestPosts(r *http.Request) (interface{}, error) {
+	cursor, err := pagination.ParseCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		return nil, domain.ErrPostsBadRequest
+	}
+
+	return &GetAllLatestPostsInput{
+		Sort:   r.URL.Query().Get("sort"),
+		Period: r.URL.Query().Get("t"),
+		Cursor: cursor,
 	}, nil
 }
 
@@ -58,11 +74,15 @@ func DecodeUpdatePost(r *http.Request) (interface{}, error) {
 		return nil, domain.ErrPostsBadRequest
 	}
 
+	_, tagsSent := r.PostForm["tags"]
+
 	return &UpdatePostInput{
-		ID:      id,
-		Title:   r.PostForm.Get("title"),
-		Content: r.PostForm.Get("content"),
-		Errors:  make(validator.Errors),
+		ID:       id,
+		Title:    r.PostForm.Get("title"),
+		Content:  r.PostForm.Get("content"),
+		Tags:     r.PostForm.Get("tags"),
+		KeepTags: !tagsSent,
+		Errors:   make(validator.Errors),
 	}, nil
 }
 
@@ -76,3 +96,14 @@ func DecodeDeletePost(r *http.Request) (interface{}, error) {
 		ID: id,
 	}, nil
 }
+
+func DecodeApprovePost(r *http.Request) (interface{}, error) {
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		return nil, domain.ErrPostsBadRequest
+	}
+
+	return &ApprovePostInput{
+		ID: id,
+	}, nil
+}
@@ -2,14 +2,11 @@ package domain
 
 import (
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
 )
 
 type CommentsRepository interface {
 	GetAllForPost(input GetAllCommentsForPostInput) ([]*dto.Comment, error)
 }
 
-type GetAllCommentsForPostInput struct {
-	PostID         int
-	AuthUserID     int
-	SortedByNewest bool
-}
+type GetAllCommentsForPostInput = repository.GetAllCommentsForPostInput
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type MentionsRepository interface {
+	Create(tx *sql.Tx, input CreateMentionsInput) error
+	Delete(tx *sql.Tx, input DeleteMentionsInput) error
+	GetAllForPost(input GetMentionsInput) ([]*dto.User, error)
+}
+
+type (
+	CreateMentionsInput = repository.CreatePostMentionsInput
+	DeleteMentionsInput = repository.DeletePostMentionsInput
+	GetMentionsInput    = repository.GetPostMentionsInput
+)
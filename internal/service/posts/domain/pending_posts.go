@@ -0,0 +1,16 @@
+package domain
+
+import "database/sql"
+
+type PendingPostsRepository interface {
+	Create(tx *sql.Tx, input CreatePendingPostInput) error
+	Delete(tx *sql.Tx, input DeletePendingPostInput) error
+}
+
+type CreatePendingPostInput struct {
+	PostID int
+}
+
+type DeletePendingPostInput struct {
+	PostID int
+}
@@ -1,17 +1,21 @@
 package domain
 
-import "database/sql"
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/repository"
+)
 
 type PostCategoriesRepository interface {
 	Create(tx *sql.Tx, input CreatePostCategoriesInput) error
 	GetAllForPost(input GetPostCategoriesInput) ([]string, error)
 }
 
-type CreatePostCategoriesInput struct {
-	PostID       int
-	CategoriesID []int
-}
+type (
+	CreatePostCategoriesInput = repository.CreatePostCategoriesInput
+	GetPostCategoriesInput    = repository.GetPostCategoriesInput
+)
 
-type GetPostCategoriesInput struct {
-	PostID int
-}
+var (
+	ErrUnknownCategory = repository.ErrUnknownCategory
+)
@@ -0,0 +1,22 @@
+package domain
+
+import "database/sql"
+
+type PostTagsRepository interface {
+	Create(tx *sql.Tx, input CreatePostTagsInput) error
+	DeleteAllForPost(tx *sql.Tx, input DeletePostTagsInput) error
+	GetAllForPost(input GetPostTagsInput) ([]string, error)
+}
+
+type CreatePostTagsInput struct {
+	PostID int
+	Tags   []string
+}
+
+type DeletePostTagsInput struct {
+	PostID int
+}
+
+type GetPostTagsInput struct {
+	PostID int
+}
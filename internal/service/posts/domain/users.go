@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type UsersRepository interface {
+	GetAllByUsernames(input GetUsersByUsernamesInput) ([]*dto.User, error)
+}
+
+type GetUsersByUsernamesInput = repository.GetUsersByUsernamesInput
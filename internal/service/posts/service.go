@@ -7,31 +7,48 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
 	"github.com/itelman/forum/internal/service/posts/adapters"
 	"github.com/itelman/forum/internal/service/posts/domain"
+	"github.com/itelman/forum/pkg/events"
+	"github.com/itelman/forum/pkg/mentions"
+	"github.com/itelman/forum/pkg/pagination"
 )
 
 type Service interface {
 	CreatePost(input *CreatePostInput, dir string) (*CreatePostResponse, error)
 	GetPost(input *GetPostInput) (*GetPostResponse, error)
-	GetAllLatestPosts() (*GetAllPostsResponse, error)
+	GetAllLatestPosts(input *GetAllLatestPostsInput) (*GetAllPostsResponse, error)
+	GetAllPendingPosts() (*GetAllPostsResponse, error)
 	UpdatePost(input *UpdatePostInput, post *dto.Post) error
+	ApprovePost(input *ApprovePostInput) error
 	DeletePost(input *DeletePostInput, dir string) error
+	DeletePendingPost(input *DeletePostInput, dir string) error
 }
 
 type service struct {
 	posts          domain.PostsRepository
 	postCategories domain.PostCategoriesRepository
+	postTags       domain.PostTagsRepository
 	comments       domain.CommentsRepository
 	images         domain.ImagesRepository
+	pendingPosts   domain.PendingPostsRepository
+	mentions       domain.MentionsRepository
+	users          domain.UsersRepository
+	notifications  domain.NotificationsRepository
+	events         events.Publisher
+	approval       bool
 	db             *sql.DB
 }
 
 func NewService(opts ...Option) *service {
-	svc := &service{}
+	svc := &service{events: events.Discard}
 	for _, opt := range opts {
 		opt(svc)
 	}
@@ -43,16 +60,70 @@ type Option func(*service)
 
 func WithSqlite(db *sql.DB) Option {
 	return func(s *service) {
-		s.posts = adapters.NewPostsRepositorySqlite(db)
-		s.postCategories = adapters.NewPostCategoriesRepositorySqlite(db)
+		s.posts = repository.NewPostsRepositorySqlite(db)
+		s.postCategories = repository.NewPostCategoriesRepositorySqlite(db)
+		s.postTags = adapters.NewPostTagsRepositorySqlite(db)
 		s.images = adapters.NewImagesRepositorySqlite(db)
-		s.comments = adapters.NewCommentsRepositorySqlite(db)
+		s.comments = repository.NewCommentsRepositorySqlite(db)
+		s.pendingPosts = adapters.NewPendingPostsRepositorySqlite(db)
+		s.mentions = repository.NewPostMentionsRepositorySqlite(db)
+		s.users = repository.NewUsersRepositorySqlite(db)
+		s.notifications = repository.NewNotificationsRepositorySqlite(db)
 		s.db = db
 	}
 }
 
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.posts = repository.NewPostsRepositoryPostgres(db)
+		s.postCategories = repository.NewPostCategoriesRepositoryPostgres(db)
+		s.postTags = adapters.NewPostTagsRepositoryPostgres(db)
+		s.images = adapters.NewImagesRepositoryPostgres(db)
+		s.comments = repository.NewCommentsRepositoryPostgres(db)
+		s.pendingPosts = adapters.NewPendingPostsRepositoryPostgres(db)
+		s.mentions = repository.NewPostMentionsRepositoryPostgres(db)
+		s.users = repository.NewUsersRepositoryPostgres(db)
+		s.notifications = repository.NewNotificationsRepositoryPostgres(db)
+		s.db = db
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.posts = memory.NewPostsRepository(store)
+		s.postCategories = memory.NewPostCategoriesRepository(store)
+		s.postTags = memory.NewPostTagsRepository(store)
+		s.images = memory.NewImagesRepository(store)
+		s.comments = memory.NewCommentsRepository(store)
+		s.pendingPosts = memory.NewPendingPostsRepository(store)
+		s.mentions = memory.NewPostMentionsRepository(store)
+		s.users = memory.NewUsersRepository(store)
+		s.notifications = memory.NewNotificationsRepository(store)
+		s.db = store.DB()
+	}
+}
+
+func WithApproval(enabled bool) Option {
+	return func(s *service) {
+		s.approval = enabled
+	}
+}
+
+func WithEvents(publisher events.Publisher) Option {
+	return func(s *service) {
+		s.events = publisher
+	}
+}
+
 type CreatePostResponse struct {
-	PostID int
+	PostID  int
+	Pending bool
 }
 
 func (s *service) CreatePost(input *CreatePostInput, dir string) (*CreatePostResponse, error) {
@@ -66,6 +137,11 @@ func (s *service) CreatePost(input *CreatePostInput, dir string) (*CreatePostRes
 		return nil, err
 	}
 
+	mentioned, err := s.users.GetAllByUsernames(domain.GetUsersByUsernamesInput{Usernames: mentions.Parse(input.Content)})
+	if err != nil {
+		return nil, err
+	}
+
 	tx, err := s.db.Begin()
 	if err != nil {
 		return nil, err
@@ -84,15 +160,45 @@ func (s *service) CreatePost(input *CreatePostInput, dir string) (*CreatePostRes
 	if err := s.postCategories.Create(tx, domain.CreatePostCategoriesInput{
 		PostID:       postId,
 		CategoriesID: catgsId,
-	}); errors.Is(err, domain.ErrPostsBadRequest) {
+	}); errors.Is(err, domain.ErrUnknownCategory) {
 		input.Errors.Add("categories", "Please provide valid categories")
 		tx.Rollback()
-		return nil, err
+		return nil, domain.ErrPostsBadRequest
 	} else if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
 
+	if err := s.postTags.Create(tx, domain.CreatePostTagsInput{
+		PostID: postId,
+		Tags:   input.tags,
+	}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := s.mentions.Create(tx, domain.CreateMentionsInput{
+		PostID:  postId,
+		UserIDs: mentions.UserIDs(mentioned),
+	}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	notified := map[int]int{}
+	if s.approval {
+		if err := s.pendingPosts.Create(tx, domain.CreatePendingPostInput{PostID: postId}); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	} else {
+		notified, err = mentions.Notify(tx, s.notifications, domain.CreateNotificationInput{ActorID: input.UserID, PostID: postId}, mentioned)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
 	if fileExists {
 		dirPath := filepath.Join(dir, strconv.Itoa(postId))
 		if err := os.MkdirAll(dirPath, os.ModePerm); err != nil {
@@ -126,7 +232,9 @@ func (s *service) CreatePost(input *CreatePostInput, dir string) (*CreatePostRes
 		return nil, err
 	}
 
-	return &CreatePostResponse{PostID: postId}, nil
+	s.publishMentions(postId, notified)
+
+	return &CreatePostResponse{PostID: postId, Pending: s.approval}, nil
 }
 
 type GetPostResponse struct {
@@ -142,12 +250,28 @@ func (s *service) GetPost(input *GetPostInput) (*GetPostResponse, error) {
 		return nil, err
 	}
 
+	if !post.VisibleTo(input.AuthUserID, input.AuthUserRole) {
+		return nil, domain.ErrPostNotFound
+	}
+
 	categories, err := s.postCategories.GetAllForPost(domain.GetPostCategoriesInput{PostID: input.ID})
 	if err != nil {
 		return nil, err
 	}
 	post.Categories = categories
 
+	tags, err := s.postTags.GetAllForPost(domain.GetPostTagsInput{PostID: input.ID})
+	if err != nil {
+		return nil, err
+	}
+	post.Tags = tags
+
+	mentioned, err := s.mentions.GetAllForPost(domain.GetMentionsInput{PostID: input.ID})
+	if err != nil {
+		return nil, err
+	}
+	post.Mentions = usernames(mentioned)
+
 	comments, err := s.comments.GetAllForPost(domain.GetAllCommentsForPostInput{
 		PostID:         input.ID,
 		AuthUserID:     input.AuthUserID,
@@ -156,7 +280,7 @@ func (s *service) GetPost(input *GetPostInput) (*GetPostResponse, error) {
 	if err != nil {
 		return nil, err
 	}
-	post.Comments = comments
+	post.Comments = buildCommentTree(comments)
 
 	image, err := s.images.Get(domain.GetImageInput{PostID: input.ID})
 	if err != nil && !errors.Is(err, domain.ErrImageNotFound) {
@@ -168,18 +292,41 @@ func (s *service) GetPost(input *GetPostInput) (*GetPostResponse, error) {
 }
 
 type GetAllPostsResponse struct {
-	Posts []*dto.Post
+	Posts      []*dto.Post
+	NextCursor string
+}
+
+func (s *service) GetAllLatestPosts(input *GetAllLatestPostsInput) (*GetAllPostsResponse, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	posts, err := s.posts.GetAll(domain.GetAllPostsInput{
+		SortedByNewest: true,
+		Sort:           input.Sort,
+		Period:         input.Period,
+		Cursor:         input.Cursor,
+		Limit:          pagination.DefaultLimit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	posts, nextCursor := pagination.Paginate(posts, pagination.DefaultLimit, postCursor)
+
+	return &GetAllPostsResponse{posts, nextCursor}, nil
 }
 
-func (s *service) GetAllLatestPosts() (*GetAllPostsResponse, error) {
+func (s *service) GetAllPendingPosts() (*GetAllPostsResponse, error) {
 	posts, err := s.posts.GetAll(domain.GetAllPostsInput{
+		Pending:        true,
 		SortedByNewest: true,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &GetAllPostsResponse{posts}, nil
+	return &GetAllPostsResponse{Posts: posts}, nil
 }
 
 func (s *service) UpdatePost(input *UpdatePostInput, post *dto.Post) error {
@@ -187,17 +334,161 @@ func (s *service) UpdatePost(input *UpdatePostInput, post *dto.Post) error {
 		return err
 	}
 
-	if err := s.posts.Update(domain.UpdatePostInput{
+	mentioned, err := s.users.GetAllByUsernames(domain.GetUsersByUsernamesInput{Usernames: mentions.Parse(input.Content)})
+	if err != nil {
+		return err
+	}
+
+	previous, err := s.mentions.GetAllForPost(domain.GetMentionsInput{PostID: input.ID})
+	if err != nil {
+		return err
+	}
+	added, removed := mentions.Diff(previous, mentioned), mentions.Diff(mentioned, previous)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := s.posts.Update(tx, domain.UpdatePostInput{
 		ID:      input.ID,
 		Title:   input.Title,
 		Content: input.Content,
 	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := s.postTags.DeleteAllForPost(tx, domain.DeletePostTagsInput{PostID: input.ID}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := s.postTags.Create(tx, domain.CreatePostTagsInput{
+		PostID: input.ID,
+		Tags:   input.tags,
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := s.mentions.Delete(tx, domain.DeleteMentionsInput{
+		PostID:  input.ID,
+		UserIDs: mentions.UserIDs(removed),
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := s.mentions.Create(tx, domain.CreateMentionsInput{
+		PostID:  input.ID,
+		UserIDs: mentions.UserIDs(added),
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Only users newly mentioned by this edit are notified; pending posts
+	// notify everyone once they are approved.
+	notified := map[int]int{}
+	if !post.Pending {
+		notified, err = mentions.Notify(tx, s.notifications, domain.CreateNotificationInput{ActorID: post.User.ID, PostID: input.ID}, added)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	s.publishMentions(input.ID, notified)
+
+	return nil
+}
+
+func (s *service) ApprovePost(input *ApprovePostInput) error {
+	post, err := s.getPendingPost(input.ID)
+	if err != nil {
+		return err
+	}
+
+	mentioned, err := s.mentions.GetAllForPost(domain.GetMentionsInput{PostID: input.ID})
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := s.pendingPosts.Delete(tx, domain.DeletePendingPostInput{
+		PostID: input.ID,
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	notified, err := mentions.Notify(tx, s.notifications, domain.CreateNotificationInput{ActorID: post.User.ID, PostID: input.ID}, mentioned)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
 		return err
 	}
 
+	s.publishMentions(input.ID, notified)
+
 	return nil
 }
 
+func (s *service) DeletePendingPost(input *DeletePostInput, dir string) error {
+	if _, err := s.getPendingPost(input.ID); err != nil {
+		return err
+	}
+
+	return s.DeletePost(input, dir)
+}
+
+func (s *service) getPendingPost(id int) (*dto.Post, error) {
+	post, err := s.posts.Get(domain.GetPostInput{ID: id, AuthUserID: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	if !post.Pending {
+		return nil, domain.ErrPostNotFound
+	}
+
+	return post, nil
+}
+
+// publishMentions pushes the committed mention notifications to live subscribers.
+func (s *service) publishMentions(postId int, notified map[int]int) {
+	if len(notified) == 0 {
+		return
+	}
+
+	post, err := s.posts.Get(domain.GetPostInput{ID: postId, AuthUserID: -1})
+	if err != nil {
+		return
+	}
+
+	for userId, notificationId := range notified {
+		s.events.Publish(events.UserTopic(userId), events.Event{Name: events.Notification, Data: &dto.Notification{
+			ID:      notificationId,
+			Type:    dto.NotificationMention,
+			Actor:   post.User,
+			PostID:  postId,
+			Created: time.Now(),
+		}})
+	}
+}
+
 func (s *service) DeletePost(input *DeletePostInput, dir string) error {
 	if err := os.RemoveAll(filepath.Join(dir, strconv.Itoa(input.ID))); err != nil {
 		return err
@@ -211,3 +502,42 @@ func (s *service) DeletePost(input *DeletePostInput, dir string) error {
 
 	return nil
 }
+
+func buildCommentTree(comments []*dto.Comment) []*dto.Comment {
+	byId := make(map[int]*dto.Comment, len(comments))
+	for _, comment := range comments {
+		byId[comment.ID] = comment
+	}
+
+	roots := []*dto.Comment{}
+	for _, comment := range comments {
+		parent, ok := byId[comment.ParentID]
+		if !ok {
+			roots = append(roots, comment)
+			continue
+		}
+
+		parent.Replies = append(parent.Replies, comment)
+	}
+
+	for _, comment := range comments {
+		sort.SliceStable(comment.Replies, func(i, j int) bool {
+			return comment.Replies[i].Created.Before(comment.Replies[j].Created)
+		})
+	}
+
+	return roots
+}
+
+func postCursor(post *dto.Post) *pagination.Cursor {
+	return &pagination.Cursor{Created: post.Created, Score: post.Score, ID: post.ID}
+}
+
+func usernames(users []*dto.User) []string {
+	names := make([]string, len(users))
+	for i, user := range users {
+		names[i] = user.Username
+	}
+
+	return names
+}
@@ -0,0 +1,625 @@
+package posts
+
+import (
+	"errors"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	commentsdomain "github.com/itelman/forum/internal/service/comments/domain"
+	"github.com/itelman/forum/internal/service/posts/domain"
+	"github.com/itelman/forum/pkg/pagination"
+	"github.com/itelman/forum/pkg/validator"
+)
+
+const (
+	alice = 1
+	bob   = 2
+)
+
+func newTestService(t *testing.T, opts ...Option) (*service, *memory.Store) {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice", "bob")
+
+	return NewService(append([]Option{WithMemoryStore(store)}, opts...)...), store
+}
+
+func createPost(t *testing.T, svc *service, userId int, title, content, tags string) int {
+	t.Helper()
+
+	resp, err := svc.CreatePost(&CreatePostInput{
+		UserID:       userId,
+		Title:        title,
+		Content:      content,
+		CategoriesID: []string{"1"},
+		Tags:         tags,
+		Errors:       make(validator.Errors),
+	}, t.TempDir())
+	if err != nil {
+		t.Fatalf("create post %q: %v", title, err)
+	}
+
+	return resp.PostID
+}
+
+func unreadCount(t *testing.T, store *memory.Store, userId int) int {
+	t.Helper()
+
+	count, err := memory.NewNotificationsRepository(store).CountUnread(repository.CountUnreadNotificationsInput{UserID: userId})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return count
+}
+
+func TestCreatePost(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      CreatePostInput
+		wantErr    error
+		wantFields []string
+	}{
+		{"valid", CreatePostInput{Title: "Hello world", Content: "First post", CategoriesID: []string{"1", "2"}}, nil, nil},
+		{"with tags", CreatePostInput{Title: "Hello world", Content: "First post", CategoriesID: []string{"1"}, Tags: "go, web"}, nil, nil},
+		{"short title", CreatePostInput{Title: "Hey", Content: "First post", CategoriesID: []string{"1"}}, domain.ErrPostsBadRequest, []string{"title"}},
+		{"long title", CreatePostInput{Title: strings.Repeat("a", titleMaxLen+1), Content: "First post", CategoriesID: []string{"1"}}, domain.ErrPostsBadRequest, []string{"title"}},
+		{"padded title", CreatePostInput{Title: " Hello world", Content: "First post", CategoriesID: []string{"1"}}, domain.ErrPostsBadRequest, []string{"title"}},
+		{"blank content", CreatePostInput{Title: "Hello world", Content: "  ", CategoriesID: []string{"1"}}, domain.ErrPostsBadRequest, []string{"content"}},
+		{"padded content", CreatePostInput{Title: "Hello world", Content: "First post\n", CategoriesID: []string{"1"}}, domain.ErrPostsBadRequest, []string{"content"}},
+		{"no categories", CreatePostInput{Title: "Hello world", Content: "First post"}, domain.ErrPostsBadRequest, []string{"categories"}},
+		{"malformed category", CreatePostInput{Title: "Hello world", Content: "First post", CategoriesID: []string{"one"}}, domain.ErrPostsBadRequest, []string{"categories"}},
+		{"unknown category", CreatePostInput{Title: "Hello world", Content: "First post", CategoriesID: []string{"99"}}, domain.ErrPostsBadRequest, []string{"categories"}},
+		{"too many tags", CreatePostInput{Title: "Hello world", Content: "First post", CategoriesID: []string{"1"}, Tags: "a b c d e f"}, domain.ErrPostsBadRequest, []string{"tags"}},
+		{"invalid tag", CreatePostInput{Title: "Hello world", Content: "First post", CategoriesID: []string{"1"}, Tags: "c++"}, domain.ErrPostsBadRequest, []string{"tags"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+			input := tt.input
+			input.UserID = alice
+			input.Errors = make(validator.Errors)
+
+			resp, err := svc.CreatePost(&input, t.TempDir())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if len(input.Errors) != len(tt.wantFields) {
+				t.Errorf("got errors %v, want fields %v", input.Errors, tt.wantFields)
+			}
+			for _, field := range tt.wantFields {
+				if input.Errors.Get(field) == "" {
+					t.Errorf("missing error for %q", field)
+				}
+			}
+
+			all, err := svc.GetAllLatestPosts(&GetAllLatestPostsInput{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantErr != nil {
+				if len(all.Posts) != 0 {
+					t.Errorf("got %d posts after a failed create, want 0", len(all.Posts))
+				}
+				return
+			}
+
+			if resp.Pending || len(all.Posts) != 1 || all.Posts[0].ID != resp.PostID {
+				t.Errorf("got %+v and %d posts, want the created post", resp, len(all.Posts))
+			}
+		})
+	}
+}
+
+func TestCreatePostImage(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		size     int64
+		wantErr  error
+	}{
+		{"png", "cat.png", 3, nil},
+		{"jpeg", "cat.jpeg", 3, nil},
+		{"unsupported extension", "cat.txt", 3, domain.ErrPostsBadRequest},
+		{"too large", "cat.png", maxFileSize + 1, domain.ErrPostsBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+			dir := t.TempDir()
+
+			file, err := os.CreateTemp(t.TempDir(), "upload")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			if _, err := file.WriteString("img"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := file.Seek(0, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			input := &CreatePostInput{
+				UserID:       alice,
+				Title:        "Hello world",
+				Content:      "First post",
+				CategoriesID: []string{"1"},
+				ImageFile:    file,
+				ImageHeader:  &multipart.FileHeader{Filename: tt.filename, Size: tt.size},
+				Errors:       make(validator.Errors),
+			}
+
+			resp, err := svc.CreatePost(input, dir)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				if input.Errors.Get("image") == "" {
+					t.Errorf("got errors %v, want an image error", input.Errors)
+				}
+				return
+			}
+
+			post, err := svc.GetPost(&GetPostInput{ID: resp.PostID, AuthUserID: -1})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if post.Post.Image == nil || post.Post.Image.Path != "/images/1/"+tt.filename {
+				t.Errorf("got image %+v, want /images/1/%s", post.Post.Image, tt.filename)
+			}
+
+			if content, err := os.ReadFile(filepath.Join(dir, "1", tt.filename)); err != nil || string(content) != "img" {
+				t.Errorf("got file %q (%v), want %q", content, err, "img")
+			}
+		})
+	}
+}
+
+func TestCreatePostMentions(t *testing.T) {
+	tests := []struct {
+		name         string
+		approval     bool
+		content      string
+		wantMentions []string
+		wantNotified int
+	}{
+		{"mention", false, "Hi @bob", []string{"bob"}, 1},
+		{"self mention", false, "Hi @alice", []string{"alice"}, 0},
+		{"unknown user", false, "Hi @nobody", []string{}, 0},
+		{"pending post", true, "Hi @bob", []string{"bob"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store := newTestService(t, WithApproval(tt.approval))
+
+			postId := createPost(t, svc, alice, "Hello world", tt.content, "")
+
+			post, err := svc.GetPost(&GetPostInput{ID: postId, AuthUserID: alice})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if strings.Join(post.Post.Mentions, ",") != strings.Join(tt.wantMentions, ",") {
+				t.Errorf("got mentions %v, want %v", post.Post.Mentions, tt.wantMentions)
+			}
+
+			if got := unreadCount(t, store, bob); got != tt.wantNotified {
+				t.Errorf("got %d notifications for bob, want %d", got, tt.wantNotified)
+			}
+		})
+	}
+}
+
+func TestGetPost(t *testing.T) {
+	tests := []struct {
+		name     string
+		pending  bool
+		input    GetPostInput
+		wantErr  error
+		wantTags []string
+	}{
+		{"anonymous", false, GetPostInput{ID: 1, AuthUserID: -1}, nil, []string{"go", "web"}},
+		{"missing", false, GetPostInput{ID: 2, AuthUserID: -1}, domain.ErrPostNotFound, nil},
+		{"pending for author", true, GetPostInput{ID: 1, AuthUserID: alice}, nil, []string{"go", "web"}},
+		{"pending for moderator", true, GetPostInput{ID: 1, AuthUserID: bob, AuthUserRole: dto.RoleModerator}, nil, []string{"go", "web"}},
+		{"pending for admin", true, GetPostInput{ID: 1, AuthUserID: bob, AuthUserRole: dto.RoleAdmin}, nil, []string{"go", "web"}},
+		{"pending for user", true, GetPostInput{ID: 1, AuthUserID: bob}, domain.ErrPostNotFound, nil},
+		{"pending for anonymous", true, GetPostInput{ID: 1, AuthUserID: -1}, domain.ErrPostNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t, WithApproval(tt.pending))
+			createPost(t, svc, alice, "Hello world", "First post", "web go")
+
+			resp, err := svc.GetPost(&tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if resp.Post.User.Username != "alice" || resp.Post.Pending != tt.pending {
+				t.Errorf("got post by %q pending %v", resp.Post.User.Username, resp.Post.Pending)
+			}
+			if strings.Join(resp.Post.Tags, ",") != strings.Join(tt.wantTags, ",") {
+				t.Errorf("got tags %v, want %v", resp.Post.Tags, tt.wantTags)
+			}
+			if strings.Join(resp.Post.Categories, ",") != "Music" {
+				t.Errorf("got categories %v, want [Music]", resp.Post.Categories)
+			}
+		})
+	}
+}
+
+func TestGetPostCommentTree(t *testing.T) {
+	svc, store := newTestService(t)
+	postId := createPost(t, svc, alice, "Hello world", "First post", "")
+
+	comments := memory.NewCommentsRepository(store)
+	replies := memory.NewCommentRepliesRepository(store)
+	for _, c := range []struct{ id, parentId int }{{1, 0}, {2, 1}, {3, 0}, {4, 2}} {
+		if _, err := comments.Create(nil, repository.CreateCommentInput{PostID: postId, UserID: bob, Content: "comment"}); err != nil {
+			t.Fatal(err)
+		}
+		if c.parentId != 0 {
+			if err := replies.Create(nil, commentsdomain.CreateCommentReplyInput{CommentID: c.id, ParentID: c.parentId}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	resp, err := svc.GetPost(&GetPostInput{ID: postId, AuthUserID: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Comments created within the same second have no defined order.
+	roots := resp.Post.Comments
+	if len(roots) != 2 || roots[0].ID+roots[1].ID != 4 {
+		t.Fatalf("got roots %v, want 1 and 3", commentIds(roots))
+	}
+
+	first := roots[0]
+	if first.ID != 1 {
+		first = roots[1]
+	}
+	if len(first.Replies) != 1 || first.Replies[0].ID != 2 {
+		t.Fatalf("got replies of 1 %v, want [2]", commentIds(first.Replies))
+	}
+	if len(first.Replies[0].Replies) != 1 || first.Replies[0].Replies[0].ID != 4 {
+		t.Errorf("got replies of 2 %v, want [4]", commentIds(first.Replies[0].Replies))
+	}
+}
+
+func commentIds(comments []*dto.Comment) []int {
+	ids := make([]int, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.ID
+	}
+
+	return ids
+}
+
+func TestGetAllLatestPosts(t *testing.T) {
+	tests := []struct {
+		name      string
+		posts     int
+		input     GetAllLatestPostsInput
+		wantErr   error
+		wantCount int
+		wantNext  bool
+	}{
+		{"empty", 0, GetAllLatestPostsInput{}, nil, 0, false},
+		{"one page", pagination.DefaultLimit, GetAllLatestPostsInput{}, nil, pagination.DefaultLimit, false},
+		{"two pages", pagination.DefaultLimit + 1, GetAllLatestPostsInput{}, nil, pagination.DefaultLimit, true},
+		{"top of the week", 3, GetAllLatestPostsInput{Sort: pagination.SortTop, Period: pagination.PeriodWeek}, nil, 3, false},
+		{"hot", 3, GetAllLatestPostsInput{Sort: pagination.SortHot}, nil, 3, false},
+		{"unknown sort", 0, GetAllLatestPostsInput{Sort: "best"}, domain.ErrPostsBadRequest, 0, false},
+		{"unknown period", 0, GetAllLatestPostsInput{Sort: pagination.SortTop, Period: "year"}, domain.ErrPostsBadRequest, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+			for i := 0; i < tt.posts; i++ {
+				createPost(t, svc, alice, "Hello world", "First post", "")
+			}
+
+			resp, err := svc.GetAllLatestPosts(&tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if len(resp.Posts) != tt.wantCount || (resp.NextCursor != "") != tt.wantNext {
+				t.Errorf("got %d posts and cursor %q", len(resp.Posts), resp.NextCursor)
+			}
+		})
+	}
+}
+
+func TestGetAllLatestPostsCursor(t *testing.T) {
+	svc, _ := newTestService(t)
+	for i := 0; i < pagination.DefaultLimit+5; i++ {
+		createPost(t, svc, alice, "Hello world", "First post", "")
+	}
+
+	first, err := svc.GetAllLatestPosts(&GetAllLatestPostsInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cursor, err := pagination.ParseCursor(first.NextCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := svc.GetAllLatestPosts(&GetAllLatestPostsInput{Cursor: cursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(second.Posts) != 5 || second.NextCursor != "" {
+		t.Fatalf("got %d posts and cursor %q on the second page, want 5 and none", len(second.Posts), second.NextCursor)
+	}
+	if first.Posts[0].ID != pagination.DefaultLimit+5 || second.Posts[4].ID != 1 {
+		t.Errorf("got pages from %d to %d, want newest first", first.Posts[0].ID, second.Posts[4].ID)
+	}
+}
+
+func TestGetAllPendingPosts(t *testing.T) {
+	tests := []struct {
+		name     string
+		approval bool
+		want     int
+	}{
+		{"approval off", false, 0},
+		{"approval on", true, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t, WithApproval(tt.approval))
+			createPost(t, svc, alice, "Hello world", "First post", "")
+			createPost(t, svc, bob, "Hello again", "Second post", "")
+
+			resp, err := svc.GetAllPendingPosts()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(resp.Posts) != tt.want {
+				t.Errorf("got %d pending posts, want %d", len(resp.Posts), tt.want)
+			}
+
+			latest, err := svc.GetAllLatestPosts(&GetAllLatestPostsInput{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(latest.Posts)+len(resp.Posts) != 2 {
+				t.Errorf("got %d latest posts next to %d pending", len(latest.Posts), len(resp.Posts))
+			}
+		})
+	}
+}
+
+func TestUpdatePost(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        UpdatePostInput
+		wantErr      error
+		wantFields   []string
+		wantTags     []string
+		wantNotified int
+	}{
+		{"new title", UpdatePostInput{Title: "Hello there", Content: "First post", KeepTags: true}, nil, nil, []string{"go"}, 0},
+		{"new tags", UpdatePostInput{Title: "Hello world", Content: "First post", Tags: "web"}, nil, nil, []string{"web"}, 0},
+		{"cleared tags", UpdatePostInput{Title: "Hello world", Content: "First post"}, nil, nil, []string{}, 0},
+		{"new mention", UpdatePostInput{Title: "Hello world", Content: "Hi @bob", KeepTags: true}, nil, nil, []string{"go"}, 1},
+		{"unchanged", UpdatePostInput{Title: "Hello world", Content: "First post", KeepTags: true}, domain.ErrPostsBadRequest, []string{"generic"}, []string{"go"}, 0},
+		{"unchanged tags", UpdatePostInput{Title: "Hello world", Content: "First post", Tags: "Go"}, domain.ErrPostsBadRequest, []string{"generic"}, []string{"go"}, 0},
+		{"short title", UpdatePostInput{Title: "Hey", Content: "First post", KeepTags: true}, domain.ErrPostsBadRequest, []string{"title"}, []string{"go"}, 0},
+		{"padded title", UpdatePostInput{Title: "Hello world ", Content: "First post", KeepTags: true}, domain.ErrPostsBadRequest, []string{"title"}, []string{"go"}, 0},
+		{"blank content", UpdatePostInput{Title: "Hello world", Content: "", KeepTags: true}, domain.ErrPostsBadRequest, []string{"content"}, []string{"go"}, 0},
+		{"invalid tags", UpdatePostInput{Title: "Hello world", Content: "First post", Tags: "a b c d e f"}, domain.ErrPostsBadRequest, []string{"tags"}, []string{"go"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store := newTestService(t)
+			postId := createPost(t, svc, alice, "Hello world", "First post", "go")
+
+			before, err := svc.GetPost(&GetPostInput{ID: postId, AuthUserID: alice})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			input := tt.input
+			input.ID = postId
+			input.Errors = make(validator.Errors)
+
+			if err := svc.UpdatePost(&input, before.Post); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if len(input.Errors) != len(tt.wantFields) {
+				t.Errorf("got errors %v, want fields %v", input.Errors, tt.wantFields)
+			}
+			for _, field := range tt.wantFields {
+				if input.Errors.Get(field) == "" {
+					t.Errorf("missing error for %q", field)
+				}
+			}
+
+			after, err := svc.GetPost(&GetPostInput{ID: postId, AuthUserID: alice})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantErr == nil && (after.Post.Title != tt.input.Title || after.Post.Content != tt.input.Content) {
+				t.Errorf("got %q/%q, want %q/%q", after.Post.Title, after.Post.Content, tt.input.Title, tt.input.Content)
+			}
+			if strings.Join(after.Post.Tags, ",") != strings.Join(tt.wantTags, ",") {
+				t.Errorf("got tags %v, want %v", after.Post.Tags, tt.wantTags)
+			}
+			if got := unreadCount(t, store, bob); got != tt.wantNotified {
+				t.Errorf("got %d notifications for bob, want %d", got, tt.wantNotified)
+			}
+		})
+	}
+}
+
+func TestUpdatePostRemovesMentions(t *testing.T) {
+	svc, store := newTestService(t)
+	postId := createPost(t, svc, alice, "Hello world", "Hi @bob", "")
+
+	before, err := svc.GetPost(&GetPostInput{ID: postId, AuthUserID: alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.UpdatePost(&UpdatePostInput{ID: postId, Title: "Hello world", Content: "Hi all, @bob", KeepTags: true, Errors: make(validator.Errors)}, before.Post); err != nil {
+		t.Fatal(err)
+	}
+	if got := unreadCount(t, store, bob); got != 1 {
+		t.Errorf("got %d notifications after keeping the mention, want 1", got)
+	}
+
+	after, err := svc.GetPost(&GetPostInput{ID: postId, AuthUserID: alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.UpdatePost(&UpdatePostInput{ID: postId, Title: "Hello world", Content: "Hi all", KeepTags: true, Errors: make(validator.Errors)}, after.Post); err != nil {
+		t.Fatal(err)
+	}
+
+	final, err := svc.GetPost(&GetPostInput{ID: postId, AuthUserID: alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(final.Post.Mentions) != 0 {
+		t.Errorf("got mentions %v, want none", final.Post.Mentions)
+	}
+}
+
+func TestApprovePost(t *testing.T) {
+	tests := []struct {
+		name     string
+		approval bool
+		id       int
+		wantErr  error
+	}{
+		{"pending", true, 1, nil},
+		{"approved", false, 1, domain.ErrPostNotFound},
+		{"missing", true, 2, domain.ErrPostNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store := newTestService(t, WithApproval(tt.approval))
+			createPost(t, svc, alice, "Hello world", "Hi @bob", "")
+			notifiedBefore := unreadCount(t, store, bob)
+
+			if err := svc.ApprovePost(&ApprovePostInput{ID: tt.id}); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			resp, err := svc.GetPost(&GetPostInput{ID: 1, AuthUserID: -1})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.Post.Pending {
+				t.Error("post is still pending")
+			}
+			if got := unreadCount(t, store, bob); got != notifiedBefore+1 {
+				t.Errorf("got %d notifications for bob, want %d", got, notifiedBefore+1)
+			}
+		})
+	}
+}
+
+func TestDeletePost(t *testing.T) {
+	tests := []struct {
+		name        string
+		approval    bool
+		onlyPending bool
+		wantErr     error
+	}{
+		{"approved", false, false, nil},
+		{"pending", true, false, nil},
+		{"pending only", true, true, nil},
+		{"pending only, approved", false, true, domain.ErrPostNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store := newTestService(t, WithApproval(tt.approval))
+			dir := t.TempDir()
+			postId := createPost(t, svc, alice, "Hello world", "Hi @bob", "go")
+
+			if err := os.MkdirAll(filepath.Join(dir, "1"), os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+
+			var err error
+			if tt.onlyPending {
+				err = svc.DeletePendingPost(&DeletePostInput{ID: postId}, dir)
+			} else {
+				err = svc.DeletePost(&DeletePostInput{ID: postId}, dir)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			_, err = svc.GetPost(&GetPostInput{ID: postId, AuthUserID: alice})
+			_, statErr := os.Stat(filepath.Join(dir, "1"))
+
+			if tt.wantErr != nil {
+				if err != nil || statErr != nil {
+					t.Errorf("post or image dir removed after a failed delete: %v, %v", err, statErr)
+				}
+				return
+			}
+
+			if !errors.Is(err, domain.ErrPostNotFound) {
+				t.Errorf("got error %v after delete, want %v", err, domain.ErrPostNotFound)
+			}
+			if !os.IsNotExist(statErr) {
+				t.Errorf("image dir still exists: %v", statErr)
+			}
+			if got := unreadCount(t, store, bob); got != 0 {
+				t.Errorf("got %d notifications for a deleted post, want 0", got)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package posts
 
 import (
+	"errors"
 	"fmt"
 	"mime/multipart"
 	"path/filepath"
@@ -9,6 +10,8 @@ import (
 
 	"github.com/itelman/forum/internal/dto"
 	"github.com/itelman/forum/internal/service/posts/domain"
+	"github.com/itelman/forum/pkg/pagination"
+	"github.com/itelman/forum/pkg/tags"
 	"github.com/itelman/forum/pkg/validator"
 )
 
@@ -23,9 +26,11 @@ type CreatePostInput struct {
 	Title        string
 	Content      string
 	CategoriesID []string
+	Tags         string
 	ImageFile    multipart.File
 	ImageHeader  *multipart.FileHeader
 	Errors       validator.Errors
+	tags         []string
 }
 
 func (i *CreatePostInput) validate(fileExists bool) ([]int, error) {
@@ -35,6 +40,7 @@ func (i *CreatePostInput) validate(fileExists bool) ([]int, error) {
 
 	i.validateTitle()
 	i.validateContent()
+	i.tags = validateTags(i.Tags, i.Errors)
 	ids := i.validateCategoriesID()
 
 	if len(i.Errors) != 0 || ids == nil {
@@ -84,6 +90,19 @@ func (i *CreatePostInput) validateCategoriesID() []int {
 	return result
 }
 
+func validateTags(raw string, errs validator.Errors) []string {
+	result, err := tags.Parse(raw)
+	if errors.Is(err, tags.ErrTooMany) {
+		errs.Add("tags", fmt.Sprintf("No more than %d tags per post", tags.MaxPerPost))
+		return nil
+	} else if err != nil {
+		errs.Add("tags", fmt.Sprintf("Tags may only contain letters, digits, - and _ (max %d characters)", tags.MaxLen))
+		return nil
+	}
+
+	return result
+}
+
 func (i *CreatePostInput) validateImage() {
 	if i.ImageHeader.Size > maxFileSize {
 		i.Errors.Add("image", fmt.Sprintf("Max size exceeded (max %d MB)", 20))
@@ -106,26 +125,50 @@ func (i *CreatePostInput) validateImage() {
 }
 
 type GetPostInput struct {
-	ID         int
-	AuthUserID int
+	ID           int
+	AuthUserID   int
+	AuthUserRole string
+}
+
+type GetAllLatestPostsInput struct {
+	Sort   string
+	Period string
+	Cursor *pagination.Cursor
+}
+
+func (i *GetAllLatestPostsInput) validate() error {
+	if !pagination.ValidSort(i.Sort, i.Period) {
+		return domain.ErrPostsBadRequest
+	}
+
+	return nil
 }
 
 type UpdatePostInput struct {
-	ID      int
-	Title   string
-	Content string
-	Errors  validator.Errors
+	ID       int
+	Title    string
+	Content  string
+	Tags     string
+	KeepTags bool
+	Errors   validator.Errors
+	tags     []string
 }
 
 func (i *UpdatePostInput) validate(post *dto.Post) error {
 	i.validateTitle()
 	i.validateContent()
 
+	if i.KeepTags {
+		i.tags = post.Tags
+	} else {
+		i.tags = validateTags(i.Tags, i.Errors)
+	}
+
 	if len(i.Errors) != 0 {
 		return domain.ErrPostsBadRequest
 	}
 
-	if i.Title == post.Title && i.Content == post.Content {
+	if i.Title == post.Title && i.Content == post.Content && sameTags(i.tags, post.Tags) {
 		i.Errors.Add("generic", validator.ErrInputUnchanged)
 		return domain.ErrPostsBadRequest
 	}
@@ -157,6 +200,29 @@ func (i *UpdatePostInput) validateContent() {
 	}
 }
 
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	set := make(map[string]bool, len(a))
+	for _, tag := range a {
+		set[tag] = true
+	}
+
+	for _, tag := range b {
+		if !set[tag] {
+			return false
+		}
+	}
+
+	return true
+}
+
 type DeletePostInput struct {
 	ID int
 }
+
+type ApprovePostInput struct {
+	ID int
+}
@@ -0,0 +1,20 @@
+package profiles
+
+import (
+	"net/http"
+
+	"github.com/itelman/forum/internal/service/profiles/domain"
+	"github.com/itelman/forum/pkg/pagination"
+)
+
+func DecodeGetProfile(r *http.Request) (interface{}, error) {
+	cursor, err := pagination.ParseCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		return nil, domain.ErrProfilesBadRequest
+	}
+
+	return &GetProfileInput{
+		Username: r.URL.Query().Get("name"),
+		Cursor:   cursor,
+	}, nil
+}
@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type PostsRepository interface {
+	GetAllForUser(input GetAllPostsForUserInput) ([]*dto.Post, error)
+}
+
+type GetAllPostsForUserInput = repository.GetAllPostsForUserInput
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type UsersRepository interface {
+	Get(input GetUserInput) (*dto.User, error)
+}
+
+type GetUserInput = repository.GetUserInput
+
+var (
+	ErrProfilesBadRequest = errors.New("PROFILES: bad request")
+	ErrUserNotFound       = repository.ErrUserNotFound
+)
@@ -0,0 +1,93 @@
+package profiles
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/service/profiles/domain"
+	"github.com/itelman/forum/pkg/pagination"
+)
+
+type Service interface {
+	GetProfile(input *GetProfileInput) (*GetProfileResponse, error)
+}
+
+type service struct {
+	users domain.UsersRepository
+	posts domain.PostsRepository
+}
+
+func NewService(opts ...Option) *service {
+	svc := &service{}
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	return svc
+}
+
+type Option func(*service)
+
+func WithSqlite(db *sql.DB) Option {
+	return func(s *service) {
+		s.users = repository.NewUsersRepositorySqlite(db)
+		s.posts = repository.NewPostsRepositorySqlite(db)
+	}
+}
+
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.users = repository.NewUsersRepositoryPostgres(db)
+		s.posts = repository.NewPostsRepositoryPostgres(db)
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.users = memory.NewUsersRepository(store)
+		s.posts = memory.NewPostsRepository(store)
+	}
+}
+
+type GetProfileResponse struct {
+	User       *dto.User
+	Posts      []*dto.Post
+	NextCursor string
+}
+
+func (s *service) GetProfile(input *GetProfileInput) (*GetProfileResponse, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.Get(domain.GetUserInput{Key: "username", Value: input.Username})
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := s.posts.GetAllForUser(domain.GetAllPostsForUserInput{
+		UserID:         user.ID,
+		SortedByNewest: true,
+		Cursor:         input.Cursor,
+		Limit:          pagination.DefaultLimit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	posts, nextCursor := pagination.Paginate(posts, pagination.DefaultLimit, postCursor)
+
+	return &GetProfileResponse{User: user, Posts: posts, NextCursor: nextCursor}, nil
+}
+
+func postCursor(post *dto.Post) *pagination.Cursor {
+	return &pagination.Cursor{Created: post.Created, ID: post.ID}
+}
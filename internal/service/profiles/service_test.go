@@ -0,0 +1,93 @@
+package profiles
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	"github.com/itelman/forum/internal/service/profiles/domain"
+	"github.com/itelman/forum/pkg/pagination"
+)
+
+// newTestService seeds posts 1 and 3 by alice, post 2 by bob and a pending
+// post 4 by alice.
+func newTestService(t *testing.T) (*service, *memory.Store) {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice", "bob", "carol")
+
+	for _, userId := range []int{1, 2, 1, 1} {
+		memorytest.Post(t, store, userId, "Hello world")
+	}
+
+	memorytest.Pending(t, store, 4)
+
+	return NewService(WithMemoryStore(store)), store
+}
+
+func TestGetProfile(t *testing.T) {
+	tests := []struct {
+		name      string
+		username  string
+		wantErr   error
+		wantUser  string
+		wantPosts string
+	}{
+		{"alice", "alice", nil, "alice", "[3 1]"},
+		{"with at sign", " @bob", nil, "bob", "[2]"},
+		{"no posts", "carol", nil, "carol", "[]"},
+		{"missing", "dave", domain.ErrUserNotFound, "", ""},
+		{"blank", " @", domain.ErrProfilesBadRequest, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+
+			resp, err := svc.GetProfile(&GetProfileInput{Username: tt.username})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if resp.User.Username != tt.wantUser {
+				t.Errorf("got user %q, want %q", resp.User.Username, tt.wantUser)
+			}
+			if got := memorytest.PostIDs(resp.Posts); got != tt.wantPosts || resp.NextCursor != "" {
+				t.Errorf("got posts %s and cursor %q, want %s", got, resp.NextCursor, tt.wantPosts)
+			}
+		})
+	}
+}
+
+func TestGetProfileCursor(t *testing.T) {
+	svc, store := newTestService(t)
+
+	for i := 0; i < pagination.DefaultLimit; i++ {
+		memorytest.Post(t, store, 2, "Hello again")
+	}
+
+	first, err := svc.GetProfile(&GetProfileInput{Username: "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cursor, err := pagination.ParseCursor(first.NextCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := svc.GetProfile(&GetProfileInput{Username: "bob", Cursor: cursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(first.Posts) != pagination.DefaultLimit || memorytest.PostIDs(second.Posts) != "[2]" || second.NextCursor != "" {
+		t.Errorf("got pages of %d and %s, cursor %q", len(first.Posts), memorytest.PostIDs(second.Posts), second.NextCursor)
+	}
+}
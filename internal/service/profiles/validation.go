@@ -0,0 +1,22 @@
+package profiles
+
+import (
+	"strings"
+
+	"github.com/itelman/forum/internal/service/profiles/domain"
+	"github.com/itelman/forum/pkg/pagination"
+)
+
+type GetProfileInput struct {
+	Username string
+	Cursor   *pagination.Cursor
+}
+
+func (i *GetProfileInput) validate() error {
+	i.Username = strings.TrimPrefix(strings.TrimSpace(i.Username), "@")
+	if len(i.Username) == 0 {
+		return domain.ErrProfilesBadRequest
+	}
+
+	return nil
+}
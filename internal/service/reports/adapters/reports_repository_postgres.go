@@ -0,0 +1,145 @@
+package adapters
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/reports/domain"
+	"github.com/lib/pq"
+)
+
+type ReportsRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewReportsRepositoryPostgres(db *sql.DB) *ReportsRepositoryPostgres {
+	return &ReportsRepositoryPostgres{db}
+}
+
+func (r *ReportsRepositoryPostgres) Create(input domain.CreateReportInput) error {
+	query := "INSERT INTO reports (post_id, mod_id, content) VALUES ($1, $2, $3)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(input.PostID, input.ModeratorID, input.Content)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+		return domain.ErrReportExists
+	} else if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReportsRepositoryPostgres) Get(input domain.GetReportInput) (*dto.Report, error) {
+	query := "SELECT reports.id, posts.id, posts.title, users.id, users.username, reports.content, reports.admin_review, reports.created, reports.reviewed FROM reports INNER JOIN posts ON reports.post_id = posts.id INNER JOIN users ON reports.mod_id = users.id WHERE reports.id = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	report := &dto.Report{Post: &dto.Post{}, Moderator: &dto.User{}}
+	var reviewSql sql.NullString
+	var reviewedSql sql.NullTime
+	if err := stmt.QueryRow(input.ID).Scan(
+		&report.ID,
+		&report.Post.ID,
+		&report.Post.Title,
+		&report.Moderator.ID,
+		&report.Moderator.Username,
+		&report.Content,
+		&reviewSql,
+		&report.Created,
+		&reviewedSql,
+	); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrReportNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if reviewSql.Valid {
+		report.AdminReview = reviewSql.String
+	}
+
+	if reviewedSql.Valid {
+		report.Reviewed = reviewedSql.Time
+	}
+
+	return report, nil
+}
+
+func (r *ReportsRepositoryPostgres) GetAll(input domain.GetAllReportsInput) ([]*dto.Report, error) {
+	query := "SELECT reports.id, posts.id, posts.title, users.id, users.username, reports.content, reports.admin_review, reports.created, reports.reviewed FROM reports INNER JOIN posts ON reports.post_id = posts.id INNER JOIN users ON reports.mod_id = users.id WHERE (reports.mod_id = $1 OR $2 = -1)"
+	if input.SortedByNewest {
+		query += " ORDER BY reports.created DESC"
+	}
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(input.ModeratorID, input.ModeratorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reports := []*dto.Report{}
+	for rows.Next() {
+		report := &dto.Report{Post: &dto.Post{}, Moderator: &dto.User{}}
+		var reviewSql sql.NullString
+		var reviewedSql sql.NullTime
+
+		if err := rows.Scan(
+			&report.ID,
+			&report.Post.ID,
+			&report.Post.Title,
+			&report.Moderator.ID,
+			&report.Moderator.Username,
+			&report.Content,
+			&reviewSql,
+			&report.Created,
+			&reviewedSql,
+		); err != nil {
+			return nil, err
+		}
+
+		if reviewSql.Valid {
+			report.AdminReview = reviewSql.String
+		}
+
+		if reviewedSql.Valid {
+			report.Reviewed = reviewedSql.Time
+		}
+
+		reports = append(reports, report)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+func (r *ReportsRepositoryPostgres) Review(input domain.ReviewReportInput) error {
+	query := "UPDATE reports SET admin_review = $1, reviewed = (CURRENT_TIMESTAMP AT TIME ZONE 'UTC') WHERE id = $2"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.AdminReview, input.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package reports
+
+import (
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/reports/domain"
+	"github.com/itelman/forum/pkg/validator"
+	"net/http"
+	"strconv"
+)
+
+func DecodeCreateReport(r *http.Request) (interface{}, error) {
+	postId, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		return nil, domain.ErrReportsBadRequest
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return nil, domain.ErrReportsBadRequest
+	}
+
+	return &CreateReportInput{
+		PostID:      postId,
+		ModeratorID: dto.GetAuthUser(r).ID,
+		Content:     r.PostForm.Get("content"),
+		Errors:      make(validator.Errors),
+	}, nil
+}
+
+func DecodeGetAllModeratorReports(r *http.Request) interface{} {
+	return &GetAllModeratorReportsInput{dto.GetAuthUser(r).ID}
+}
+
+func DecodeReviewReport(r *http.Request) (interface{}, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, domain.ErrReportsBadRequest
+	}
+
+	id, err := strconv.Atoi(r.PostForm.Get("report_id"))
+	if err != nil {
+		return nil, domain.ErrReportsBadRequest
+	}
+
+	return &ReviewReportInput{
+		ID:          id,
+		AdminReview: r.PostForm.Get("admin_review"),
+		Errors:      make(validator.Errors),
+	}, nil
+}
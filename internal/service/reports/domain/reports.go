@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"github.com/itelman/forum/internal/dto"
+)
+
+type ReportsRepository interface {
+	Create(input CreateReportInput) error
+	Get(input GetReportInput) (*dto.Report, error)
+	GetAll(input GetAllReportsInput) ([]*dto.Report, error)
+	Review(input ReviewReportInput) error
+}
+
+type CreateReportInput struct {
+	PostID      int
+	ModeratorID int
+	Content     string
+}
+
+type GetReportInput struct {
+	ID int
+}
+
+type GetAllReportsInput struct {
+	ModeratorID    int
+	SortedByNewest bool
+}
+
+type ReviewReportInput struct {
+	ID          int
+	AdminReview string
+}
+
+var (
+	ErrReportsBadRequest = errors.New("REPORTS: bad request")
+	ErrReportNotFound    = errors.New("DATABASE: Report not found")
+	ErrReportExists      = errors.New("DATABASE: Report exists")
+	ErrReportReviewed    = errors.New("REPORTS: report already reviewed")
+)
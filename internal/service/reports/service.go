@@ -0,0 +1,133 @@
+package reports
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/service/reports/adapters"
+	"github.com/itelman/forum/internal/service/reports/domain"
+)
+
+type Service interface {
+	CreateReport(input *CreateReportInput) error
+	GetAllReports() (*GetAllReportsResponse, error)
+	GetAllModeratorReports(input *GetAllModeratorReportsInput) (*GetAllReportsResponse, error)
+	ReviewReport(input *ReviewReportInput) error
+}
+
+type service struct {
+	reports domain.ReportsRepository
+	posts   domain.PostsRepository
+}
+
+func NewService(opts ...Option) *service {
+	svc := &service{}
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	return svc
+}
+
+type Option func(*service)
+
+func WithSqlite(db *sql.DB) Option {
+	return func(s *service) {
+		s.reports = adapters.NewReportsRepositorySqlite(db)
+		s.posts = repository.NewPostsRepositorySqlite(db)
+	}
+}
+
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.reports = adapters.NewReportsRepositoryPostgres(db)
+		s.posts = repository.NewPostsRepositoryPostgres(db)
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.reports = memory.NewReportsRepository(store)
+		s.posts = memory.NewPostsRepository(store)
+	}
+}
+
+func (s *service) CreateReport(input *CreateReportInput) error {
+	if err := input.validate(); err != nil {
+		return err
+	}
+
+	if _, err := s.posts.Get(domain.GetPostInput{ID: input.PostID}); err != nil {
+		return err
+	}
+
+	if err := s.reports.Create(domain.CreateReportInput{
+		PostID:      input.PostID,
+		ModeratorID: input.ModeratorID,
+		Content:     input.Content,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type GetAllReportsResponse struct {
+	Reports []*dto.Report
+}
+
+func (s *service) GetAllReports() (*GetAllReportsResponse, error) {
+	reports, err := s.reports.GetAll(domain.GetAllReportsInput{
+		ModeratorID:    -1,
+		SortedByNewest: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetAllReportsResponse{Reports: reports}, nil
+}
+
+func (s *service) GetAllModeratorReports(input *GetAllModeratorReportsInput) (*GetAllReportsResponse, error) {
+	reports, err := s.reports.GetAll(domain.GetAllReportsInput{
+		ModeratorID:    input.ModeratorID,
+		SortedByNewest: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetAllReportsResponse{Reports: reports}, nil
+}
+
+func (s *service) ReviewReport(input *ReviewReportInput) error {
+	if err := input.validate(); err != nil {
+		return err
+	}
+
+	report, err := s.reports.Get(domain.GetReportInput{ID: input.ID})
+	if err != nil {
+		return err
+	}
+
+	if len(report.AdminReview) != 0 {
+		return domain.ErrReportReviewed
+	}
+
+	if err := s.reports.Review(domain.ReviewReportInput{
+		ID:          input.ID,
+		AdminReview: input.AdminReview,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
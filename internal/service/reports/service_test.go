@@ -0,0 +1,144 @@
+package reports
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	"github.com/itelman/forum/internal/service/reports/domain"
+	"github.com/itelman/forum/pkg/validator"
+)
+
+const (
+	alice = 1
+	bob   = 2
+	carol = 3
+)
+
+// newTestService seeds posts 1-3 by alice, with bob reporting posts 1 and 2
+// (reports 1 and 2) and carol reporting post 3 (report 3).
+func newTestService(t *testing.T) *service {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice", "bob", "carol")
+	store.SetUserRole(bob, dto.RoleModerator)
+	store.SetUserRole(carol, dto.RoleModerator)
+
+	svc := NewService(WithMemoryStore(store))
+	for _, modId := range []int{bob, bob, carol} {
+		postId := memorytest.Post(t, store, alice, "Hello world")
+
+		if err := svc.CreateReport(&CreateReportInput{PostID: postId, ModeratorID: modId, Content: "Spam", Errors: make(validator.Errors)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return svc
+}
+
+func reportIDs(reports []*dto.Report) string {
+	ids := make([]int, len(reports))
+	for i, report := range reports {
+		ids[i] = report.ID
+	}
+
+	return fmt.Sprint(ids)
+}
+
+func TestCreateReport(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   CreateReportInput
+		wantErr error
+	}{
+		{"empty reason", CreateReportInput{PostID: 1, ModeratorID: carol, Content: "  "}, domain.ErrReportsBadRequest},
+		{"untrimmed reason", CreateReportInput{PostID: 1, ModeratorID: carol, Content: " Spam"}, domain.ErrReportsBadRequest},
+		{"missing post", CreateReportInput{PostID: 9, ModeratorID: carol, Content: "Spam"}, domain.ErrPostNotFound},
+		{"already reported", CreateReportInput{PostID: 1, ModeratorID: carol, Content: "Spam"}, domain.ErrReportExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(t)
+
+			input := tt.input
+			input.Errors = make(validator.Errors)
+			if err := svc.CreateReport(&input); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetAllReports(t *testing.T) {
+	svc := newTestService(t)
+
+	resp, err := svc.GetAllReports()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reportIDs(resp.Reports); got != "[3 2 1]" {
+		t.Fatalf("reports = %s, want [3 2 1]", got)
+	}
+
+	tests := []struct {
+		name  string
+		modId int
+		want  string
+	}{
+		{"bob", bob, "[2 1]"},
+		{"carol", carol, "[3]"},
+		{"no reports", alice, "[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := svc.GetAllModeratorReports(&GetAllModeratorReportsInput{ModeratorID: tt.modId})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := reportIDs(resp.Reports); got != tt.want {
+				t.Fatalf("reports = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReviewReport(t *testing.T) {
+	svc := newTestService(t)
+
+	tests := []struct {
+		name    string
+		input   ReviewReportInput
+		wantErr error
+	}{
+		{"empty review", ReviewReportInput{ID: 1, AdminReview: ""}, domain.ErrReportsBadRequest},
+		{"missing report", ReviewReportInput{ID: 9, AdminReview: "Removed"}, domain.ErrReportNotFound},
+		{"review", ReviewReportInput{ID: 1, AdminReview: "Removed"}, nil},
+		{"already reviewed", ReviewReportInput{ID: 1, AdminReview: "Kept"}, domain.ErrReportReviewed},
+	}
+
+	// The cases run in order against the same service.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := tt.input
+			input.Errors = make(validator.Errors)
+			if err := svc.ReviewReport(&input); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	report, err := svc.reports.Get(domain.GetReportInput{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.AdminReview != "Removed" || report.Reviewed.IsZero() {
+		t.Fatalf("review = %q at %v, want %q", report.AdminReview, report.Reviewed, "Removed")
+	}
+}
@@ -0,0 +1,48 @@
+package reports
+
+import (
+	"github.com/itelman/forum/internal/service/reports/domain"
+	"github.com/itelman/forum/pkg/validator"
+	"strings"
+)
+
+type CreateReportInput struct {
+	PostID      int
+	ModeratorID int
+	Content     string
+	Errors      validator.Errors
+}
+
+func (i *CreateReportInput) validate() error {
+	if len(strings.TrimSpace(i.Content)) == 0 || i.Content != strings.TrimSpace(i.Content) {
+		i.Errors.Add("content", validator.ErrInputRequired("reason"))
+	}
+
+	if len(i.Errors) != 0 {
+		return domain.ErrReportsBadRequest
+	}
+
+	return nil
+}
+
+type GetAllModeratorReportsInput struct {
+	ModeratorID int
+}
+
+type ReviewReportInput struct {
+	ID          int
+	AdminReview string
+	Errors      validator.Errors
+}
+
+func (i *ReviewReportInput) validate() error {
+	if len(strings.TrimSpace(i.AdminReview)) == 0 || i.AdminReview != strings.TrimSpace(i.AdminReview) {
+		i.Errors.Add("admin_review", validator.ErrInputRequired("review"))
+	}
+
+	if len(i.Errors) != 0 {
+		return domain.ErrReportsBadRequest
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package adapters
+
+import (
+	"database/sql"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/service/search/domain"
+)
+
+type CommentsRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewCommentsRepositoryPostgres(db *sql.DB) *CommentsRepositoryPostgres {
+	return &CommentsRepositoryPostgres{db}
+}
+
+func (r *CommentsRepositoryPostgres) Search(input domain.SearchInput) ([]*dto.SearchResult, error) {
+	match := tsQuery(input.Match)
+	if len(match) == 0 {
+		return []*dto.SearchResult{}, nil
+	}
+
+	baseQuery := "SELECT comments.id, posts.id, posts.title, users.id, users.username, comments.created, ts_headline('english', comments.content, q, " + headlineSnippet + "), -ts_rank(comments.search, q) FROM comments INNER JOIN posts ON comments.post_id = posts.id INNER JOIN users ON comments.user_id = users.id CROSS JOIN to_tsquery('english', ?) AS q WHERE comments.search @@ q AND NOT EXISTS (SELECT 1 FROM pending_posts WHERE pending_posts.post_id = posts.id)"
+
+	clauses, clauseArgs := searchClausesPostgres("comments.created", input)
+	baseQuery += clauses + " ORDER BY ts_rank(comments.search, q) DESC LIMIT ?"
+
+	args := append([]interface{}{match}, clauseArgs...)
+	args = append(args, input.Limit)
+
+	stmt, err := r.db.Prepare(repository.Rebind(baseQuery))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*dto.SearchResult{}
+	for rows.Next() {
+		result := &dto.SearchResult{Post: &dto.Post{}, Comment: &dto.Comment{User: &dto.User{}}}
+		var title, snippet string
+
+		if err := rows.Scan(
+			&result.Comment.ID,
+			&result.Post.ID,
+			&title,
+			&result.Comment.User.ID,
+			&result.Comment.User.Username,
+			&result.Comment.Created,
+			&snippet,
+			&result.Rank,
+		); err != nil {
+			return nil, err
+		}
+
+		result.Comment.PostID = result.Post.ID
+		result.Post.Title = title
+		result.Title = markup(title)
+		result.Snippet = markup(snippet)
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,68 @@
+package adapters
+
+import (
+	"database/sql"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/search/domain"
+)
+
+type CommentsRepositorySqlite struct {
+	db *sql.DB
+}
+
+func NewCommentsRepositorySqlite(db *sql.DB) *CommentsRepositorySqlite {
+	return &CommentsRepositorySqlite{db}
+}
+
+func (r *CommentsRepositorySqlite) Search(input domain.SearchInput) ([]*dto.SearchResult, error) {
+	baseQuery := "SELECT comments.id, posts.id, posts.title, users.id, users.username, comments.created, snippet(comments_fts, 0, " + matchStart + ", " + matchEnd + ", '…', 24), bm25(comments_fts) FROM comments_fts INNER JOIN comments ON comments_fts.rowid = comments.id INNER JOIN posts ON comments.post_id = posts.id INNER JOIN users ON comments.user_id = users.id WHERE comments_fts MATCH ? AND NOT EXISTS (SELECT 1 FROM pending_posts WHERE pending_posts.post_id = posts.id)"
+
+	clauses, clauseArgs := searchClauses("comments.created", input)
+	baseQuery += clauses + " ORDER BY bm25(comments_fts) LIMIT ?"
+
+	args := append([]interface{}{input.Match}, clauseArgs...)
+	args = append(args, input.Limit)
+
+	stmt, err := r.db.Prepare(baseQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*dto.SearchResult{}
+	for rows.Next() {
+		result := &dto.SearchResult{Post: &dto.Post{}, Comment: &dto.Comment{User: &dto.User{}}}
+		var title, snippet string
+
+		if err := rows.Scan(
+			&result.Comment.ID,
+			&result.Post.ID,
+			&title,
+			&result.Comment.User.ID,
+			&result.Comment.User.Username,
+			&result.Comment.Created,
+			&snippet,
+			&result.Rank,
+		); err != nil {
+			return nil, err
+		}
+
+		result.Comment.PostID = result.Post.ID
+		result.Post.Title = title
+		result.Title = markup(title)
+		result.Snippet = markup(snippet)
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,74 @@
+package adapters
+
+import (
+	"database/sql"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/service/search/domain"
+)
+
+type PostsRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewPostsRepositoryPostgres(db *sql.DB) *PostsRepositoryPostgres {
+	return &PostsRepositoryPostgres{db}
+}
+
+// Search ranks title matches ten times higher than content matches, like the
+// bm25() weights of the SQLite repository.
+func (r *PostsRepositoryPostgres) Search(input domain.SearchInput) ([]*dto.SearchResult, error) {
+	match := tsQuery(input.Match)
+	if len(match) == 0 {
+		return []*dto.SearchResult{}, nil
+	}
+
+	baseQuery := "SELECT posts.id, users.id, users.username, posts.created, ts_headline('english', posts.title, q, " + headlineTitle + "), ts_headline('english', posts.content, q, " + headlineSnippet + "), -ts_rank('{0.1, 0.1, 0.1, 1.0}', posts.search, q) FROM posts INNER JOIN users ON posts.user_id = users.id CROSS JOIN to_tsquery('english', ?) AS q WHERE posts.search @@ q AND NOT EXISTS (SELECT 1 FROM pending_posts WHERE pending_posts.post_id = posts.id)"
+
+	clauses, clauseArgs := searchClausesPostgres("posts.created", input)
+	baseQuery += clauses + " ORDER BY ts_rank('{0.1, 0.1, 0.1, 1.0}', posts.search, q) DESC LIMIT ?"
+
+	args := append([]interface{}{match}, clauseArgs...)
+	args = append(args, input.Limit)
+
+	stmt, err := r.db.Prepare(repository.Rebind(baseQuery))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*dto.SearchResult{}
+	for rows.Next() {
+		result := &dto.SearchResult{Post: &dto.Post{User: &dto.User{}}}
+		var title, snippet string
+
+		if err := rows.Scan(
+			&result.Post.ID,
+			&result.Post.User.ID,
+			&result.Post.User.Username,
+			&result.Post.Created,
+			&title,
+			&snippet,
+			&result.Rank,
+		); err != nil {
+			return nil, err
+		}
+
+		result.Post.Title = plain(title)
+		result.Title = markup(title)
+		result.Snippet = markup(snippet)
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,66 @@
+package adapters
+
+import (
+	"database/sql"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/search/domain"
+)
+
+type PostsRepositorySqlite struct {
+	db *sql.DB
+}
+
+func NewPostsRepositorySqlite(db *sql.DB) *PostsRepositorySqlite {
+	return &PostsRepositorySqlite{db}
+}
+
+func (r *PostsRepositorySqlite) Search(input domain.SearchInput) ([]*dto.SearchResult, error) {
+	baseQuery := "SELECT posts.id, users.id, users.username, posts.created, highlight(posts_fts, 0, " + matchStart + ", " + matchEnd + "), snippet(posts_fts, 1, " + matchStart + ", " + matchEnd + ", '…', 24), bm25(posts_fts, 10.0, 1.0) FROM posts_fts INNER JOIN posts ON posts_fts.rowid = posts.id INNER JOIN users ON posts.user_id = users.id WHERE posts_fts MATCH ? AND NOT EXISTS (SELECT 1 FROM pending_posts WHERE pending_posts.post_id = posts.id)"
+
+	clauses, clauseArgs := searchClauses("posts.created", input)
+	baseQuery += clauses + " ORDER BY bm25(posts_fts, 10.0, 1.0) LIMIT ?"
+
+	args := append([]interface{}{input.Match}, clauseArgs...)
+	args = append(args, input.Limit)
+
+	stmt, err := r.db.Prepare(baseQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*dto.SearchResult{}
+	for rows.Next() {
+		result := &dto.SearchResult{Post: &dto.Post{User: &dto.User{}}}
+		var title, snippet string
+
+		if err := rows.Scan(
+			&result.Post.ID,
+			&result.Post.User.ID,
+			&result.Post.User.Username,
+			&result.Post.Created,
+			&title,
+			&snippet,
+			&result.Rank,
+		); err != nil {
+			return nil, err
+		}
+
+		result.Post.Title = plain(title)
+		result.Title = markup(title)
+		result.Snippet = markup(snippet)
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
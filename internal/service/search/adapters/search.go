@@ -0,0 +1,61 @@
+package adapters
+
+import (
+	"html"
+	"html/template"
+	"strings"
+
+	"github.com/itelman/forum/internal/service/search/domain"
+)
+
+// Matches are wrapped in these control characters by highlight() and snippet()
+// so that the text can be escaped before the <mark> tags are added.
+const (
+	matchStart = "char(2)"
+	matchEnd   = "char(3)"
+)
+
+var (
+	markReplacer  = strings.NewReplacer("\x02", "<mark>", "\x03", "</mark>")
+	plainReplacer = strings.NewReplacer("\x02", "", "\x03", "")
+)
+
+func markup(text string) template.HTML {
+	return template.HTML(markReplacer.Replace(html.EscapeString(text)))
+}
+
+func plain(text string) string {
+	return plainReplacer.Replace(text)
+}
+
+func searchClauses(createdColumn string, input domain.SearchInput) (string, []interface{}) {
+	authorClause := " users.username = ? COLLATE NOCASE"
+	catgClause := " EXISTS (SELECT 1 FROM post_categories INNER JOIN categories ON post_categories.category_id = categories.id WHERE post_categories.post_id = posts.id AND categories.name = ? COLLATE NOCASE)"
+	afterClause := " DATE(" + createdColumn + ") > ?"
+	beforeClause := " DATE(" + createdColumn + ") < ?"
+
+	query := ""
+	args := make([]interface{}, 0)
+
+	if len(input.Author) != 0 {
+		query += " AND" + authorClause
+		args = append(args, input.Author)
+	}
+
+	if len(input.Category) != 0 {
+		query += " AND" + catgClause
+		args = append(args, input.Category)
+	}
+
+	if len(input.After) != 0 {
+		query += " AND" + afterClause
+		args = append(args, input.After)
+	}
+
+	if len(input.Before) != 0 {
+		query += " AND" + beforeClause
+		args = append(args, input.Before)
+	}
+
+	return query, args
+}
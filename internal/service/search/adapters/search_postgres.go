@@ -0,0 +1,72 @@
+package adapters
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/itelman/forum/internal/service/search/domain"
+)
+
+// ts_headline() options wrapping matches like highlight() and snippet() do.
+const (
+	headlineTitle   = "'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', HighlightAll=true'"
+	headlineSnippet = "'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxWords=24, MinWords=12, MaxFragments=1'"
+)
+
+var phraseRx = regexp.MustCompile(`"((?:[^"]|"")*)"(\*?)`)
+
+// tsQuery turns the FTS5 match expression built by the search service into a
+// tsquery: the words of a phrase must follow each other, and all phrases
+// must match. It returns "" when there is nothing to search for.
+func tsQuery(match string) string {
+	phrases := []string{}
+	for _, m := range phraseRx.FindAllStringSubmatch(match, -1) {
+		words := strings.FieldsFunc(m[1], func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if len(words) == 0 {
+			continue
+		}
+
+		if len(m[2]) != 0 {
+			words[len(words)-1] += ":*"
+		}
+
+		phrases = append(phrases, "("+strings.Join(words, " <-> ")+")")
+	}
+
+	return strings.Join(phrases, " & ")
+}
+
+func searchClausesPostgres(createdColumn string, input domain.SearchInput) (string, []interface{}) {
+	authorClause := " LOWER(users.username) = LOWER(?)"
+	catgClause := " EXISTS (SELECT 1 FROM post_categories INNER JOIN categories ON post_categories.category_id = categories.id WHERE post_categories.post_id = posts.id AND LOWER(categories.name) = LOWER(?))"
+	afterClause := " " + createdColumn + "::date > ?"
+	beforeClause := " " + createdColumn + "::date < ?"
+
+	query := ""
+	args := make([]interface{}, 0)
+
+	if len(input.Author) != 0 {
+		query += " AND" + authorClause
+		args = append(args, input.Author)
+	}
+
+	if len(input.Category) != 0 {
+		query += " AND" + catgClause
+		args = append(args, input.Category)
+	}
+
+	if len(input.After) != 0 {
+		query += " AND" + afterClause
+		args = append(args, input.After)
+	}
+
+	if len(input.Before) != 0 {
+		query += " AND" + beforeClause
+		args = append(args, input.Before)
+	}
+
+	return query, args
+}
@@ -0,0 +1,13 @@
+package search
+
+import (
+	"github.com/itelman/forum/pkg/validator"
+	"net/http"
+)
+
+func DecodeSearch(r *http.Request) interface{} {
+	return &SearchInput{
+		Query:  r.URL.Query().Get("q"),
+		Errors: make(validator.Errors),
+	}
+}
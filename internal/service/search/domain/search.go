@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"errors"
+	"github.com/itelman/forum/internal/dto"
+)
+
+type PostsRepository interface {
+	Search(input SearchInput) ([]*dto.SearchResult, error)
+}
+
+type CommentsRepository interface {
+	Search(input SearchInput) ([]*dto.SearchResult, error)
+}
+
+type SearchInput struct {
+	Match    string
+	Author   string
+	Category string
+	After    string
+	Before   string
+	Limit    int
+}
+
+var (
+	ErrSearchBadRequest = errors.New("SEARCH: bad request")
+)
@@ -0,0 +1,101 @@
+package search
+
+import (
+	"database/sql"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/service/search/adapters"
+	"github.com/itelman/forum/internal/service/search/domain"
+	"sort"
+)
+
+const resultsLimit = 50
+
+type Service interface {
+	Search(input *SearchInput) (*SearchResponse, error)
+}
+
+type service struct {
+	posts    domain.PostsRepository
+	comments domain.CommentsRepository
+}
+
+func NewService(opts ...Option) *service {
+	svc := &service{}
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	return svc
+}
+
+type Option func(*service)
+
+func WithSqlite(db *sql.DB) Option {
+	return func(s *service) {
+		s.posts = adapters.NewPostsRepositorySqlite(db)
+		s.comments = adapters.NewCommentsRepositorySqlite(db)
+	}
+}
+
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.posts = adapters.NewPostsRepositoryPostgres(db)
+		s.comments = adapters.NewCommentsRepositoryPostgres(db)
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+// It matches whole words instead of stems, and ranks by how many matched.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.posts = memory.NewSearchPostsRepository(store)
+		s.comments = memory.NewSearchCommentsRepository(store)
+	}
+}
+
+type SearchResponse struct {
+	Results []*dto.SearchResult
+}
+
+func (s *service) Search(input *SearchInput) (*SearchResponse, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	searchInput := domain.SearchInput{
+		Match:    input.match,
+		Author:   input.author,
+		Category: input.category,
+		After:    input.after,
+		Before:   input.before,
+		Limit:    resultsLimit,
+	}
+
+	posts, err := s.posts.Search(searchInput)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.comments.Search(searchInput)
+	if err != nil {
+		return nil, err
+	}
+
+	// bm25 scores are negative, the best match has the lowest rank.
+	results := append(posts, comments...)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Rank < results[j].Rank
+	})
+
+	if len(results) > resultsLimit {
+		results = results[:resultsLimit]
+	}
+
+	return &SearchResponse{Results: results}, nil
+}
@@ -0,0 +1,252 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	"github.com/itelman/forum/internal/service/search/domain"
+	"github.com/itelman/forum/pkg/validator"
+)
+
+const (
+	alice = 1
+	bob   = 2
+)
+
+// newTestService seeds two posts in Programming by alice, a music post by
+// bob that is pending approval, and comments by bob. The first post is 48
+// hours old.
+func newTestService(t *testing.T) *service {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice", "bob")
+
+	posts := memory.NewPostsRepository(store)
+	categories := memory.NewPostCategoriesRepository(store)
+	comments := memory.NewCommentsRepository(store)
+	for _, p := range []struct {
+		userId   int
+		title    string
+		content  string
+		category int
+	}{
+		{alice, "Learning Go", "Goroutines & channels make concurrency easy.", 5},
+		{alice, "SQLite tips", "Full text search with FTS5 in Go.", 5},
+		{bob, "Guitar chords", "Go learn the chords first.", 1},
+	} {
+		postId, err := posts.Create(nil, repository.CreatePostInput{UserID: p.userId, Title: p.title, Content: p.content})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := categories.Create(nil, repository.CreatePostCategoriesInput{PostID: postId, CategoriesID: []int{p.category}}); err != nil {
+			t.Fatal(err)
+		}
+
+		if postId == 1 {
+			store.Age(48 * time.Hour)
+		}
+	}
+	memorytest.Pending(t, store, 3)
+
+	for _, c := range []struct {
+		postId  int
+		content string
+	}{
+		{1, "Channels are great, thanks!"},
+		{3, "Channels on a guitar?"},
+	} {
+		if _, err := comments.Create(nil, repository.CreateCommentInput{PostID: c.postId, UserID: bob, Content: c.content}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return NewService(WithMemoryStore(store))
+}
+
+// resultIDs formats each result as p<post id> or c<comment id>, in order.
+func resultIDs(results []*dto.SearchResult) string {
+	ids := []string{}
+	for _, result := range results {
+		if result.Comment != nil {
+			ids = append(ids, fmt.Sprintf("c%d", result.Comment.ID))
+		} else {
+			ids = append(ids, fmt.Sprintf("p%d", result.Post.ID))
+		}
+	}
+
+	return strings.Join(ids, " ")
+}
+
+func TestSearch(t *testing.T) {
+	today := time.Now().UTC().Format(dateLayout)
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr error
+		want    string
+	}{
+		{"title ranks first", "go", nil, "p1 p2"},
+		{"comments", "channels", nil, "p1 c1"},
+		{"all words", "go fts5", nil, "p2"},
+		{"phrase", `"text search"`, nil, "p2"},
+		{"phrase out of order", `"search text"`, nil, ""},
+		{"prefix", "gorout*", nil, "p1"},
+		{"case insensitive", "SQLITE", nil, "p2"},
+		{"author", "go author:ALICE", nil, "p1 p2"},
+		{"comment author", "channels author:bob", nil, "c1"},
+		{"category", "go category:programming", nil, "p1 p2"},
+		{"other category", "go category:music", nil, ""},
+		{"before", "go before:" + today, nil, "p1"},
+		{"after", "go after:" + time.Now().UTC().Add(-72*time.Hour).Format(dateLayout), nil, "p1 p2"},
+		{"pending excluded", "guitar", nil, ""},
+		{"no match", "python", nil, ""},
+		{"empty", "   ", domain.ErrSearchBadRequest, ""},
+		{"operators only", "author:alice", domain.ErrSearchBadRequest, ""},
+		{"bad date", "go after:yesterday", domain.ErrSearchBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(t)
+
+			resp, err := svc.Search(&SearchInput{Query: tt.query, Errors: make(validator.Errors)})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := resultIDs(resp.Results); got != tt.want {
+				t.Errorf("results = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchMarkup(t *testing.T) {
+	svc := newTestService(t)
+
+	resp, err := svc.Search(&SearchInput{Query: "channels", Errors: make(validator.Errors)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	post := resp.Results[0]
+	if post.Title != "Learning Go" || post.Snippet != "Goroutines &amp; <mark>channels</mark> make concurrency easy." {
+		t.Errorf("post title %q, snippet %q", post.Title, post.Snippet)
+	}
+
+	comment := resp.Results[1]
+	if comment.Post.ID != 1 || comment.Comment.User.Username != "bob" || comment.Snippet != "<mark>Channels</mark> are great, thanks!" {
+		t.Errorf("comment %+v, snippet %q", comment.Comment, comment.Snippet)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		match    string
+		author   string
+		category string
+		after    string
+		before   string
+		wantErr  bool
+	}{
+		{"words", "go  sqlite", `"go" "sqlite"`, "", "", "", "", false},
+		{"phrase", `"full text" search`, `"full text" "search"`, "", "", "", "", false},
+		{"prefix", "gorout*", `"gorout"*`, "", "", "", "", false},
+		{"quoted prefix is literal", `"gorout*"`, `"gorout"`, "", "", "", "", false},
+		{"fts5 operators are quoted", "go OR NOT rust", `"go" "OR" "NOT" "rust"`, "", "", "", "", false},
+		{"fts5 syntax is quoted", "title:go (a) -b ^c", `"title:go" "(a)" "-b" "^c"`, "", "", "", "", false},
+		{"quote inside a word", `it"s`, `"it""s"`, "", "", "", "", false},
+		{"unclosed quote", `"go sqlite`, `"go sqlite"`, "", "", "", "", false},
+		{"punctuation only is dropped", "go ... !!", `"go"`, "", "", "", "", false},
+		{"operators", "go author:alice category:Books after:2024-01-01 before:2024-12-31", `"go"`, "alice", "Books", "2024-01-01", "2024-12-31", false},
+		{"operator case", "go AUTHOR:alice", `"go"`, "alice", "", "", "", false},
+		{"quoted operator value", `go category:"Books"`, `"go"`, "", "Books", "", "", false},
+		{"quoted operator is a phrase", `"author:alice" go`, `"author:alice" "go"`, "", "", "", "", false},
+		{"unknown operator", "lang:go", `"lang:go"`, "", "", "", "", false},
+		{"empty", "", "", "", "", "", "", true},
+		{"blank", " \t ", "", "", "", "", "", true},
+		{"too long", strings.Repeat("a", queryMaxLen+1), "", "", "", "", "", true},
+		{"operators only", "author:alice", "", "alice", "", "", "", true},
+		{"bad date", "go before:31-12-2024", `"go"`, "", "", "", "31-12-2024", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := &SearchInput{Query: tt.query, Errors: make(validator.Errors)}
+			input.parseQuery()
+
+			if gotErr := len(input.Errors) != 0; gotErr != tt.wantErr {
+				t.Fatalf("errors = %v, want errors %t", input.Errors, tt.wantErr)
+			}
+
+			got := []string{input.match, input.author, input.category, input.after, input.before}
+			want := []string{tt.match, tt.author, tt.category, tt.after, tt.before}
+			if fmt.Sprintf("%q", got) != fmt.Sprintf("%q", want) {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestSplitQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{}},
+		{"   ", []string{}},
+		{"go", []string{"go"}},
+		{" go \t sqlite\n", []string{"go", "sqlite"}},
+		{`"full text" search`, []string{`"full text"`, "search"}},
+		{`category:"Science Fiction" go`, []string{`category:"Science Fiction"`, "go"}},
+		{`"unclosed phrase`, []string{`"unclosed phrase`}},
+		{`a"b c"d`, []string{`a"b c"d`}},
+	}
+
+	for _, tt := range tests {
+		if got := splitQuery(tt.query); fmt.Sprintf("%q", got) != fmt.Sprintf("%q", tt.want) {
+			t.Errorf("splitQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestMatchTerm(t *testing.T) {
+	tests := []struct {
+		token string
+		want  string
+	}{
+		{"go", `"go"`},
+		{"go*", `"go"*`},
+		{`"go*"`, `"go"`},
+		{`"full text"`, `"full text"`},
+		{`it"s`, `"it""s"`},
+		{"NEAR", `"NEAR"`},
+		{"a-b", `"a-b"`},
+		{"日本", `"日本"`},
+		{"*", ""},
+		{`""`, ""},
+		{"...", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := matchTerm(tt.token); got != tt.want {
+			t.Errorf("matchTerm(%q) = %q, want %q", tt.token, got, tt.want)
+		}
+	}
+}
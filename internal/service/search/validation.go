@@ -0,0 +1,138 @@
+package search
+
+import (
+	"github.com/itelman/forum/internal/service/search/domain"
+	"github.com/itelman/forum/pkg/validator"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	queryMinLen = 1
+	queryMaxLen = 200
+
+	dateLayout = "2006-01-02"
+)
+
+var errInputDate = "Please enter dates as YYYY-MM-DD"
+
+type SearchInput struct {
+	Query    string
+	Errors   validator.Errors
+	match    string
+	author   string
+	category string
+	after    string
+	before   string
+}
+
+func (i *SearchInput) validate() error {
+	i.parseQuery()
+
+	if len(i.Errors) != 0 {
+		return domain.ErrSearchBadRequest
+	}
+
+	return nil
+}
+
+// parseQuery turns the query into an FTS5 match expression. Bare words and
+// "quoted phrases" must all match; a trailing * matches a prefix. The
+// author:, category:, after: and before: operators narrow the results.
+func (i *SearchInput) parseQuery() {
+	i.Query = strings.TrimSpace(i.Query)
+
+	if !(len(i.Query) >= queryMinLen && len(i.Query) <= queryMaxLen) {
+		i.Errors.Add("q", validator.ErrInputLength(queryMinLen, queryMaxLen))
+		return
+	}
+
+	terms := []string{}
+	for _, token := range splitQuery(i.Query) {
+		key, value, found := strings.Cut(token, ":")
+		if found && !strings.HasPrefix(token, `"`) {
+			value = strings.Trim(value, `"`)
+
+			switch strings.ToLower(key) {
+			case "author":
+				i.author = value
+				continue
+			case "category":
+				i.category = value
+				continue
+			case "after":
+				i.after = i.parseDate(value)
+				continue
+			case "before":
+				i.before = i.parseDate(value)
+				continue
+			}
+		}
+
+		if term := matchTerm(token); len(term) != 0 {
+			terms = append(terms, term)
+		}
+	}
+
+	if len(terms) == 0 {
+		i.Errors.Add("q", validator.ErrInputRequired("search term"))
+		return
+	}
+
+	i.match = strings.Join(terms, " ")
+}
+
+func (i *SearchInput) parseDate(value string) string {
+	if _, err := time.Parse(dateLayout, value); err != nil {
+		i.Errors.Add("q", errInputDate)
+	}
+
+	return value
+}
+
+func splitQuery(query string) []string {
+	tokens := []string{}
+
+	var token strings.Builder
+	quoted := false
+	for _, r := range query {
+		if r == '"' {
+			quoted = !quoted
+		}
+
+		if unicode.IsSpace(r) && !quoted {
+			if token.Len() != 0 {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+			continue
+		}
+
+		token.WriteRune(r)
+	}
+
+	if token.Len() != 0 {
+		tokens = append(tokens, token.String())
+	}
+
+	return tokens
+}
+
+func matchTerm(token string) string {
+	prefix := !strings.HasPrefix(token, `"`) && strings.HasSuffix(token, "*")
+	text := strings.Trim(token, `"*`)
+
+	if strings.IndexFunc(text, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}) == -1 {
+		return ""
+	}
+
+	term := `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+	if prefix {
+		term += "*"
+	}
+
+	return term
+}
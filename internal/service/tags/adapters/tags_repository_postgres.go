@@ -0,0 +1,87 @@
+package adapters
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/tags/domain"
+)
+
+type TagsRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewTagsRepositoryPostgres(db *sql.DB) *TagsRepositoryPostgres {
+	return &TagsRepositoryPostgres{db}
+}
+
+func (r *TagsRepositoryPostgres) Get(input domain.GetTagInput) (*dto.Tag, error) {
+	query := "SELECT id, name, created, (SELECT COUNT(*) FROM post_tags WHERE post_tags.tag_id = tags.id AND " + notPending + ") AS posts_count FROM tags WHERE name = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	tag := &dto.Tag{}
+	if err := stmt.QueryRow(input.Name).Scan(
+		&tag.ID,
+		&tag.Name,
+		&tag.Created,
+		&tag.PostsCount,
+	); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrTagNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (r *TagsRepositoryPostgres) GetAllByPrefix(input domain.GetAllTagsByPrefixInput) ([]*dto.Tag, error) {
+	query := "SELECT tags.id, tags.name, tags.created, COUNT(post_tags.post_id) AS posts_count FROM tags INNER JOIN post_tags ON post_tags.tag_id = tags.id WHERE substr(tags.name, 1, $1) = $2 AND " + notPending + " GROUP BY tags.id ORDER BY posts_count DESC, tags.name LIMIT $3"
+
+	return r.getAll(query, len(input.Prefix), input.Prefix, input.Limit)
+}
+
+func (r *TagsRepositoryPostgres) GetAllTrending(input domain.GetAllTrendingTagsInput) ([]*dto.Tag, error) {
+	query := "SELECT tags.id, tags.name, tags.created, COUNT(post_tags.post_id) AS posts_count FROM tags INNER JOIN post_tags ON post_tags.tag_id = tags.id WHERE post_tags.created >= $1 AND " + notPending + " GROUP BY tags.id ORDER BY posts_count DESC, tags.name LIMIT $2"
+
+	return r.getAll(query, input.Since.UTC().Format("2006-01-02 15:04:05"), input.Limit)
+}
+
+func (r *TagsRepositoryPostgres) getAll(query string, args ...interface{}) ([]*dto.Tag, error) {
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []*dto.Tag{}
+	for rows.Next() {
+		tag := &dto.Tag{}
+
+		if err := rows.Scan(
+			&tag.ID,
+			&tag.Name,
+			&tag.Created,
+			&tag.PostsCount,
+		); err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,25 @@
+package tags
+
+import (
+	"github.com/itelman/forum/internal/service/tags/domain"
+	"github.com/itelman/forum/pkg/pagination"
+	"net/http"
+)
+
+func DecodeGetTag(r *http.Request) (interface{}, error) {
+	cursor, err := pagination.ParseCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		return nil, domain.ErrTagsBadRequest
+	}
+
+	return &GetTagInput{
+		Name:   r.URL.Query().Get("name"),
+		Cursor: cursor,
+	}, nil
+}
+
+func DecodeAutocompleteTags(r *http.Request) interface{} {
+	return &AutocompleteTagsInput{
+		Prefix: r.URL.Query().Get("q"),
+	}
+}
@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+type PostsRepository interface {
+	GetAllForTag(input GetAllPostsForTagInput) ([]*dto.Post, error)
+}
+
+type GetAllPostsForTagInput = repository.GetAllPostsForTagInput
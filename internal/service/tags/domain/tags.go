@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"github.com/itelman/forum/internal/dto"
+	"time"
+)
+
+type TagsRepository interface {
+	Get(input GetTagInput) (*dto.Tag, error)
+	GetAllByPrefix(input GetAllTagsByPrefixInput) ([]*dto.Tag, error)
+	GetAllTrending(input GetAllTrendingTagsInput) ([]*dto.Tag, error)
+}
+
+type GetTagInput struct {
+	Name string
+}
+
+type GetAllTagsByPrefixInput struct {
+	Prefix string
+	Limit  int
+}
+
+type GetAllTrendingTagsInput struct {
+	Since time.Time
+	Limit int
+}
+
+var (
+	ErrTagsBadRequest = errors.New("TAGS: bad request")
+	ErrTagNotFound    = errors.New("DATABASE: Tag not found")
+)
@@ -0,0 +1,135 @@
+package tags
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/service/tags/adapters"
+	"github.com/itelman/forum/internal/service/tags/domain"
+	"github.com/itelman/forum/pkg/pagination"
+)
+
+const (
+	autocompleteLimit = 10
+	trendingLimit     = 10
+	trendingPeriod    = 7 * 24 * time.Hour
+)
+
+type Service interface {
+	GetTag(input *GetTagInput) (*GetTagResponse, error)
+	AutocompleteTags(input *AutocompleteTagsInput) (*GetAllTagsResponse, error)
+	GetTrendingTags() (*GetAllTagsResponse, error)
+}
+
+type service struct {
+	tags  domain.TagsRepository
+	posts domain.PostsRepository
+}
+
+func NewService(opts ...Option) *service {
+	svc := &service{}
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	return svc
+}
+
+type Option func(*service)
+
+func WithSqlite(db *sql.DB) Option {
+	return func(s *service) {
+		s.tags = adapters.NewTagsRepositorySqlite(db)
+		s.posts = repository.NewPostsRepositorySqlite(db)
+	}
+}
+
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.tags = adapters.NewTagsRepositoryPostgres(db)
+		s.posts = repository.NewPostsRepositoryPostgres(db)
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.tags = memory.NewTagsRepository(store)
+		s.posts = memory.NewPostsRepository(store)
+	}
+}
+
+type GetTagResponse struct {
+	Tag        *dto.Tag
+	Posts      []*dto.Post
+	NextCursor string
+}
+
+func (s *service) GetTag(input *GetTagInput) (*GetTagResponse, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	tag, err := s.tags.Get(domain.GetTagInput{Name: input.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := s.posts.GetAllForTag(domain.GetAllPostsForTagInput{
+		TagID:          tag.ID,
+		SortedByNewest: true,
+		Cursor:         input.Cursor,
+		Limit:          pagination.DefaultLimit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	posts, nextCursor := pagination.Paginate(posts, pagination.DefaultLimit, postCursor)
+
+	return &GetTagResponse{Tag: tag, Posts: posts, NextCursor: nextCursor}, nil
+}
+
+type GetAllTagsResponse struct {
+	Tags []*dto.Tag
+}
+
+func (s *service) AutocompleteTags(input *AutocompleteTagsInput) (*GetAllTagsResponse, error) {
+	if !input.validate() {
+		return &GetAllTagsResponse{Tags: []*dto.Tag{}}, nil
+	}
+
+	tags, err := s.tags.GetAllByPrefix(domain.GetAllTagsByPrefixInput{
+		Prefix: input.Prefix,
+		Limit:  autocompleteLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetAllTagsResponse{Tags: tags}, nil
+}
+
+func (s *service) GetTrendingTags() (*GetAllTagsResponse, error) {
+	tags, err := s.tags.GetAllTrending(domain.GetAllTrendingTagsInput{
+		Since: time.Now().Add(-trendingPeriod),
+		Limit: trendingLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetAllTagsResponse{Tags: tags}, nil
+}
+
+func postCursor(post *dto.Post) *pagination.Cursor {
+	return &pagination.Cursor{Created: post.Created, ID: post.ID}
+}
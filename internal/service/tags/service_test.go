@@ -0,0 +1,129 @@
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	postsdomain "github.com/itelman/forum/internal/service/posts/domain"
+	"github.com/itelman/forum/internal/service/tags/domain"
+)
+
+// newTestService seeds posts with these tags:
+//
+//	1: go, golang
+//	2: go, web
+//	3: go, gopher  (pending)
+//	4: web
+func newTestService(t *testing.T) *service {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice")
+
+	postTags := memory.NewPostTagsRepository(store)
+	for _, tags := range [][]string{{"go", "golang"}, {"go", "web"}, {"go", "gopher"}, {"web"}} {
+		postId := memorytest.Post(t, store, 1, "Hello world")
+
+		if err := postTags.Create(nil, postsdomain.CreatePostTagsInput{PostID: postId, Tags: tags}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	memorytest.Pending(t, store, 3)
+
+	return NewService(WithMemoryStore(store))
+}
+
+func tagCounts(tags []*dto.Tag) string {
+	counts := make([]string, len(tags))
+	for i, tag := range tags {
+		counts[i] = fmt.Sprintf("%s:%d", tag.Name, tag.PostsCount)
+	}
+
+	return fmt.Sprint(counts)
+}
+
+func TestGetTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantErr   error
+		wantCount int
+		wantPosts string
+	}{
+		{"go", "go", nil, 2, "[2 1]"},
+		{"any case", "WEB", nil, 2, "[4 2]"},
+		{"pending only", "gopher", nil, 0, "[]"},
+		{"missing", "rust", domain.ErrTagNotFound, 0, ""},
+		{"invalid", "c++", domain.ErrTagsBadRequest, 0, ""},
+		{"blank", "", domain.ErrTagsBadRequest, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(t)
+
+			resp, err := svc.GetTag(&GetTagInput{Name: tt.input})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if resp.Tag.PostsCount != tt.wantCount {
+				t.Errorf("got %d posts counted, want %d", resp.Tag.PostsCount, tt.wantCount)
+			}
+			if got := memorytest.PostIDs(resp.Posts); got != tt.wantPosts || resp.NextCursor != "" {
+				t.Errorf("got posts %s and cursor %q, want %s", got, resp.NextCursor, tt.wantPosts)
+			}
+		})
+	}
+}
+
+func TestAutocompleteTags(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		want   string
+	}{
+		{"prefix", "go", "[go:2 golang:1]"},
+		{"any case", "WE", "[web:2]"},
+		{"no match", "rust", "[]"},
+		{"invalid", "c++", "[]"},
+		{"blank", "", "[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(t)
+
+			resp, err := svc.AutocompleteTags(&AutocompleteTagsInput{Prefix: tt.prefix})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := tagCounts(resp.Tags); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTrendingTags(t *testing.T) {
+	svc := newTestService(t)
+
+	resp, err := svc.GetTrendingTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := tagCounts(resp.Tags), "[go:2 web:2 golang:1]"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
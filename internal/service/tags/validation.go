@@ -0,0 +1,36 @@
+package tags
+
+import (
+	"github.com/itelman/forum/internal/service/tags/domain"
+	"github.com/itelman/forum/pkg/pagination"
+	"github.com/itelman/forum/pkg/tags"
+)
+
+type GetTagInput struct {
+	Name   string
+	Cursor *pagination.Cursor
+}
+
+func (i *GetTagInput) validate() error {
+	name, err := tags.Normalize(i.Name)
+	if err != nil {
+		return domain.ErrTagsBadRequest
+	}
+	i.Name = name
+
+	return nil
+}
+
+type AutocompleteTagsInput struct {
+	Prefix string
+}
+
+func (i *AutocompleteTagsInput) validate() bool {
+	prefix, err := tags.Normalize(i.Prefix)
+	if err != nil {
+		return false
+	}
+	i.Prefix = prefix
+
+	return true
+}
@@ -0,0 +1,146 @@
+package adapters
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/tokens/domain"
+)
+
+type TokensRepositoryPostgres struct {
+	db *sql.DB
+}
+
+func NewTokensRepositoryPostgres(db *sql.DB) *TokensRepositoryPostgres {
+	return &TokensRepositoryPostgres{db}
+}
+
+func (r *TokensRepositoryPostgres) Create(input domain.CreateTokenInput) (int, error) {
+	query := "INSERT INTO api_tokens (user_id, name, token_hash, scope) VALUES ($1, $2, $3, $4) RETURNING id"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return -1, err
+	}
+	defer stmt.Close()
+
+	var id int
+	if err := stmt.QueryRow(input.UserID, input.Name, input.Hash, input.Scope).Scan(&id); err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
+
+func (r *TokensRepositoryPostgres) GetAll(input domain.GetAllTokensInput) ([]*dto.Token, error) {
+	query := "SELECT id, user_id, name, scope, last_used, created FROM api_tokens WHERE user_id = $1"
+	if input.SortedByNewest {
+		query += " ORDER BY created DESC"
+	}
+
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []*dto.Token{}
+	for rows.Next() {
+		token := &dto.Token{}
+		var lastUsed sql.NullTime
+
+		if err := rows.Scan(
+			&token.ID,
+			&token.UserID,
+			&token.Name,
+			&token.Scope,
+			&lastUsed,
+			&token.Created,
+		); err != nil {
+			return nil, err
+		}
+		token.LastUsed = lastUsed.Time
+
+		tokens = append(tokens, token)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (r *TokensRepositoryPostgres) GetByHash(input domain.GetTokenByHashInput) (*dto.Token, error) {
+	query := "SELECT id, user_id, name, scope, last_used, created FROM api_tokens WHERE token_hash = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	token := &dto.Token{}
+	var lastUsed sql.NullTime
+
+	if err := stmt.QueryRow(input.Hash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&token.Scope,
+		&lastUsed,
+		&token.Created,
+	); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrTokenNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	token.LastUsed = lastUsed.Time
+
+	return token, nil
+}
+
+func (r *TokensRepositoryPostgres) UpdateLastUsed(input domain.UpdateTokenLastUsedInput) error {
+	query := "UPDATE api_tokens SET last_used = (CURRENT_TIMESTAMP AT TIME ZONE 'UTC') WHERE id = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TokensRepositoryPostgres) Delete(input domain.DeleteTokenInput) error {
+	query := "DELETE FROM api_tokens WHERE id = $1 AND user_id = $2"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(input.ID, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrTokenNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package adapters
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/tokens/domain"
+)
+
+type TokensRepositorySqlite struct {
+	db *sql.DB
+}
+
+func NewTokensRepositorySqlite(db *sql.DB) *TokensRepositorySqlite {
+	return &TokensRepositorySqlite{db}
+}
+
+func (r *TokensRepositorySqlite) Create(input domain.CreateTokenInput) (int, error) {
+	query := "INSERT INTO api_tokens (user_id, name, token_hash, scope) VALUES (?, ?, ?, ?)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return -1, err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(input.UserID, input.Name, input.Hash, input.Scope)
+	if err != nil {
+		return -1, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return -1, err
+	}
+
+	return int(id), nil
+}
+
+func (r *TokensRepositorySqlite) GetAll(input domain.GetAllTokensInput) ([]*dto.Token, error) {
+	query := "SELECT id, user_id, name, scope, last_used, created FROM api_tokens WHERE user_id = ?"
+	if input.SortedByNewest {
+		query += " ORDER BY created DESC"
+	}
+
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []*dto.Token{}
+	for rows.Next() {
+		token := &dto.Token{}
+		var lastUsed sql.NullTime
+
+		if err := rows.Scan(
+			&token.ID,
+			&token.UserID,
+			&token.Name,
+			&token.Scope,
+			&lastUsed,
+			&token.Created,
+		); err != nil {
+			return nil, err
+		}
+		token.LastUsed = lastUsed.Time
+
+		tokens = append(tokens, token)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (r *TokensRepositorySqlite) GetByHash(input domain.GetTokenByHashInput) (*dto.Token, error) {
+	query := "SELECT id, user_id, name, scope, last_used, created FROM api_tokens WHERE token_hash = ?"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	token := &dto.Token{}
+	var lastUsed sql.NullTime
+
+	if err := stmt.QueryRow(input.Hash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&token.Scope,
+		&lastUsed,
+		&token.Created,
+	); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrTokenNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	token.LastUsed = lastUsed.Time
+
+	return token, nil
+}
+
+func (r *TokensRepositorySqlite) UpdateLastUsed(input domain.UpdateTokenLastUsedInput) error {
+	query := "UPDATE api_tokens SET last_used = CURRENT_TIMESTAMP WHERE id = ?"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(input.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TokensRepositorySqlite) Delete(input domain.DeleteTokenInput) error {
+	query := "DELETE FROM api_tokens WHERE id = ? AND user_id = ?"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(input.ID, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrTokenNotFound
+	}
+
+	return nil
+}
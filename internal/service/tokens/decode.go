@@ -0,0 +1,49 @@
+package tokens
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/tokens/domain"
+	"github.com/itelman/forum/pkg/validator"
+)
+
+func DecodeCreateToken(r *http.Request) (interface{}, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, domain.ErrTokensBadRequest
+	}
+
+	return &CreateTokenInput{
+		UserID: dto.GetAuthUser(r).ID,
+		Name:   r.PostForm.Get("name"),
+		Scope:  r.PostForm.Get("scope"),
+		Errors: make(validator.Errors),
+	}, nil
+}
+
+func DecodeGetAllTokens(r *http.Request) interface{} {
+	return &GetAllTokensInput{dto.GetAuthUser(r).ID}
+}
+
+func DecodeAuthenticateToken(r *http.Request) (interface{}, error) {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || len(token) == 0 {
+		return nil, domain.ErrTokensBadRequest
+	}
+
+	return &AuthenticateTokenInput{Token: token}, nil
+}
+
+func DecodeRevokeToken(r *http.Request) (interface{}, error) {
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		return nil, domain.ErrTokensBadRequest
+	}
+
+	return &RevokeTokenInput{
+		ID:     id,
+		UserID: dto.GetAuthUser(r).ID,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/itelman/forum/internal/dto"
+)
+
+type TokensRepository interface {
+	Create(input CreateTokenInput) (int, error)
+	GetAll(input GetAllTokensInput) ([]*dto.Token, error)
+	GetByHash(input GetTokenByHashInput) (*dto.Token, error)
+	UpdateLastUsed(input UpdateTokenLastUsedInput) error
+	Delete(input DeleteTokenInput) error
+}
+
+type CreateTokenInput struct {
+	UserID int
+	Name   string
+	Hash   string
+	Scope  string
+}
+
+type GetAllTokensInput struct {
+	UserID         int
+	SortedByNewest bool
+}
+
+type GetTokenByHashInput struct {
+	Hash string
+}
+
+type UpdateTokenLastUsedInput struct {
+	ID int
+}
+
+type DeleteTokenInput struct {
+	ID     int
+	UserID int
+}
+
+var (
+	ErrTokensBadRequest = errors.New("TOKENS: bad request")
+	ErrTokenNotFound    = errors.New("DATABASE: Token not found")
+)
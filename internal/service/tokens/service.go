@@ -0,0 +1,142 @@
+package tokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/service/tokens/adapters"
+	"github.com/itelman/forum/internal/service/tokens/domain"
+)
+
+const tokenPrefix = "fpat_"
+
+type Service interface {
+	CreateToken(input *CreateTokenInput) (*CreateTokenResponse, error)
+	GetAllTokens(input *GetAllTokensInput) (*GetAllTokensResponse, error)
+	AuthenticateToken(input *AuthenticateTokenInput) (*AuthenticateTokenResponse, error)
+	RevokeToken(input *RevokeTokenInput) error
+}
+
+type service struct {
+	tokens domain.TokensRepository
+}
+
+func NewService(opts ...Option) *service {
+	svc := &service{}
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	return svc
+}
+
+type Option func(*service)
+
+func WithSqlite(db *sql.DB) Option {
+	return func(s *service) {
+		s.tokens = adapters.NewTokensRepositorySqlite(db)
+	}
+}
+
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.tokens = adapters.NewTokensRepositoryPostgres(db)
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.tokens = memory.NewTokensRepository(store)
+	}
+}
+
+type CreateTokenResponse struct {
+	ID    int
+	Token string
+}
+
+func (s *service) CreateToken(input *CreateTokenInput) (*CreateTokenResponse, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	token := tokenPrefix + hex.EncodeToString(secret)
+
+	id, err := s.tokens.Create(domain.CreateTokenInput{
+		UserID: input.UserID,
+		Name:   input.Name,
+		Hash:   hashToken(token),
+		Scope:  input.Scope,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateTokenResponse{ID: id, Token: token}, nil
+}
+
+type GetAllTokensResponse struct {
+	Tokens []*dto.Token
+}
+
+func (s *service) GetAllTokens(input *GetAllTokensInput) (*GetAllTokensResponse, error) {
+	tokens, err := s.tokens.GetAll(domain.GetAllTokensInput{
+		UserID:         input.UserID,
+		SortedByNewest: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetAllTokensResponse{tokens}, nil
+}
+
+type AuthenticateTokenResponse struct {
+	Token *dto.Token
+}
+
+func (s *service) AuthenticateToken(input *AuthenticateTokenInput) (*AuthenticateTokenResponse, error) {
+	token, err := s.tokens.GetByHash(domain.GetTokenByHashInput{Hash: hashToken(input.Token)})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tokens.UpdateLastUsed(domain.UpdateTokenLastUsedInput{ID: token.ID}); err != nil {
+		return nil, err
+	}
+
+	return &AuthenticateTokenResponse{token}, nil
+}
+
+func (s *service) RevokeToken(input *RevokeTokenInput) error {
+	if err := s.tokens.Delete(domain.DeleteTokenInput{
+		ID:     input.ID,
+		UserID: input.UserID,
+	}); errors.Is(err, domain.ErrTokenNotFound) {
+		return domain.ErrTokensBadRequest
+	} else if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
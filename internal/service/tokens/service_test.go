@@ -0,0 +1,148 @@
+package tokens
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/repository/memory/memorytest"
+	"github.com/itelman/forum/internal/service/tokens/domain"
+	"github.com/itelman/forum/pkg/validator"
+)
+
+const (
+	alice = 1
+	bob   = 2
+)
+
+func newTestService(t *testing.T) *service {
+	t.Helper()
+
+	store := memory.NewStore()
+	memorytest.Users(t, store, "alice", "bob")
+
+	return NewService(WithMemoryStore(store))
+}
+
+func createToken(t *testing.T, svc *service, userId int, name, scope string) *CreateTokenResponse {
+	t.Helper()
+
+	resp, err := svc.CreateToken(&CreateTokenInput{UserID: userId, Name: name, Scope: scope, Errors: make(validator.Errors)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp
+}
+
+func TestCreateToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		tokenName string
+		scope     string
+		wantErr   error
+	}{
+		{"read", "CLI", dto.ScopeRead, nil},
+		{"write", "Deploy bot", dto.ScopeWrite, nil},
+		{"empty name", "", dto.ScopeRead, domain.ErrTokensBadRequest},
+		{"untrimmed name", " CLI", dto.ScopeRead, domain.ErrTokensBadRequest},
+		{"short name", "ab", dto.ScopeRead, domain.ErrTokensBadRequest},
+		{"long name", strings.Repeat("a", 51), dto.ScopeRead, domain.ErrTokensBadRequest},
+		{"unknown scope", "CLI", "admin", domain.ErrTokensBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(t)
+
+			resp, err := svc.CreateToken(&CreateTokenInput{UserID: alice, Name: tt.tokenName, Scope: tt.scope, Errors: make(validator.Errors)})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if !strings.HasPrefix(resp.Token, tokenPrefix) {
+				t.Fatalf("token = %q, want prefix %q", resp.Token, tokenPrefix)
+			}
+		})
+	}
+}
+
+func TestGetAllTokens(t *testing.T) {
+	svc := newTestService(t)
+	createToken(t, svc, alice, "CLI", dto.ScopeRead)
+	createToken(t, svc, alice, "Deploy bot", dto.ScopeWrite)
+	createToken(t, svc, bob, "Bob's CLI", dto.ScopeRead)
+
+	resp, err := svc.GetAllTokens(&GetAllTokensInput{UserID: alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Tokens) != 2 || resp.Tokens[0].Name != "Deploy bot" || resp.Tokens[1].Name != "CLI" {
+		t.Fatalf("tokens = %+v, want Deploy bot then CLI", resp.Tokens)
+	}
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	svc := newTestService(t)
+	created := createToken(t, svc, alice, "CLI", dto.ScopeWrite)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"valid", created.Token, nil},
+		{"unknown", tokenPrefix + strings.Repeat("0", 64), domain.ErrTokenNotFound},
+		{"empty", "", domain.ErrTokenNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := svc.AuthenticateToken(&AuthenticateTokenInput{Token: tt.token})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if resp.Token.ID != created.ID || resp.Token.UserID != alice || resp.Token.Scope != dto.ScopeWrite {
+				t.Fatalf("token = %+v, want alice's write token %d", resp.Token, created.ID)
+			}
+		})
+	}
+
+	resp, err := svc.GetAllTokens(&GetAllTokensInput{UserID: alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Tokens[0].LastUsed.IsZero() {
+		t.Fatal("last used was not recorded")
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	svc := newTestService(t)
+	created := createToken(t, svc, alice, "CLI", dto.ScopeRead)
+
+	if err := svc.RevokeToken(&RevokeTokenInput{ID: created.ID, UserID: bob}); !errors.Is(err, domain.ErrTokensBadRequest) {
+		t.Fatalf("revoke by bob: err = %v, want %v", err, domain.ErrTokensBadRequest)
+	}
+
+	if err := svc.RevokeToken(&RevokeTokenInput{ID: created.ID, UserID: alice}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.AuthenticateToken(&AuthenticateTokenInput{Token: created.Token}); !errors.Is(err, domain.ErrTokenNotFound) {
+		t.Fatalf("authenticate: err = %v, want %v", err, domain.ErrTokenNotFound)
+	}
+	if err := svc.RevokeToken(&RevokeTokenInput{ID: created.ID, UserID: alice}); !errors.Is(err, domain.ErrTokensBadRequest) {
+		t.Fatalf("revoke again: err = %v, want %v", err, domain.ErrTokensBadRequest)
+	}
+}
@@ -0,0 +1,67 @@
+package tokens
+
+import (
+	"strings"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/service/tokens/domain"
+	"github.com/itelman/forum/pkg/validator"
+)
+
+const (
+	nameMinLen = 3
+	nameMaxLen = 50
+)
+
+type CreateTokenInput struct {
+	UserID int
+	Name   string
+	Scope  string
+	Errors validator.Errors
+}
+
+func (i *CreateTokenInput) validate() error {
+	i.validateName()
+	i.validateScope()
+
+	if len(i.Errors) != 0 {
+		return domain.ErrTokensBadRequest
+	}
+
+	return nil
+}
+
+func (i *CreateTokenInput) validateName() {
+	if len(strings.TrimSpace(i.Name)) == 0 {
+		i.Errors.Add("name", validator.ErrInputRequired("name"))
+		return
+	}
+
+	if i.Name != strings.TrimSpace(i.Name) {
+		i.Errors.Add("name", validator.ErrInputRequired("name"))
+		return
+	}
+
+	if !(len(i.Name) >= nameMinLen && len(i.Name) <= nameMaxLen) {
+		i.Errors.Add("name", validator.ErrInputLength(nameMinLen, nameMaxLen))
+	}
+}
+
+func (i *CreateTokenInput) validateScope() {
+	if !(i.Scope == dto.ScopeRead || i.Scope == dto.ScopeWrite) {
+		i.Errors.Add("scope", validator.ErrInputRequired("scope"))
+	}
+}
+
+type GetAllTokensInput struct {
+	UserID int
+}
+
+type AuthenticateTokenInput struct {
+	Token string
+}
+
+type RevokeTokenInput struct {
+	ID     int
+	UserID int
+}
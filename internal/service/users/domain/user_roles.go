@@ -0,0 +1,13 @@
+package domain
+
+import "github.com/itelman/forum/internal/repository"
+
+type UserRolesRepository interface {
+	Get(input GetUserRoleInput) (string, error)
+}
+
+type GetUserRoleInput = repository.GetUserRoleInput
+
+var (
+	ErrUserRoleNotFound = repository.ErrUserRoleNotFound
+)
@@ -2,7 +2,9 @@ package domain
 
 import (
 	"errors"
+
 	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
 )
 
 type UsersRepository interface {
@@ -11,25 +13,15 @@ type UsersRepository interface {
 	Authenticate(input AuthUserInput) (int, error)
 }
 
-type GetUserInput struct {
-	Key   string
-	Value interface{}
-}
-
-type AuthUserInput struct {
-	Username string
-	Password string
-}
-
-type RegisterUserInput struct {
-	Username string
-	Email    string
-	Password string
-}
+type (
+	GetUserInput      = repository.GetUserInput
+	AuthUserInput     = repository.AuthUserInput
+	RegisterUserInput = repository.RegisterUserInput
+)
 
 var (
 	ErrUsersBadRequest    = errors.New("USERS: bad request")
-	ErrUserNotFound       = errors.New("DATABASE: User not found")
+	ErrUserNotFound       = repository.ErrUserNotFound
 	ErrUserExists         = errors.New("DATABASE: User exists")
-	ErrInvalidCredentials = errors.New("DATABASE: Invalid credentials")
+	ErrInvalidCredentials = repository.ErrInvalidCredentials
 )
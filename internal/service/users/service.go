@@ -7,17 +7,20 @@ import (
 	"github.com/itelman/forum/internal/service/users/domain"
 
 	"github.com/itelman/forum/internal/dto"
-	"github.com/itelman/forum/internal/service/users/adapters"
+	"github.com/itelman/forum/internal/repository"
+	"github.com/itelman/forum/internal/repository/memory"
 )
 
 type Service interface {
 	SignupUser(input *SignupUserInput) error
 	LoginUser(input *LoginUserInput) (*LoginUserResponse, error)
 	GetUser(input *GetUserInput) (*GetUserResponse, error)
+	GetUserRole(input *GetUserRoleInput) (*GetUserRoleResponse, error)
 }
 
 type service struct {
-	users domain.UsersRepository
+	users     domain.UsersRepository
+	userRoles domain.UserRolesRepository
 }
 
 func NewService(opts ...Option) *service {
@@ -33,7 +36,28 @@ type Option func(*service)
 
 func WithSqlite(db *sql.DB) Option {
 	return func(s *service) {
-		s.users = adapters.NewUsersRepositorySqlite(db)
+		s.users = repository.NewUsersRepositorySqlite(db)
+		s.userRoles = repository.NewUserRolesRepositorySqlite(db)
+	}
+}
+
+func WithPostgres(db *sql.DB) Option {
+	return func(s *service) {
+		s.users = repository.NewUsersRepositoryPostgres(db)
+		s.userRoles = repository.NewUserRolesRepositoryPostgres(db)
+	}
+}
+
+// WithMemory backs the service with a fresh in-memory store.
+func WithMemory() Option {
+	return WithMemoryStore(memory.NewStore())
+}
+
+// WithMemoryStore backs the service with store, which other services can share.
+func WithMemoryStore(store *memory.Store) Option {
+	return func(s *service) {
+		s.users = memory.NewUsersRepository(store)
+		s.userRoles = memory.NewUserRolesRepository(store)
 	}
 }
 
@@ -112,3 +136,18 @@ func (s *service) GetUser(input *GetUserInput) (*GetUserResponse, error) {
 
 	return &GetUserResponse{user}, nil
 }
+
+type GetUserRoleResponse struct {
+	Role string
+}
+
+func (s *service) GetUserRole(input *GetUserRoleInput) (*GetUserRoleResponse, error) {
+	role, err := s.userRoles.Get(domain.GetUserRoleInput{UserID: input.UserID})
+	if errors.Is(err, domain.ErrUserRoleNotFound) {
+		return &GetUserRoleResponse{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &GetUserRoleResponse{role}, nil
+}
@@ -0,0 +1,189 @@
+package users
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/itelman/forum/internal/repository/memory"
+	"github.com/itelman/forum/internal/service/users/domain"
+	"github.com/itelman/forum/pkg/validator"
+)
+
+func newTestService(t *testing.T) (*service, *memory.Store) {
+	t.Helper()
+
+	store := memory.NewStore()
+	svc := NewService(WithMemoryStore(store))
+
+	if err := svc.SignupUser(&SignupUserInput{
+		Username: "alice",
+		Email:    "alice@example.com",
+		Password: "secret1",
+		Errors:   make(validator.Errors),
+	}); err != nil {
+		t.Fatalf("signup alice: %v", err)
+	}
+
+	return svc, store
+}
+
+func TestSignupUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      SignupUserInput
+		wantErr    error
+		wantFields []string
+	}{
+		{"valid", SignupUserInput{Username: "Bobby", Email: "Bobby@example.com", Password: "secret1"}, nil, nil},
+		{"username taken", SignupUserInput{Username: "ALICE", Email: "other@example.com", Password: "secret1"}, domain.ErrUserExists, []string{"username"}},
+		{"email taken", SignupUserInput{Username: "other", Email: "alice@example.com", Password: "secret1"}, domain.ErrUserExists, []string{"email"}},
+		{"both taken", SignupUserInput{Username: "alice", Email: "alice@example.com", Password: "secret1"}, domain.ErrUserExists, []string{"username", "email"}},
+		{"short username", SignupUserInput{Username: "bob", Email: "bob@example.com", Password: "secret1"}, domain.ErrUsersBadRequest, []string{"username"}},
+		{"long username", SignupUserInput{Username: "abcdefghijklmnopqrstuvwxyzabcde", Email: "bob@example.com", Password: "secret1"}, domain.ErrUsersBadRequest, []string{"username"}},
+		{"invalid email", SignupUserInput{Username: "bobby", Email: "bobby", Password: "secret1"}, domain.ErrUsersBadRequest, []string{"email"}},
+		{"named email", SignupUserInput{Username: "bobby", Email: "Bobby <bobby@example.com>", Password: "secret1"}, domain.ErrUsersBadRequest, []string{"email"}},
+		{"short password", SignupUserInput{Username: "bobby", Email: "bobby@example.com", Password: "abc"}, domain.ErrUsersBadRequest, []string{"password"}},
+		{"invalid password", SignupUserInput{Username: "bobby", Email: "bobby@example.com", Password: "secret 1"}, domain.ErrUsersBadRequest, []string{"password"}},
+		{"empty", SignupUserInput{}, domain.ErrUsersBadRequest, []string{"username", "email", "password"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+			input := tt.input
+			input.Errors = make(validator.Errors)
+
+			if err := svc.SignupUser(&input); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if len(input.Errors) != len(tt.wantFields) {
+				t.Errorf("got errors %v, want fields %v", input.Errors, tt.wantFields)
+			}
+			for _, field := range tt.wantFields {
+				if input.Errors.Get(field) == "" {
+					t.Errorf("missing error for %q", field)
+				}
+			}
+		})
+	}
+}
+
+func TestSignupUserLowercases(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	if err := svc.SignupUser(&SignupUserInput{Username: "Bobby", Email: "Bobby@Example.com", Password: "secret1", Errors: make(validator.Errors)}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := svc.LoginUser(&LoginUserInput{Username: "BOBBY", Password: "secret1", Errors: make(validator.Errors)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := svc.GetUser(&GetUserInput{ID: resp.UserID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.User.Username != "bobby" || user.User.Email != "bobby@example.com" {
+		t.Errorf("got %q <%s>, want lowercase", user.User.Username, user.User.Email)
+	}
+}
+
+func TestLoginUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      LoginUserInput
+		wantErr    error
+		wantFields []string
+	}{
+		{"valid", LoginUserInput{Username: "alice", Password: "secret1"}, nil, nil},
+		{"any case", LoginUserInput{Username: "Alice", Password: "secret1"}, nil, nil},
+		{"wrong password", LoginUserInput{Username: "alice", Password: "secret2"}, nil, []string{"generic"}},
+		{"unknown user", LoginUserInput{Username: "nobody", Password: "secret1"}, nil, []string{"username"}},
+		{"empty", LoginUserInput{}, domain.ErrUsersBadRequest, []string{"username", "password"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+			input := tt.input
+			input.Errors = make(validator.Errors)
+
+			resp, err := svc.LoginUser(&input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if len(input.Errors) != len(tt.wantFields) {
+				t.Errorf("got errors %v, want fields %v", input.Errors, tt.wantFields)
+			}
+			for _, field := range tt.wantFields {
+				if input.Errors.Get(field) == "" {
+					t.Errorf("missing error for %q", field)
+				}
+			}
+
+			if err == nil && len(tt.wantFields) == 0 && resp.UserID != 1 {
+				t.Errorf("got user %d, want 1", resp.UserID)
+			}
+		})
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		want    string
+		wantErr error
+	}{
+		{"existing", 1, "alice", nil},
+		{"missing", 2, "", domain.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+
+			resp, err := svc.GetUser(&GetUserInput{ID: tt.id})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if err == nil && resp.User.Username != tt.want {
+				t.Errorf("got %q, want %q", resp.User.Username, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetUserRole(t *testing.T) {
+	tests := []struct {
+		name string
+		role string
+		want string
+	}{
+		{"no role", "", ""},
+		{"moderator", "moderator", "moderator"},
+		{"admin", "admin", "admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store := newTestService(t)
+			if tt.role != "" {
+				store.SetUserRole(1, tt.role)
+			}
+
+			resp, err := svc.GetUserRole(&GetUserRoleInput{UserID: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.Role != tt.want {
+				t.Errorf("got %q, want %q", resp.Role, tt.want)
+			}
+		})
+	}
+}
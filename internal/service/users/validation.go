@@ -37,6 +37,10 @@ type GetUserInput struct {
 	ID int
 }
 
+type GetUserRoleInput struct {
+	UserID int
+}
+
 var (
 	usernameRX = regexp.MustCompile(`^[a-zA-Z]{5,}([._]{0,1}[a-zA-Z0-9]{2,})*$`)
 	passwordRX = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
@@ -0,0 +1,27 @@
+DROP TABLE IF EXISTS reports;
+
+DROP TABLE IF EXISTS requests;
+
+DROP TABLE IF EXISTS user_roles;
+
+DROP TABLE IF EXISTS roles;
+
+DROP TABLE IF EXISTS post_categories;
+
+DROP TABLE IF EXISTS categories;
+
+DROP TABLE IF EXISTS images;
+
+DROP TABLE IF EXISTS comment_reactions;
+
+DROP TABLE IF EXISTS post_reactions;
+
+DROP TABLE IF EXISTS comments;
+
+DROP TABLE IF EXISTS posts;
+
+DROP TABLE IF EXISTS users_oauth;
+
+DROP TABLE IF EXISTS oauth_types;
+
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS pending_posts (
+    post_id INTEGER PRIMARY KEY,
+    created TIMESTAMP(0) DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS comments_search;
+
+DROP INDEX IF EXISTS posts_search;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS search;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS search;
@@ -0,0 +1,11 @@
+-- Titles are weighted A and contents B, so that ts_rank can count a match in
+-- the title ten times.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS search TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', title), 'A') || setweight(to_tsvector('english', content), 'B')
+) STORED;
+
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS search TSVECTOR GENERATED ALWAYS AS (to_tsvector('english', content)) STORED;
+
+CREATE INDEX IF NOT EXISTS posts_search ON posts USING GIN (search);
+
+CREATE INDEX IF NOT EXISTS comments_search ON comments USING GIN (search);
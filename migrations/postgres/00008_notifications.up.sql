@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS notifications (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    actor_id INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    post_id INTEGER NOT NULL,
+    comment_id INTEGER,
+    post_reaction_id INTEGER,
+    comment_reaction_id INTEGER,
+    read BOOLEAN DEFAULT FALSE,
+    created TIMESTAMP(0) DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE,
+    FOREIGN KEY (comment_id) REFERENCES comments (id) ON DELETE CASCADE,
+    FOREIGN KEY (post_reaction_id) REFERENCES post_reactions (id) ON DELETE CASCADE,
+    FOREIGN KEY (comment_reaction_id) REFERENCES comment_reactions (id) ON DELETE CASCADE
+);
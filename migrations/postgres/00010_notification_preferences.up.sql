@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INTEGER NOT NULL,
+    event TEXT NOT NULL,
+    in_app BOOLEAN NOT NULL DEFAULT TRUE,
+    live BOOLEAN NOT NULL DEFAULT TRUE,
+    email BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY (user_id, event),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+-- PostgreSQL databases have recorded notifications since their first
+-- migration, so there is nothing to backfill. The version is kept so that
+-- both migration directories number the same changes alike.
+SELECT 1;
@@ -0,0 +1,15 @@
+DROP TRIGGER IF EXISTS comments_fts_delete;
+
+DROP TRIGGER IF EXISTS comments_fts_update;
+
+DROP TRIGGER IF EXISTS comments_fts_insert;
+
+DROP TRIGGER IF EXISTS posts_fts_delete;
+
+DROP TRIGGER IF EXISTS posts_fts_update;
+
+DROP TRIGGER IF EXISTS posts_fts_insert;
+
+DROP TABLE IF EXISTS comments_fts;
+
+DROP TABLE IF EXISTS posts_fts;
@@ -0,0 +1,30 @@
+CREATE VIRTUAL TABLE IF NOT EXISTS posts_fts USING fts5 (title, content, tokenize = 'porter unicode61');
+
+CREATE VIRTUAL TABLE IF NOT EXISTS comments_fts USING fts5 (content, tokenize = 'porter unicode61');
+
+CREATE TRIGGER IF NOT EXISTS posts_fts_insert AFTER INSERT ON posts BEGIN
+    INSERT INTO posts_fts (rowid, title, content) VALUES (new.id, new.title, new.content);
+END;
+
+CREATE TRIGGER IF NOT EXISTS posts_fts_update AFTER UPDATE OF title, content ON posts BEGIN
+    UPDATE posts_fts SET title = new.title, content = new.content WHERE rowid = new.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS posts_fts_delete AFTER DELETE ON posts BEGIN
+    DELETE FROM posts_fts WHERE rowid = old.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS comments_fts_insert AFTER INSERT ON comments BEGIN
+    INSERT INTO comments_fts (rowid, content) VALUES (new.id, new.content);
+END;
+
+CREATE TRIGGER IF NOT EXISTS comments_fts_update AFTER UPDATE OF content ON comments BEGIN
+    UPDATE comments_fts SET content = new.content WHERE rowid = new.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS comments_fts_delete AFTER DELETE ON comments BEGIN
+    DELETE FROM comments_fts WHERE rowid = old.id;
+END;
+
+INSERT INTO posts_fts (rowid, title, content) SELECT id, title, content FROM posts WHERE id NOT IN (SELECT rowid FROM posts_fts);
+INSERT INTO comments_fts (rowid, content) SELECT id, content FROM comments WHERE id NOT IN (SELECT rowid FROM comments_fts);
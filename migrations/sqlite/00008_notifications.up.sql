@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS notifications (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    actor_id INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    post_id INTEGER NOT NULL,
+    comment_id INTEGER,
+    post_reaction_id INTEGER,
+    comment_reaction_id INTEGER,
+    read BOOLEAN DEFAULT 0,
+    created DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE,
+    FOREIGN KEY (comment_id) REFERENCES comments (id) ON DELETE CASCADE,
+    FOREIGN KEY (post_reaction_id) REFERENCES post_reactions (id) ON DELETE CASCADE,
+    FOREIGN KEY (comment_reaction_id) REFERENCES comment_reactions (id) ON DELETE CASCADE
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INTEGER NOT NULL,
+    event TEXT NOT NULL,
+    in_app BOOLEAN NOT NULL DEFAULT 1,
+    live BOOLEAN NOT NULL DEFAULT 1,
+    email BOOLEAN NOT NULL DEFAULT 1,
+    PRIMARY KEY (user_id, event),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+-- Backfilled notifications cannot be told apart from ones that were read
+-- since, so rolling back keeps them.
+SELECT 1;
@@ -0,0 +1,38 @@
+-- Notifications for comments and reactions made before notifications were
+-- recorded, marked as read so that nobody's inbox fills up at once. Activity
+-- since the first recorded notification already has one, unless it was the
+-- user's own or the notification was deleted, so it is left alone.
+
+CREATE TEMP TABLE backfill_cutoff AS SELECT COALESCE(MIN(created), '9999-12-31') AS created FROM notifications;
+
+INSERT INTO notifications (user_id, actor_id, type, post_id, comment_id, read, created)
+SELECT p.user_id, c.user_id, 'comment', c.post_id, c.id, 1, c.created
+FROM comments c
+INNER JOIN posts p ON c.post_id = p.id
+LEFT JOIN comment_replies r ON c.id = r.comment_id
+WHERE r.comment_id IS NULL AND c.user_id != p.user_id
+AND c.created < (SELECT created FROM backfill_cutoff);
+
+INSERT INTO notifications (user_id, actor_id, type, post_id, comment_id, read, created)
+SELECT parent.user_id, c.user_id, 'reply', c.post_id, c.id, 1, c.created
+FROM comments c
+INNER JOIN comment_replies r ON c.id = r.comment_id
+INNER JOIN comments parent ON r.parent_id = parent.id
+WHERE c.user_id != parent.user_id
+AND c.created < (SELECT created FROM backfill_cutoff);
+
+INSERT INTO notifications (user_id, actor_id, type, post_id, post_reaction_id, read, created)
+SELECT p.user_id, pr.user_id, CASE pr.is_like WHEN 1 THEN 'post_like' ELSE 'post_dislike' END, pr.post_id, pr.id, 1, pr.created
+FROM post_reactions pr
+INNER JOIN posts p ON pr.post_id = p.id
+WHERE pr.user_id != p.user_id
+AND pr.created < (SELECT created FROM backfill_cutoff);
+
+INSERT INTO notifications (user_id, actor_id, type, post_id, comment_id, comment_reaction_id, read, created)
+SELECT c.user_id, cr.user_id, CASE cr.is_like WHEN 1 THEN 'comment_like' ELSE 'comment_dislike' END, c.post_id, c.id, cr.id, 1, cr.created
+FROM comment_reactions cr
+INNER JOIN comments c ON cr.comment_id = c.id
+WHERE cr.user_id != c.user_id
+AND cr.created < (SELECT created FROM backfill_cutoff);
+
+DROP TABLE backfill_cutoff;
@@ -0,0 +1,97 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	Comment      = "comment"
+	Notification = "notification"
+	Unread       = "unread"
+)
+
+// subscriberBuffer is how many events a slow subscriber may fall behind
+// before further events for it are dropped.
+const subscriberBuffer = 16
+
+type Event struct {
+	Name string
+	Data interface{}
+}
+
+type Publisher interface {
+	Publish(topic string, event Event)
+}
+
+type Hub interface {
+	Publisher
+	Subscribe(topics ...string) (<-chan Event, func())
+}
+
+type hub struct {
+	mu   sync.RWMutex
+	subs map[string]map[chan Event]struct{}
+}
+
+func NewHub() *hub {
+	return &hub{subs: make(map[string]map[chan Event]struct{})}
+}
+
+func UserTopic(id int) string {
+	return fmt.Sprintf("user:%d", id)
+}
+
+func PostTopic(id int) string {
+	return fmt.Sprintf("post:%d", id)
+}
+
+// Publish never blocks: subscribers whose buffer is full miss the event.
+func (h *hub) Publish(topic string, event Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subs[topic] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel receiving events published to any of topics
+// and a function that must be called to unsubscribe.
+func (h *hub) Subscribe(topics ...string) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	h.mu.Lock()
+	for _, topic := range topics {
+		if h.subs[topic] == nil {
+			h.subs[topic] = make(map[chan Event]struct{})
+		}
+		h.subs[topic][ch] = struct{}{}
+	}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+
+			for _, topic := range topics {
+				delete(h.subs[topic], ch)
+				if len(h.subs[topic]) == 0 {
+					delete(h.subs, topic)
+				}
+			}
+		})
+	}
+}
+
+type discard struct{}
+
+func (discard) Publish(string, Event) {}
+
+// Discard is a Publisher that drops every event.
+var Discard Publisher = discard{}
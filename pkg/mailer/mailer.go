@@ -0,0 +1,71 @@
+package mailer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+var ErrInvalidMessage = errors.New("MAILER: invalid message")
+
+type Message struct {
+	To      string
+	Subject string
+	// Body is sent as HTML.
+	Body string
+	// Text, when set, is sent alongside Body as its plain-text alternative.
+	Text string
+}
+
+type Mailer interface {
+	Send(msg Message) error
+}
+
+func format(w io.Writer, from string, msg Message) error {
+	if len(msg.To) == 0 || strings.ContainsAny(msg.To+msg.Subject, "\r\n") {
+		return ErrInvalidMessage
+	}
+
+	headers := []string{
+		"From: " + from,
+		"To: " + msg.To,
+		"Subject: " + msg.Subject,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+	}
+
+	if len(msg.Text) == 0 {
+		headers = append(headers, "Content-Type: text/html; charset=UTF-8")
+
+		_, err := fmt.Fprintf(w, "%s\r\n\r\n%s\r\n", strings.Join(headers, "\r\n"), msg.Body)
+		return err
+	}
+
+	// Clients show the last part they support, so HTML goes after plain text.
+	body := new(bytes.Buffer)
+	parts := multipart.NewWriter(body)
+	for _, part := range [][2]string{{"text/plain", msg.Text}, {"text/html", msg.Body}} {
+		pw, err := parts.CreatePart(textproto.MIMEHeader{"Content-Type": {part[0] + "; charset=UTF-8"}})
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(pw, part[1]); err != nil {
+			return err
+		}
+	}
+
+	if err := parts.Close(); err != nil {
+		return err
+	}
+
+	headers = append(headers, "Content-Type: multipart/alternative; boundary="+parts.Boundary())
+
+	_, err := fmt.Fprintf(w, "%s\r\n\r\n%s\r\n", strings.Join(headers, "\r\n"), body)
+	return err
+}
@@ -0,0 +1,33 @@
+package mailer
+
+import (
+	"bytes"
+	"net"
+	"net/smtp"
+)
+
+type smtpMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer sends mail through an SMTP server. Authentication is skipped
+// when username is empty.
+func NewSMTPMailer(host, port, username, password, from string) *smtpMailer {
+	m := &smtpMailer{addr: net.JoinHostPort(host, port), from: from}
+	if len(username) != 0 {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return m
+}
+
+func (m *smtpMailer) Send(msg Message) error {
+	buf := new(bytes.Buffer)
+	if err := format(buf, m.from, msg); err != nil {
+		return err
+	}
+
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{msg.To}, buf.Bytes())
+}
@@ -0,0 +1,30 @@
+package mailer
+
+import (
+	"io"
+	"sync"
+)
+
+// writerMailer writes every message to w instead of delivering it, which is
+// enough to see what would have been sent during local development.
+type writerMailer struct {
+	w    io.Writer
+	from string
+	mu   sync.Mutex
+}
+
+func NewWriterMailer(w io.Writer, from string) *writerMailer {
+	return &writerMailer{w: w, from: from}
+}
+
+func (m *writerMailer) Send(msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := format(m.w, m.from, msg); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(m.w, "\r\n")
+	return err
+}
@@ -0,0 +1,68 @@
+package mentions
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MaxPerContent caps how many different users a single post or comment can mention.
+const MaxPerContent = 10
+
+// A mention is "@" followed by a username. It must start the text or follow a
+// character that cannot be part of a username or an email address.
+var mentionRx = regexp.MustCompile(`(?:^|[^\w@.])@([\w.]+)`)
+
+// ReplaceAll calls fn for every mention in content and substitutes the
+// "@username" text with its result.
+func ReplaceAll(content string, fn func(username string) string) string {
+	var b strings.Builder
+
+	last := 0
+	for _, loc := range mentionRx.FindAllStringSubmatchIndex(content, -1) {
+		username := trim(content[loc[2]:loc[3]])
+		if len(username) == 0 {
+			continue
+		}
+
+		start, end := loc[2]-1, loc[2]+len(username)
+		b.WriteString(content[last:start])
+		b.WriteString(fn(username))
+		last = end
+	}
+	b.WriteString(content[last:])
+
+	return b.String()
+}
+
+// Parse returns the distinct usernames mentioned in content, in order of
+// first appearance and at most MaxPerContent of them.
+func Parse(content string) []string {
+	usernames := []string{}
+	seen := make(map[string]bool)
+
+	ReplaceAll(content, func(username string) string {
+		if !seen[username] && len(usernames) < MaxPerContent {
+			seen[username] = true
+			usernames = append(usernames, username)
+		}
+
+		return ""
+	})
+
+	return usernames
+}
+
+// trim drops trailing "." and "_", so "@alice20." at the end of a sentence
+// mentions alice20. Usernames always start with a letter.
+func trim(username string) string {
+	username = strings.TrimRight(username, "._")
+	if len(username) == 0 || !isLetter(username[0]) {
+		return ""
+	}
+
+	return username
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,134 @@
+package mentions
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"", "[]"},
+		{"no mentions here", "[]"},
+		{"@alice", "[alice]"},
+		{"hi @alice and @bob!", "[alice bob]"},
+		{"@alice @alice @Alice", "[alice Alice]"},
+		{"thanks @alice20.", "[alice20]"},
+		{"ask @j.doe_ about it", "[j.doe]"},
+		{"(@alice)", "[alice]"},
+		{"mail alice@example.com", "[]"},
+		{"@@alice", "[]"},
+		{"x.@alice", "[]"},
+		{"@1alice @_alice @", "[]"},
+		{strings.Repeat("@a ", 3) + "@b", "[a b]"},
+	}
+
+	for _, tt := range tests {
+		if got := fmt.Sprint(Parse(tt.content)); got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	var content strings.Builder
+	for i := 0; i < MaxPerContent+5; i++ {
+		fmt.Fprintf(&content, "@user%d ", i)
+	}
+
+	got := Parse(content.String())
+	if len(got) != MaxPerContent || got[0] != "user0" || got[MaxPerContent-1] != fmt.Sprintf("user%d", MaxPerContent-1) {
+		t.Errorf("Parse kept %v", got)
+	}
+}
+
+func TestReplaceAll(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"hi @alice.", "hi [alice]."},
+		{"@alice,@bob", "[alice],[bob]"},
+		{"alice@example.com @", "alice@example.com @"},
+		{"@alice_ and @_bob", "[alice]_ and @_bob"},
+	}
+
+	for _, tt := range tests {
+		got := ReplaceAll(tt.content, func(username string) string { return "[" + username + "]" })
+		if got != tt.want {
+			t.Errorf("ReplaceAll(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func users(ids ...int) []*dto.User {
+	users := []*dto.User{}
+	for _, id := range ids {
+		users = append(users, &dto.User{ID: id})
+	}
+
+	return users
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		a, b []*dto.User
+		want string
+	}{
+		{users(), users(), "[]"},
+		{users(), users(1, 2), "[1 2]"},
+		{users(1, 2), users(), "[]"},
+		{users(1), users(1, 2, 3), "[2 3]"},
+		{users(3, 1), users(1, 2, 3), "[2]"},
+	}
+
+	for _, tt := range tests {
+		if got := fmt.Sprint(UserIDs(Diff(tt.a, tt.b))); got != tt.want {
+			t.Errorf("Diff(%v, %v) = %s, want %s", UserIDs(tt.a), UserIDs(tt.b), got, tt.want)
+		}
+	}
+}
+
+type notifications struct {
+	created []repository.CreateNotificationInput
+	err     error
+}
+
+func (n *notifications) Create(tx *sql.Tx, input repository.CreateNotificationInput) (int, error) {
+	if n.err != nil {
+		return 0, n.err
+	}
+
+	n.created = append(n.created, input)
+	return len(n.created) * 10, nil
+}
+
+func TestNotify(t *testing.T) {
+	repo := &notifications{}
+	input := repository.CreateNotificationInput{ActorID: 1, PostID: 7, CommentID: 9}
+
+	notified, err := Notify(nil, repo, input, users(2, 1, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fmt.Sprint(notified); got != "map[2:10 3:20]" {
+		t.Errorf("notified = %s", got)
+	}
+	if got := fmt.Sprint(repo.created); got != "[{2 1 mention 7 9 0 0} {3 1 mention 7 9 0 0}]" {
+		t.Errorf("created = %s", got)
+	}
+
+	repo.err = errors.New("disk full")
+	if _, err := Notify(nil, repo, input, users(2)); !errors.Is(err, repo.err) {
+		t.Errorf("err = %v, want %v", err, repo.err)
+	}
+}
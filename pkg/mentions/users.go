@@ -0,0 +1,62 @@
+package mentions
+
+import (
+	"database/sql"
+
+	"github.com/itelman/forum/internal/dto"
+	"github.com/itelman/forum/internal/repository"
+)
+
+// NotificationsRepository is the part of the notifications repository that
+// Notify needs.
+type NotificationsRepository interface {
+	Create(tx *sql.Tx, input repository.CreateNotificationInput) (int, error)
+}
+
+// Notify creates a mention notification for every mentioned user except the
+// actor of input, which also carries the post and comment mentioned from. It
+// returns the notification ids by recipient.
+func Notify(tx *sql.Tx, notifications NotificationsRepository, input repository.CreateNotificationInput, mentioned []*dto.User) (map[int]int, error) {
+	notified := map[int]int{}
+	for _, user := range mentioned {
+		if user.ID == input.ActorID {
+			continue
+		}
+
+		input.UserID = user.ID
+		input.Type = dto.NotificationMention
+		id, err := notifications.Create(tx, input)
+		if err != nil {
+			return nil, err
+		}
+		notified[user.ID] = id
+	}
+
+	return notified, nil
+}
+
+// Diff returns the users in b that are not in a.
+func Diff(a, b []*dto.User) []*dto.User {
+	ids := make(map[int]bool, len(a))
+	for _, user := range a {
+		ids[user.ID] = true
+	}
+
+	diff := []*dto.User{}
+	for _, user := range b {
+		if !ids[user.ID] {
+			diff = append(diff, user)
+		}
+	}
+
+	return diff
+}
+
+func UserIDs(users []*dto.User) []int {
+	ids := make([]int, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+
+	return ids
+}
@@ -0,0 +1,86 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultLimit = 20
+
+	createdLayout = "2006-01-02 15:04:05"
+)
+
+var ErrInvalidCursor = errors.New("PAGINATION: invalid cursor")
+
+// Cursor points at the last row of a page. Lists are ordered by creation time
+// or by a score, highest first, with the row id breaking ties.
+type Cursor struct {
+	Created time.Time
+	Score   float64
+	ID      int
+}
+
+func (c *Cursor) Encode() string {
+	value := fmt.Sprintf("%d:%s:%d", c.Created.Unix(), strconv.FormatFloat(c.Score, 'g', -1, 64), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// Args returns the values for a "(created, id) < (?, ?)" clause, formatted
+// the way CURRENT_TIMESTAMP stores them.
+func (c *Cursor) Args() []interface{} {
+	return []interface{}{c.Created.UTC().Format(createdLayout), c.ID}
+}
+
+// ScoreArgs returns the values for a "(score, id) < (?, ?)" clause.
+func (c *Cursor) ScoreArgs() []interface{} {
+	return []interface{}{c.Score, c.ID}
+}
+
+func ParseCursor(value string) (*Cursor, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parts := strings.Split(string(decoded), ":")
+	if len(parts) != 3 {
+		return nil, ErrInvalidCursor
+	}
+
+	sec, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	score, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	id, err := strconv.Atoi(parts[2])
+	if err != nil || id < 1 {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{Created: time.Unix(sec, 0).UTC(), Score: score, ID: id}, nil
+}
+
+// Paginate trims a list fetched with limit+1 rows down to limit and returns
+// the encoded cursor of the next page, or "" if this is the last one.
+func Paginate[T any](items []T, limit int, cursor func(T) *Cursor) ([]T, string) {
+	if len(items) <= limit {
+		return items, ""
+	}
+
+	items = items[:limit]
+	return items, cursor(items[len(items)-1]).Encode()
+}
@@ -0,0 +1,152 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	created := time.Date(2024, 5, 17, 13, 45, 10, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		cursor Cursor
+	}{
+		{"created", Cursor{Created: created, ID: 42}},
+		{"score", Cursor{Created: created, Score: 12.5, ID: 7}},
+		{"negative score", Cursor{Created: created, Score: -3, ID: 1}},
+		{"fractional hot score", Cursor{Created: created, Score: 11920.3409926, ID: 3}},
+		{"large score", Cursor{Created: created, Score: math.MaxFloat64, ID: math.MaxInt32}},
+		{"before 1970", Cursor{Created: time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC), ID: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCursor(tt.cursor.Encode())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !got.Created.Equal(tt.cursor.Created) || got.Score != tt.cursor.Score || got.ID != tt.cursor.ID {
+				t.Errorf("got %+v, want %+v", got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestCursorDropsSubseconds(t *testing.T) {
+	created := time.Date(2024, 5, 17, 13, 45, 10, 999, time.FixedZone("UTC+5", 5*60*60))
+
+	got, err := ParseCursor((&Cursor{Created: created, ID: 1}).Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := created.Truncate(time.Second).UTC(); got.Created != want {
+		t.Errorf("created = %v, want %v", got.Created, want)
+	}
+	if args := fmt.Sprint(got.Args()); args != "[2024-05-17 08:45:10 1]" {
+		t.Errorf("args = %s", args)
+	}
+}
+
+func TestParseCursorEmpty(t *testing.T) {
+	cursor, err := ParseCursor("")
+	if cursor != nil || err != nil {
+		t.Errorf("got %v, %v, want no cursor and no error", cursor, err)
+	}
+}
+
+func TestParseCursorInvalid(t *testing.T) {
+	encode := func(value string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(value))
+	}
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"not base64", "not a cursor!"},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte("1:0:1"))},
+		{"too few parts", encode("1715953510:1")},
+		{"too many parts", encode("1715953510:0:1:2")},
+		{"bad time", encode("yesterday:0:1")},
+		{"bad score", encode("1715953510:high:1")},
+		{"bad id", encode("1715953510:0:one")},
+		{"zero id", encode("1715953510:0:0")},
+		{"negative id", encode("1715953510:0:-4")},
+		{"empty parts", encode("::")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if cursor, err := ParseCursor(tt.value); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("got %v, %v, want %v", cursor, err, ErrInvalidCursor)
+			}
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	cursor := func(id int) *Cursor {
+		return &Cursor{Created: time.Unix(1715953510, 0), ID: id}
+	}
+
+	tests := []struct {
+		name     string
+		items    []int
+		limit    int
+		want     string
+		wantNext *Cursor
+	}{
+		{"empty", []int{}, 2, "[]", nil},
+		{"short page", []int{5}, 2, "[5]", nil},
+		{"full last page", []int{5, 4}, 2, "[5 4]", nil},
+		{"more to come", []int{5, 4, 3}, 2, "[5 4]", cursor(4)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, next := Paginate(tt.items, tt.limit, cursor)
+			if fmt.Sprint(items) != tt.want {
+				t.Errorf("items = %v, want %s", items, tt.want)
+			}
+
+			wantNext := ""
+			if tt.wantNext != nil {
+				wantNext = tt.wantNext.Encode()
+			}
+			if next != wantNext {
+				t.Errorf("next = %q, want %q", next, wantNext)
+			}
+		})
+	}
+}
+
+func TestValidSort(t *testing.T) {
+	tests := []struct {
+		sort   string
+		period string
+		want   bool
+	}{
+		{"", "", true},
+		{SortNew, "", true},
+		{SortTop, PeriodDay, true},
+		{SortTop, PeriodWeek, true},
+		{SortHot, PeriodAll, true},
+		{SortComments, "", true},
+		{"old", "", false},
+		{SortTop, "month", false},
+		{"TOP", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidSort(tt.sort, tt.period); got != tt.want {
+			t.Errorf("ValidSort(%q, %q) = %t, want %t", tt.sort, tt.period, got, tt.want)
+		}
+	}
+}
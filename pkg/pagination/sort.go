@@ -0,0 +1,29 @@
+package pagination
+
+const (
+	SortNew      = "new"
+	SortTop      = "top"
+	SortHot      = "hot"
+	SortComments = "comments"
+
+	PeriodDay  = "day"
+	PeriodWeek = "week"
+	PeriodAll  = "all"
+)
+
+// ValidSort reports whether sort and period name a known feed ordering. Empty
+// values stand for the defaults, SortNew and PeriodAll.
+func ValidSort(sort, period string) bool {
+	switch sort {
+	case "", SortNew, SortTop, SortHot, SortComments:
+	default:
+		return false
+	}
+
+	switch period {
+	case "", PeriodDay, PeriodWeek, PeriodAll:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,58 @@
+package sesm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+)
+
+type SessionInfo struct {
+	Key         string
+	UserAgent   string
+	IP          string
+	LastRequest time.Time
+	Created     time.Time
+}
+
+// SessionKey identifies a session on pages without revealing its cookie value.
+func SessionKey(sessionId string) string {
+	sum := sha256.Sum256([]byte(sessionId))
+	return hex.EncodeToString(sum[:8])
+}
+
+func newSessionData(r *http.Request, userId int) session {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	now := time.Now()
+
+	return session{
+		UserId:      userId,
+		LastRequest: now,
+		Status:      "active",
+		UserAgent:   r.UserAgent(),
+		IP:          ip,
+		Created:     now,
+	}
+}
+
+func newSessionInfo(sessionId string, data session) *SessionInfo {
+	info := &SessionInfo{Key: SessionKey(sessionId)}
+	info.UserAgent, _ = data[UserAgent].(string)
+	info.IP, _ = data[IP].(string)
+	info.LastRequest, _ = data[LastRequest].(time.Time)
+	info.Created, _ = data[Created].(time.Time)
+
+	return info
+}
+
+func sortSessionInfos(sessions []*SessionInfo) {
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastRequest.After(sessions[j].LastRequest)
+	})
+}
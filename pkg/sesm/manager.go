@@ -16,6 +16,11 @@ const (
 	Status         = "status"
 	BlockTimestamp = "block_timestamp"
 	Flash          = "flash"
+	UserAgent      = "user_agent"
+	IP             = "ip"
+	Created        = "created"
+	FeedSort       = "feed_sort"
+	FeedPeriod     = "feed_period"
 )
 
 var (
@@ -26,7 +31,7 @@ var (
 type session map[string]interface{}
 
 type SessionManager interface {
-	CreateSession(userId int) (string, error)
+	CreateSession(r *http.Request, userId int) (string, error)
 	CurrentSessionID(r *http.Request) (string, error)
 	DeleteCurrentSession(r *http.Request) error
 	GetSessionData(r *http.Request, key string) (interface{}, error)
@@ -35,24 +40,26 @@ type SessionManager interface {
 	UpdateSessionLastRequest(r *http.Request) error
 	UpdateSessionFlash(r *http.Request, val string) error
 	PopSessionFlash(r *http.Request) (string, error)
-	DeleteActiveUserSession(userId int)
+	GetUserSessions(userId int) ([]*SessionInfo, error)
+	DeleteUserSession(userId int, key string) error
+	DeleteAllUserSessions(userId int) error
 	BlockSession(r *http.Request) error
 }
 
 type sessionManager struct {
-	store       map[string]session
-	activeUsers map[int]string
-	mutex       sync.RWMutex
+	store        map[string]session
+	userSessions map[int]map[string]struct{}
+	mutex        sync.RWMutex
 }
 
 func NewSessionManager() *sessionManager {
 	return &sessionManager{
-		store:       make(map[string]session),
-		activeUsers: make(map[int]string),
+		store:        make(map[string]session),
+		userSessions: make(map[int]map[string]struct{}),
 	}
 }
 
-func (s *sessionManager) CreateSession(userId int) (string, error) {
+func (s *sessionManager) CreateSession(r *http.Request, userId int) (string, error) {
 	newUUID, err := uuid.NewV4()
 	if err != nil {
 		return "", err
@@ -63,8 +70,11 @@ func (s *sessionManager) CreateSession(userId int) (string, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	s.store[sessionId] = session{UserId: userId, LastRequest: time.Now(), Status: "active"}
-	s.activeUsers[userId] = sessionId
+	s.store[sessionId] = newSessionData(r, userId)
+	if _, exists := s.userSessions[userId]; !exists {
+		s.userSessions[userId] = make(map[string]struct{})
+	}
+	s.userSessions[userId][sessionId] = struct{}{}
 
 	return sessionId, nil
 }
@@ -92,18 +102,10 @@ func (s *sessionManager) DeleteCurrentSession(r *http.Request) error {
 		return nil
 	}
 
-	s.mutex.RLock()
-	uid, exists := s.store[sessionID][UserId]
-	if !exists {
-		return ErrDataNotFound
-	}
-	s.mutex.RUnlock()
-
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	delete(s.store, sessionID)
-	delete(s.activeUsers, uid.(int))
+	s.deleteSession(sessionID)
 
 	return nil
 }
@@ -188,19 +190,42 @@ func (s *sessionManager) PopSessionFlash(r *http.Request) (string, error) {
 	return flash.(string), nil
 }
 
-func (s *sessionManager) DeleteActiveUserSession(userId int) {
+func (s *sessionManager) GetUserSessions(userId int) ([]*SessionInfo, error) {
 	s.mutex.RLock()
-	sessionId, exists := s.activeUsers[userId]
-	s.mutex.RUnlock()
-	if !exists {
-		return
+	defer s.mutex.RUnlock()
+
+	sessions := []*SessionInfo{}
+	for sessionId := range s.userSessions[userId] {
+		sessions = append(sessions, newSessionInfo(sessionId, s.store[sessionId]))
 	}
+	sortSessionInfos(sessions)
+
+	return sessions, nil
+}
 
+func (s *sessionManager) DeleteUserSession(userId int, key string) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	delete(s.store, sessionId)
-	delete(s.activeUsers, userId)
+	for sessionId := range s.userSessions[userId] {
+		if SessionKey(sessionId) == key {
+			s.deleteSession(sessionId)
+			return nil
+		}
+	}
+
+	return ErrSessionNotFound
+}
+
+func (s *sessionManager) DeleteAllUserSessions(userId int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for sessionId := range s.userSessions[userId] {
+		s.deleteSession(sessionId)
+	}
+
+	return nil
 }
 
 func (s *sessionManager) BlockSession(r *http.Request) error {
@@ -210,3 +235,18 @@ func (s *sessionManager) BlockSession(r *http.Request) error {
 
 	return nil
 }
+
+// deleteSession expects the caller to hold the write lock.
+func (s *sessionManager) deleteSession(sessionId string) {
+	uid, exists := s.store[sessionId][UserId]
+	delete(s.store, sessionId)
+	if !exists {
+		return
+	}
+
+	userId := uid.(int)
+	delete(s.userSessions[userId], sessionId)
+	if len(s.userSessions[userId]) == 0 {
+		delete(s.userSessions, userId)
+	}
+}
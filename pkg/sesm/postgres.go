@@ -0,0 +1,10 @@
+package sesm
+
+import (
+	"database/sql"
+	"time"
+)
+
+func NewPostgresSessionManager(db *sql.DB, lifetime time.Duration) *sqlSessionManager {
+	return newSqlSessionManager(db, lifetime)
+}
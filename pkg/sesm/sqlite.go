@@ -0,0 +1,362 @@
+package sesm
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/gob"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+var sweepInterval = 10 * time.Minute
+
+func init() {
+	gob.Register(time.Time{})
+}
+
+// sqlSessionManager keeps sessions in a table. Its queries use $n
+// placeholders, which both SQLite and PostgreSQL accept.
+type sqlSessionManager struct {
+	db       *sql.DB
+	lifetime time.Duration
+	mutex    sync.Mutex
+	stop     chan struct{}
+}
+
+func NewSqliteSessionManager(db *sql.DB, lifetime time.Duration) *sqlSessionManager {
+	return newSqlSessionManager(db, lifetime)
+}
+
+func newSqlSessionManager(db *sql.DB, lifetime time.Duration) *sqlSessionManager {
+	s := &sqlSessionManager{
+		db:       db,
+		lifetime: lifetime,
+		stop:     make(chan struct{}),
+	}
+
+	go s.sweepExpired()
+
+	return s
+}
+
+func (s *sqlSessionManager) Close() {
+	close(s.stop)
+}
+
+func (s *sqlSessionManager) sweepExpired() {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.deleteExpired()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *sqlSessionManager) deleteExpired() error {
+	return s.delete("DELETE FROM sessions WHERE last_request < $1", time.Now().Add(-s.lifetime).UTC())
+}
+
+func (s *sqlSessionManager) CreateSession(r *http.Request, userId int) (string, error) {
+	newUUID, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+
+	sessionId := newUUID.String()
+	sessionData := newSessionData(r, userId)
+
+	data, err := encodeSession(sessionData)
+	if err != nil {
+		return "", err
+	}
+
+	query := "INSERT INTO sessions (id, user_id, data, last_request) VALUES ($1, $2, $3, $4)"
+	stmt, err := s.db.Prepare(query)
+	if err != nil {
+		return "", err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(sessionId, userId, data, sessionData[LastRequest].(time.Time).UTC()); err != nil {
+		return "", err
+	}
+
+	return sessionId, nil
+}
+
+func (s *sqlSessionManager) CurrentSessionID(r *http.Request) (string, error) {
+	cookie, err := r.Cookie(SessionId)
+	if err != nil {
+		return "", ErrSessionNotFound
+	}
+
+	if _, err := s.get(cookie.Value); err != nil {
+		return "", err
+	}
+
+	return cookie.Value, nil
+}
+
+func (s *sqlSessionManager) DeleteCurrentSession(r *http.Request) error {
+	sessionId, err := s.CurrentSessionID(r)
+	if err != nil {
+		return nil
+	}
+
+	return s.delete("DELETE FROM sessions WHERE id = $1", sessionId)
+}
+
+func (s *sqlSessionManager) GetSessionData(r *http.Request, key string) (interface{}, error) {
+	sessionId, err := s.CurrentSessionID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.get(sessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	val, exists := data[key]
+	if !exists {
+		return nil, ErrDataNotFound
+	}
+
+	return val, nil
+}
+
+func (s *sqlSessionManager) AddOrUpdateSessionData(r *http.Request, dataMap session) error {
+	return s.update(r, func(data session) {
+		for key, val := range dataMap {
+			data[key] = val
+		}
+	})
+}
+
+func (s *sqlSessionManager) DeleteSessionData(r *http.Request, keys []string) error {
+	return s.update(r, func(data session) {
+		for _, key := range keys {
+			delete(data, key)
+		}
+	})
+}
+
+func (s *sqlSessionManager) UpdateSessionLastRequest(r *http.Request) error {
+	if err := s.AddOrUpdateSessionData(r, session{LastRequest: time.Now()}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *sqlSessionManager) UpdateSessionFlash(r *http.Request, val string) error {
+	if err := s.AddOrUpdateSessionData(r, session{Flash: val}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *sqlSessionManager) PopSessionFlash(r *http.Request) (string, error) {
+	flash, err := s.GetSessionData(r, Flash)
+	if errors.Is(err, ErrDataNotFound) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if err := s.DeleteSessionData(r, []string{Flash}); err != nil {
+		return "", err
+	}
+
+	return flash.(string), nil
+}
+
+func (s *sqlSessionManager) GetUserSessions(userId int) ([]*SessionInfo, error) {
+	query := "SELECT id, data FROM sessions WHERE user_id = $1 AND last_request >= $2"
+	stmt, err := s.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(userId, time.Now().Add(-s.lifetime).UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []*SessionInfo{}
+	for rows.Next() {
+		var sessionId string
+		var raw []byte
+
+		if err := rows.Scan(&sessionId, &raw); err != nil {
+			return nil, err
+		}
+
+		data, err := decodeSession(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, newSessionInfo(sessionId, data))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	sortSessionInfos(sessions)
+
+	return sessions, nil
+}
+
+func (s *sqlSessionManager) DeleteUserSession(userId int, key string) error {
+	query := "SELECT id FROM sessions WHERE user_id = $1"
+	stmt, err := s.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(userId)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	sessionId := ""
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+
+		if SessionKey(id) == key {
+			sessionId = id
+			break
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	if len(sessionId) == 0 {
+		return ErrSessionNotFound
+	}
+
+	return s.delete("DELETE FROM sessions WHERE id = $1", sessionId)
+}
+
+func (s *sqlSessionManager) DeleteAllUserSessions(userId int) error {
+	return s.delete("DELETE FROM sessions WHERE user_id = $1", userId)
+}
+
+func (s *sqlSessionManager) BlockSession(r *http.Request) error {
+	if err := s.AddOrUpdateSessionData(r, session{Status: "blocked", BlockTimestamp: time.Now()}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *sqlSessionManager) get(sessionId string) (session, error) {
+	query := "SELECT data FROM sessions WHERE id = $1 AND last_request >= $2"
+	stmt, err := s.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var raw []byte
+	if err := stmt.QueryRow(sessionId, time.Now().Add(-s.lifetime).UTC()).Scan(&raw); errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return decodeSession(raw)
+}
+
+func (s *sqlSessionManager) update(r *http.Request, apply func(data session)) error {
+	sessionId, err := s.CurrentSessionID(r)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, err := s.get(sessionId)
+	if err != nil {
+		return err
+	}
+
+	apply(data)
+
+	raw, err := encodeSession(data)
+	if err != nil {
+		return err
+	}
+
+	lastRequest, ok := data[LastRequest].(time.Time)
+	if !ok {
+		lastRequest = time.Now()
+	}
+
+	query := "UPDATE sessions SET data = $1, last_request = $2 WHERE id = $3"
+	stmt, err := s.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(raw, lastRequest.UTC(), sessionId); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *sqlSessionManager) delete(query string, arg interface{}) error {
+	stmt, err := s.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(arg); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func encodeSession(data session) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(data); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decodeSession(raw []byte) (session, error) {
+	data := make(session)
+	if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
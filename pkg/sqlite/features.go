@@ -0,0 +1,32 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// BuildTags are the go-sqlite3 build tags that compile in the SQLite features
+// the forum's migrations and queries use.
+const BuildTags = "sqlite_fts5 sqlite_math_functions"
+
+var (
+	ErrNoFTS5          = errors.New(`SQLITE: FTS5 is not available, build with -tags "` + BuildTags + `"`)
+	ErrNoMathFunctions = errors.New(`SQLITE: math functions are not available, build with -tags "` + BuildTags + `"`)
+)
+
+// CheckFeatures reports an error when the linked SQLite library lacks a
+// feature the schema depends on, before a migration fails halfway through.
+func CheckFeatures(db *sql.DB) error {
+	var fts5, math bool
+	if err := db.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5'), sqlite_compileoption_used('ENABLE_MATH_FUNCTIONS')").Scan(&fts5, &math); err != nil {
+		return err
+	}
+
+	if !fts5 {
+		return ErrNoFTS5
+	} else if !math {
+		return ErrNoMathFunctions
+	}
+
+	return nil
+}
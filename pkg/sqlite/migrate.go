@@ -1,21 +1,355 @@
 package sqlite
 
 import (
+	"crypto/sha256"
 	"database/sql"
-	"io/ioutil"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
 )
 
-func Migrate(db *sql.DB, migrPath string) error {
-	content, err := ioutil.ReadFile(migrPath)
+var (
+	ErrChecksumMismatch = errors.New("MIGRATIONS: applied migration was modified")
+	ErrUnknownMigration = errors.New("MIGRATIONS: applied migration has no file")
+	ErrUnknownVersion   = errors.New("MIGRATIONS: unknown version")
+	ErrNoDownMigration  = errors.New("MIGRATIONS: migration has no down file")
+)
+
+// Migration files are named <version>_<name>.up.sql and <version>_<name>.down.sql,
+// e.g. 00002_add_polls.up.sql.
+//
+// The migrator's own statements are written in SQL that PostgreSQL accepts as
+// well, so it migrates both databases from their own directories.
+var migrationFileRx = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    checksum TEXT NOT NULL,
+    applied TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+)`
+
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+type MigrationStatus struct {
+	Version  int
+	Name     string
+	Applied  time.Time
+	Pending  bool
+	Modified bool
+	Missing  bool
+}
+
+type MigrationResult struct {
+	Applied    []int
+	RolledBack []int
+}
+
+type appliedMigration struct {
+	version  int
+	name     string
+	checksum string
+	applied  time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []*Migration
+}
+
+func NewMigrator(db *sql.DB, dir string) (*Migrator, error) {
+	migrations, err := LoadMigrations(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.Exec(createMigrationsTable); err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Migrate applies every pending migration found in dir.
+func Migrate(db *sql.DB, dir string) error {
+	m, err := NewMigrator(db, dir)
 	if err != nil {
 		return err
 	}
-	script := string(content)
 
-	_, err = db.Exec(script)
+	_, err = m.Up()
+	return err
+}
+
+// LoadMigrations reads the migration files in dir, sorted by version.
+func LoadMigrations(dir string) ([]*Migration, error) {
+	entries, err := os.ReadDir(dir)
 	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := migrationFileRx.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("MIGRATIONS: invalid version in %s", entry.Name())
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("MIGRATIONS: version %d is used by %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+			sum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if len(migration.Checksum) == 0 {
+			return nil, fmt.Errorf("MIGRATIONS: version %d has no up file", migration.Version)
+		}
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up() (*MigrationResult, error) {
+	if len(m.migrations) == 0 {
+		return &MigrationResult{}, nil
+	}
+
+	return m.To(m.migrations[len(m.migrations)-1].Version)
+}
+
+// Down rolls back the most recently applied migration.
+func (m *Migrator) Down() (*MigrationResult, error) {
+	applied, err := m.verify()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(applied) == 0 {
+		return &MigrationResult{}, nil
+	}
+
+	target := 0
+	if len(applied) > 1 {
+		target = applied[len(applied)-2].version
+	}
+
+	return m.To(target)
+}
+
+// To applies or rolls back migrations until version is the latest applied one.
+// Version 0 rolls back everything. On error, the result holds the migrations
+// that were changed before the failing one.
+func (m *Migrator) To(version int) (*MigrationResult, error) {
+	if version != 0 && m.find(version) == nil {
+		return nil, ErrUnknownVersion
+	}
+
+	applied, err := m.verify()
+	if err != nil {
+		return nil, err
+	}
+
+	isApplied := make(map[int]bool, len(applied))
+	for _, a := range applied {
+		isApplied[a.version] = true
+	}
+
+	result := &MigrationResult{}
+	for i := len(applied) - 1; i >= 0; i-- {
+		if applied[i].version <= version {
+			continue
+		}
+
+		if err := m.rollback(m.find(applied[i].version)); err != nil {
+			return result, err
+		}
+		result.RolledBack = append(result.RolledBack, applied[i].version)
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version > version || isApplied[migration.Version] {
+			continue
+		}
+
+		if err := m.apply(migration); err != nil {
+			return result, err
+		}
+		result.Applied = append(result.Applied, migration.Version)
+	}
+
+	return result, nil
+}
+
+// Status lists every known migration, and applied migrations whose file is gone.
+func (m *Migrator) Status() ([]*MigrationStatus, error) {
+	applied, err := m.getApplied()
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*appliedMigration, len(applied))
+	for _, a := range applied {
+		byVersion[a.version] = a
+	}
+
+	statuses := []*MigrationStatus{}
+	for _, migration := range m.migrations {
+		status := &MigrationStatus{Version: migration.Version, Name: migration.Name, Pending: true}
+		if a, ok := byVersion[migration.Version]; ok {
+			status.Pending = false
+			status.Applied = a.applied
+			status.Modified = a.checksum != migration.Checksum
+			delete(byVersion, migration.Version)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	for _, a := range byVersion {
+		statuses = append(statuses, &MigrationStatus{Version: a.version, Name: a.name, Applied: a.applied, Missing: true})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
+
+func (m *Migrator) apply(migration *Migration) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(migration.Up); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("MIGRATIONS: applying %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version, name, checksum) VALUES($1, $2, $3)", migration.Version, migration.Name, migration.Checksum); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *Migrator) rollback(migration *Migration) error {
+	if len(migration.Down) == 0 {
+		return fmt.Errorf("%w: %d_%s", ErrNoDownMigration, migration.Version, migration.Name)
+	}
+
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(migration.Down); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("MIGRATIONS: rolling back %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+		tx.Rollback()
 		return err
 	}
 
+	return tx.Commit()
+}
+
+// verify returns the applied migrations after checking that each one still
+// has a file with the same content as when it was applied.
+func (m *Migrator) verify() ([]*appliedMigration, error) {
+	applied, err := m.getApplied()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range applied {
+		migration := m.find(a.version)
+		if migration == nil {
+			return nil, fmt.Errorf("%w: %d_%s", ErrUnknownMigration, a.version, a.name)
+		}
+
+		if migration.Checksum != a.checksum {
+			return nil, fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, a.version, a.name)
+		}
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) getApplied() ([]*appliedMigration, error) {
+	rows, err := m.db.Query("SELECT version, name, checksum, applied FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := []*appliedMigration{}
+	for rows.Next() {
+		a := &appliedMigration{}
+		if err := rows.Scan(&a.version, &a.name, &a.checksum, &a.applied); err != nil {
+			return nil, err
+		}
+		applied = append(applied, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) find(version int) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+
 	return nil
 }
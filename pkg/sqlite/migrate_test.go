@@ -0,0 +1,336 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// testMigrations creates two tables and adds a column; the third has no down
+// file.
+var testMigrations = map[string]string{
+	"00001_users.up.sql":      "CREATE TABLE users (id INTEGER PRIMARY KEY);",
+	"00001_users.down.sql":    "DROP TABLE users;",
+	"00002_posts.up.sql":      "CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users(id));",
+	"00002_posts.down.sql":    "DROP TABLE posts;",
+	"00003_post_title.up.sql": "ALTER TABLE posts ADD COLUMN title TEXT;",
+	"README.md":               "not a migration",
+}
+
+func newTestMigrator(t *testing.T) (*Migrator, *sql.DB, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range testMigrations {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	db, err := NewSqlite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	m, err := NewMigrator(db, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m, db, dir
+}
+
+func hasTable(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+
+	return count == 1
+}
+
+func status(t *testing.T, m *Migrator) string {
+	t.Helper()
+
+	statuses, err := m.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := ""
+	for _, status := range statuses {
+		flag := "applied"
+		switch {
+		case status.Pending:
+			flag = "pending"
+		case status.Missing:
+			flag = "missing"
+		case status.Modified:
+			flag = "modified"
+		}
+		s += fmt.Sprintf("%d:%s ", status.Version, flag)
+	}
+
+	return s
+}
+
+func TestMigratorUpDown(t *testing.T) {
+	m, db, _ := newTestMigrator(t)
+
+	if got := status(t, m); got != "1:pending 2:pending 3:pending " {
+		t.Fatalf("status = %q", got)
+	}
+
+	result, err := m.Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(result.Applied, result.RolledBack); got != "[1 2 3] []" {
+		t.Fatalf("up = %s", got)
+	}
+	if got := status(t, m); got != "1:applied 2:applied 3:applied " {
+		t.Fatalf("status = %q", got)
+	}
+
+	result, err = m.Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Applied)+len(result.RolledBack) != 0 {
+		t.Fatalf("second up = %v", result)
+	}
+
+	if _, err := m.Down(); !errors.Is(err, ErrNoDownMigration) {
+		t.Fatalf("down without a down file: err = %v, want %v", err, ErrNoDownMigration)
+	}
+	if got := status(t, m); got != "1:applied 2:applied 3:applied " {
+		t.Fatalf("status after failed down = %q", got)
+	}
+
+	if _, err := db.Exec("DELETE FROM schema_migrations WHERE version = 3"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = m.Down()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(result.Applied, result.RolledBack); got != "[] [2]" {
+		t.Fatalf("down = %s", got)
+	}
+	if hasTable(t, db, "posts") || !hasTable(t, db, "users") {
+		t.Fatal("down did not drop only posts")
+	}
+}
+
+func TestMigratorTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    int
+		to      int
+		want    string
+		wantErr error
+		status  string
+	}{
+		{"up to 1", 0, 1, "[1] []", nil, "1:applied 2:pending 3:pending "},
+		{"up to 2", 1, 2, "[2] []", nil, "1:applied 2:applied 3:pending "},
+		{"down to 1", 2, 1, "[] [2]", nil, "1:applied 2:pending 3:pending "},
+		{"down to 0", 2, 0, "[] [2 1]", nil, "1:pending 2:pending 3:pending "},
+		{"unchanged", 2, 2, "[] []", nil, "1:applied 2:applied 3:pending "},
+		{"unknown version", 1, 4, "", ErrUnknownVersion, "1:applied 2:pending 3:pending "},
+		{"no down file", 3, 2, "[] []", ErrNoDownMigration, "1:applied 2:applied 3:applied "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _, _ := newTestMigrator(t)
+			if tt.from != 0 {
+				if _, err := m.To(tt.from); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			result, err := m.To(tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if result != nil {
+				if got := fmt.Sprint(result.Applied, result.RolledBack); got != tt.want {
+					t.Errorf("result = %s, want %s", got, tt.want)
+				}
+			}
+
+			if got := status(t, m); got != tt.status {
+				t.Errorf("status = %q, want %q", got, tt.status)
+			}
+		})
+	}
+}
+
+func TestMigratorChangedFiles(t *testing.T) {
+	m, _, dir := newTestMigrator(t)
+	if _, err := m.To(2); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "00002_posts.up.sql"), []byte("CREATE TABLE posts (id INTEGER PRIMARY KEY);"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewMigrator(m.db, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := status(t, m); got != "1:applied 2:modified 3:pending " {
+		t.Fatalf("status = %q", got)
+	}
+	if _, err := m.Up(); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("up: err = %v, want %v", err, ErrChecksumMismatch)
+	}
+	if _, err := m.Down(); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("down: err = %v, want %v", err, ErrChecksumMismatch)
+	}
+
+	for _, name := range []string{"00002_posts.up.sql", "00002_posts.down.sql"} {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m, err = NewMigrator(m.db, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := status(t, m); got != "1:applied 2:missing 3:pending " {
+		t.Fatalf("status = %q", got)
+	}
+	if _, err := m.Up(); !errors.Is(err, ErrUnknownMigration) {
+		t.Fatalf("up: err = %v, want %v", err, ErrUnknownMigration)
+	}
+}
+
+// The forum's own migrations must roll all the way back and apply again.
+func TestForumMigrations(t *testing.T) {
+	db, err := NewSqlite(filepath.Join(t.TempDir(), "forum.db") + "?_foreign_keys=on")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := CheckFeatures(db); err != nil {
+		t.Skipf("%v; run make test", err)
+	}
+
+	m, err := NewMigrator(db, "../../migrations/sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, step := range []func() (*MigrationResult, error){m.Up, func() (*MigrationResult, error) { return m.To(0) }, m.Up} {
+		if _, err := step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var tables int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('users', 'notifications', 'post_mentions', 'posts_fts')").Scan(&tables); err != nil {
+		t.Fatal(err)
+	}
+	if tables != 4 {
+		t.Fatalf("got %d of 4 tables after migrating down and up again", tables)
+	}
+}
+
+func TestBackfillNotifications(t *testing.T) {
+	db, err := NewSqlite(filepath.Join(t.TempDir(), "forum.db") + "?_foreign_keys=on")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := CheckFeatures(db); err != nil {
+		t.Skipf("%v; run make test", err)
+	}
+
+	m, err := NewMigrator(db, "../../migrations/sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exec := func(queries ...string) {
+		t.Helper()
+		for _, query := range queries {
+			if _, err := db.Exec(query); err != nil {
+				t.Fatalf("%s: %v", query, err)
+			}
+		}
+	}
+
+	// Activity from before notifications were recorded.
+	if _, err := m.To(7); err != nil {
+		t.Fatal(err)
+	}
+	exec(
+		"INSERT INTO users (id, username) VALUES (1, 'alice'), (2, 'bob')",
+		"INSERT INTO posts (id, user_id, title, content, created) VALUES (1, 1, 'Hello', 'world', '2024-01-01 10:00:00')",
+		`INSERT INTO comments (id, post_id, user_id, content, created) VALUES
+			(1, 1, 2, 'Hi', '2024-01-01 11:00:00'),
+			(2, 1, 1, 'Own post', '2024-01-01 12:00:00'),
+			(3, 1, 1, 'Reply', '2024-01-01 13:00:00')`,
+		"INSERT INTO comment_replies (comment_id, parent_id) VALUES (3, 1)",
+		"INSERT INTO post_reactions (id, post_id, user_id, is_like, created) VALUES (1, 1, 2, 1, '2024-01-01 14:00:00'), (2, 1, 1, 1, '2024-01-01 14:00:00')",
+		"INSERT INTO comment_reactions (id, comment_id, user_id, is_like, created) VALUES (1, 1, 1, 0, '2024-01-01 15:00:00')",
+	)
+
+	// Activity since, of which the notification for comment 5 was deleted.
+	if _, err := m.To(11); err != nil {
+		t.Fatal(err)
+	}
+	exec(
+		"INSERT INTO comments (id, post_id, user_id, content, created) VALUES (4, 1, 2, 'Later', '2024-02-01 10:00:00'), (5, 1, 2, 'Deleted', '2024-03-01 10:00:00')",
+		"INSERT INTO notifications (user_id, actor_id, type, post_id, comment_id, created) VALUES (1, 2, 'comment', 1, 4, '2024-02-01 10:00:00')",
+	)
+
+	if _, err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := db.Query("SELECT user_id, actor_id, type, COALESCE(comment_id, 0), read FROM notifications ORDER BY created, id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	got := []string{}
+	for rows.Next() {
+		var userId, actorId, commentId int
+		var notificationType string
+		var read bool
+		if err := rows.Scan(&userId, &actorId, &notificationType, &commentId, &read); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, fmt.Sprintf("%d<-%d %s %d %t", userId, actorId, notificationType, commentId, read))
+	}
+
+	want := []string{
+		"1<-2 comment 1 true",
+		"2<-1 reply 3 true",
+		"1<-2 post_like 0 true",
+		"2<-1 comment_dislike 1 true",
+		"1<-2 comment 4 false",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("notifications =\n%v\nwant\n%v", got, want)
+	}
+}
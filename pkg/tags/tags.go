@@ -0,0 +1,64 @@
+package tags
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	MaxLen     = 30
+	MaxPerPost = 5
+)
+
+var (
+	ErrInvalidTag = errors.New("TAGS: invalid tag")
+	ErrTooMany    = errors.New("TAGS: too many tags")
+)
+
+// Normalize lowercases name and strips a leading "#". Tags are made of
+// letters, digits, "-" and "_", and must start with a letter or a digit.
+func Normalize(name string) (string, error) {
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+	if len(name) == 0 || len(name) > MaxLen {
+		return "", ErrInvalidTag
+	}
+
+	for i, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		case (c == '-' || c == '_') && i > 0:
+		default:
+			return "", ErrInvalidTag
+		}
+	}
+
+	return name, nil
+}
+
+// Parse splits raw on commas and whitespace and normalizes every tag,
+// dropping duplicates.
+func Parse(raw string) ([]string, error) {
+	fields := strings.FieldsFunc(raw, func(c rune) bool {
+		return c == ',' || c == ' ' || c == '\t' || c == '\n' || c == '\r'
+	})
+
+	tags := []string{}
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		tag, err := Normalize(field)
+		if err != nil {
+			return nil, err
+		}
+
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	if len(tags) > MaxPerPost {
+		return nil, ErrTooMany
+	}
+
+	return tags, nil
+}
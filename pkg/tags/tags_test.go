@@ -0,0 +1,69 @@
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr error
+	}{
+		{"go", "go", nil},
+		{"#Go", "go", nil},
+		{"  web-dev ", "web-dev", nil},
+		{"snake_case", "snake_case", nil},
+		{"2024", "2024", nil},
+		{strings.Repeat("a", MaxLen), strings.Repeat("a", MaxLen), nil},
+		{strings.Repeat("a", MaxLen+1), "", ErrInvalidTag},
+		{"", "", ErrInvalidTag},
+		{"#", "", ErrInvalidTag},
+		{"##go", "", ErrInvalidTag},
+		{"-go", "", ErrInvalidTag},
+		{"_go", "", ErrInvalidTag},
+		{"c++", "", ErrInvalidTag},
+		{"web dev", "", ErrInvalidTag},
+		{"café", "", ErrInvalidTag},
+	}
+
+	for _, tt := range tests {
+		got, err := Normalize(tt.name)
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("Normalize(%q) = %q, %v, want %q, %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr error
+	}{
+		{"", "[]", nil},
+		{" , ,", "[]", nil},
+		{"#go, sqlite web-dev", "[go sqlite web-dev]", nil},
+		{"go,\tsqlite\r\nhtml", "[go sqlite html]", nil},
+		{"Go go #GO", "[go]", nil},
+		{"a b c d e", "[a b c d e]", nil},
+		{"a b c d e a", "[a b c d e]", nil},
+		{"a b c d e f", "", ErrTooMany},
+		{"go c++", "", ErrInvalidTag},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.raw)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("Parse(%q): err = %v, want %v", tt.raw, err, tt.wantErr)
+			continue
+		}
+
+		if err == nil && fmt.Sprint(got) != tt.want {
+			t.Errorf("Parse(%q) = %v, want %s", tt.raw, got, tt.want)
+		}
+	}
+}
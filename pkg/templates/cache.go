@@ -1,13 +1,30 @@
 package templates
 
 import (
+	"errors"
+	"fmt"
 	"html/template"
+	"net/url"
 	"path/filepath"
 	"strings"
+	texttemplate "text/template"
 	"time"
+
+	"github.com/itelman/forum/pkg/mentions"
 )
 
-type TemplateCache map[string]*template.Template
+type TemplateCache struct {
+	pages  map[string]*template.Template
+	emails map[string]*emailTemplate
+}
+
+// emailTemplate is an *email.html file parsed twice. The "subject" and "text"
+// templates run as text/template, so nothing in the headers or the plain-text
+// body is HTML-escaped; only "body" is rendered as HTML.
+type emailTemplate struct {
+	text *texttemplate.Template
+	html *template.Template
+}
 
 func humanDate(t time.Time) string {
 	if t.IsZero() {
@@ -16,16 +33,76 @@ func humanDate(t time.Time) string {
 	return t.Local().Format("02 Jan 2006 at 15:04")
 }
 
+func dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, errors.New("dict: odd number of arguments")
+	}
+
+	m := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, errors.New("dict: keys must be strings")
+		}
+		m[key] = pairs[i+1]
+	}
+
+	return m, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func pageURL(values url.Values, cursor string) string {
+	query := url.Values{}
+	for key, vals := range values {
+		if key != "cursor" {
+			query[key] = vals
+		}
+	}
+
+	if len(cursor) != 0 {
+		query.Set("cursor", cursor)
+	}
+
+	return "?" + query.Encode()
+}
+
+// linkMentions escapes content and turns mentions of the given usernames into
+// links to their profiles. Other "@" text is left as is.
+func linkMentions(content string, usernames []string) template.HTML {
+	escaped := template.HTMLEscapeString(content)
+
+	return template.HTML(mentions.ReplaceAll(escaped, func(username string) string {
+		if !contains(usernames, username) {
+			return "@" + username
+		}
+
+		return fmt.Sprintf(`<a class="mention" href="/users?name=%s">@%s</a>`, url.QueryEscape(username), username)
+	}))
+}
+
 var functions = template.FuncMap{
-	"humanDate": humanDate,
+	"humanDate":    humanDate,
+	"dict":         dict,
+	"contains":     contains,
+	"pageURL":      pageURL,
+	"linkMentions": linkMentions,
 }
 
 func NewTemplateCache(dir string) (TemplateCache, error) {
-	cache := TemplateCache{}
+	cache := TemplateCache{pages: map[string]*template.Template{}, emails: map[string]*emailTemplate{}}
 
 	pages, err := filepath.Glob(filepath.Join(dir, "*page.html"))
 	if err != nil {
-		return nil, err
+		return TemplateCache{}, err
 	}
 
 	for _, page := range pages {
@@ -33,21 +110,43 @@ func NewTemplateCache(dir string) (TemplateCache, error) {
 
 		ts, err := template.New(name).Funcs(functions).ParseFiles(page)
 		if err != nil {
-			return nil, err
+			return TemplateCache{}, err
 		}
 
 		ts, err = ts.ParseGlob(filepath.Join(dir, "*layout.html"))
 		if err != nil {
-			return nil, err
+			return TemplateCache{}, err
 		}
 
 		ts, err = ts.ParseGlob(filepath.Join(dir, "*partial.html"))
 		if err != nil {
-			return nil, err
+			return TemplateCache{}, err
+		}
+
+		name = strings.ReplaceAll(name, ".html", "")
+		cache.pages[name] = ts
+	}
+
+	emails, err := filepath.Glob(filepath.Join(dir, "*email.html"))
+	if err != nil {
+		return TemplateCache{}, err
+	}
+
+	for _, email := range emails {
+		name := filepath.Base(email)
+
+		text, err := texttemplate.New(name).Funcs(texttemplate.FuncMap(functions)).ParseFiles(email)
+		if err != nil {
+			return TemplateCache{}, err
+		}
+
+		html, err := template.New(name).Funcs(functions).ParseFiles(email)
+		if err != nil {
+			return TemplateCache{}, err
 		}
 
 		name = strings.ReplaceAll(name, ".html", "")
-		cache[name] = ts
+		cache.emails[name] = &emailTemplate{text: text, html: html}
 	}
 
 	return cache, nil
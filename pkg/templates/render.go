@@ -6,11 +6,13 @@ import (
 	"github.com/itelman/forum/internal/dto"
 	"github.com/itelman/forum/pkg/sesm"
 	"net/http"
+	"strings"
 	"time"
 )
 
 const (
 	AuthenticatedUser = "AuthenticatedUser"
+	UserRole          = "UserRole"
 	CurrentYear       = "CurrentYear"
 	Flash             = "Flash"
 	Error             = "Error"
@@ -21,6 +23,27 @@ const (
 	Comments          = "Comments"
 	PostReactions     = "PostReactions"
 	Comment           = "Comment"
+	Requests          = "Requests"
+	Reports           = "Reports"
+	Tokens            = "Tokens"
+	Token             = "Token"
+	Sessions          = "Sessions"
+	CurrentSession    = "CurrentSession"
+	Results           = "Results"
+	Cursor            = "Cursor"
+	NextCursor        = "NextCursor"
+	Sort              = "Sort"
+	Period            = "Period"
+	Query             = "Query"
+	Tag               = "Tag"
+	Tags              = "Tags"
+	Notifications     = "Notifications"
+	UnreadCount       = "UnreadCount"
+	Filter            = "Filter"
+	Recipient         = "Recipient"
+	Host              = "Host"
+	Preferences       = "Preferences"
+	Profile           = "Profile"
 )
 
 type TemplateData map[string]any
@@ -29,29 +52,59 @@ type TemplateRender interface {
 	RenderData(w http.ResponseWriter, r *http.Request, tmplName string, td TemplateData) error
 }
 
+// EmailRender renders the "subject", "text" and "body" templates defined in
+// an *email.html file. Only body is HTML.
+type EmailRender interface {
+	RenderEmail(tmplName string, td TemplateData) (subject string, text string, body string, err error)
+}
+
 type templateRender struct {
 	templateCache TemplateCache
 	sesManager    sesm.SessionManager
+	unreadCount   func(userID int) (int, error)
 }
 
-func NewTemplateRender(templateCache TemplateCache, sesManager sesm.SessionManager) *templateRender {
-	return &templateRender{templateCache: templateCache, sesManager: sesManager}
+func NewTemplateRender(templateCache TemplateCache, sesManager sesm.SessionManager, opts ...Option) *templateRender {
+	tr := &templateRender{templateCache: templateCache, sesManager: sesManager}
+	for _, opt := range opts {
+		opt(tr)
+	}
+
+	return tr
+}
+
+type Option func(*templateRender)
+
+// WithUnreadCount adds the number of unread notifications to every page
+// rendered for a signed-in user.
+func WithUnreadCount(count func(userID int) (int, error)) Option {
+	return func(tr *templateRender) {
+		tr.unreadCount = count
+	}
 }
 
 func (tr *templateRender) RenderData(w http.ResponseWriter, r *http.Request, tmplName string, td TemplateData) error {
-	ts, ok := tr.templateCache[tmplName]
+	ts, ok := tr.templateCache.pages[tmplName]
 	if !ok {
 		return fmt.Errorf("TEMPLATE CACHE: template not found (%s)", tmplName)
 	}
 
 	addDefaultData(r, td)
 
-	if dto.GetAuthUser(r) != nil {
+	if user := dto.GetAuthUser(r); user != nil {
 		val, err := tr.sesManager.PopSessionFlash(r)
 		if err != nil {
 			return err
 		}
 		td[Flash] = val
+
+		if tr.unreadCount != nil {
+			count, err := tr.unreadCount(user.ID)
+			if err != nil {
+				return err
+			}
+			td[UnreadCount] = count
+		}
 	}
 
 	buf := new(bytes.Buffer)
@@ -68,7 +121,38 @@ func (tr *templateRender) RenderData(w http.ResponseWriter, r *http.Request, tmp
 	return nil
 }
 
+func (tr *templateRender) RenderEmail(tmplName string, td TemplateData) (string, string, string, error) {
+	ts, ok := tr.templateCache.emails[tmplName]
+	if !ok {
+		return "", "", "", fmt.Errorf("TEMPLATE CACHE: template not found (%s)", tmplName)
+	}
+
+	td[CurrentYear] = time.Now().Year()
+
+	subject := new(bytes.Buffer)
+	if err := ts.text.ExecuteTemplate(subject, "subject", td); err != nil {
+		return "", "", "", err
+	}
+
+	text := new(bytes.Buffer)
+	if err := ts.text.ExecuteTemplate(text, "text", td); err != nil {
+		return "", "", "", err
+	}
+
+	body := new(bytes.Buffer)
+	if err := ts.html.ExecuteTemplate(body, "body", td); err != nil {
+		return "", "", "", err
+	}
+
+	return strings.TrimSpace(subject.String()), strings.TrimSpace(text.String()), body.String(), nil
+}
+
 func addDefaultData(r *http.Request, td TemplateData) {
 	td[AuthenticatedUser] = dto.GetAuthUser(r)
+	td[UserRole] = dto.GetUserRole(r)
 	td[CurrentYear] = time.Now().Year()
+
+	if _, ok := td[Query]; !ok {
+		td[Query] = r.URL.Query()
+	}
 }
@@ -0,0 +1,44 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itelman/forum/internal/dto"
+)
+
+func TestRenderEmail(t *testing.T) {
+	cache, err := NewTemplateCache("../../ui/html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actor := &dto.User{ID: 2, Username: "<b>bob</b>"}
+	subject, text, body, err := NewTemplateRender(cache, nil).RenderEmail("digest_email", TemplateData{
+		Recipient:     &dto.User{ID: 1, Username: "Tom & Jerry's"},
+		Notifications: []*dto.Notification{{ID: 1, Type: dto.NotificationComment, Actor: actor, PostID: 1, Created: time.Now()}},
+		UnreadCount:   1,
+		Period:        dto.DigestDaily,
+		Host:          "http://localhost",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if subject != "Your daily forum digest: 1 new notification" {
+		t.Errorf("subject = %q", subject)
+	}
+
+	for _, want := range []string{"Hi Tom & Jerry's,", "- <b>bob</b> left a comment on your post.", "http://localhost/user/notifications/open?id=1"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text does not contain %q:\n%s", want, text)
+		}
+	}
+
+	for _, want := range []string{"Hi Tom &amp; Jerry&#39;s,", "&lt;b&gt;bob&lt;/b&gt;"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body does not contain %q:\n%s", want, body)
+		}
+	}
+}
@@ -33,3 +33,147 @@ menuItems.forEach(
     menuItem.addEventListener("click", toggleMenu);
   }
 )
+
+
+const tagInputs = document.querySelectorAll("input[data-tag-autocomplete]");
+
+tagInputs.forEach(
+  function(input) {
+    const list = document.getElementById(input.getAttribute("list"));
+
+    input.addEventListener("input", function() {
+      const value = input.value;
+      const start = Math.max(value.lastIndexOf(" "), value.lastIndexOf(",")) + 1;
+      const prefix = value.slice(start).replace(/^#/, "");
+      if (prefix.length == 0) {
+        return;
+      }
+
+      const head = value.slice(0, start);
+      fetch("/api/v1/tags/autocomplete?q=" + encodeURIComponent(prefix))
+        .then(function(resp) { return resp.json(); })
+        .then(function(data) {
+          list.innerHTML = "";
+          data.tags.forEach(function(tag) {
+            const option = document.createElement("option");
+            option.value = head + tag.name;
+            list.appendChild(option);
+          });
+        });
+    });
+  }
+)
+
+
+const unreadBadge = document.querySelector("[data-unread-badge]");
+const commentsList = document.getElementById("comments");
+
+function setUnread(count) {
+  unreadBadge.textContent = count;
+  unreadBadge.hidden = count == 0;
+}
+
+function formatDate(value) {
+  const date = new Date(value);
+  const months = ["Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"];
+  const pad = function(n) { return String(n).padStart(2, "0"); };
+  return pad(date.getDate()) + " " + months[date.getMonth()] + " " + date.getFullYear() +
+    " at " + pad(date.getHours()) + ":" + pad(date.getMinutes());
+}
+
+// appendContent mirrors the linkMentions template function: mentions of the
+// given usernames become profile links, everything else stays plain text.
+function appendContent(el, content, mentions) {
+  const pattern = /(^|[^\w@.])@([\w.]+)/g;
+  let last = 0;
+  let match;
+  while ((match = pattern.exec(content)) !== null) {
+    const username = match[2].replace(/[._]+$/, "");
+    if (!mentions.includes(username)) {
+      continue;
+    }
+
+    const start = match.index + match[1].length;
+    const link = document.createElement("a");
+    link.className = "mention";
+    link.href = "/users?name=" + encodeURIComponent(username);
+    link.textContent = "@" + username;
+
+    el.append(content.slice(last, start), link);
+    last = start + 1 + username.length;
+  }
+  el.append(content.slice(last));
+}
+
+function renderComment(comment) {
+  const el = document.createElement("div");
+  el.className = "comment-posted";
+  el.id = "comment-" + comment.id;
+
+  const author = document.createElement("h3");
+  author.className = "comment-posted-username";
+  author.textContent = "Author: " + comment.user.username;
+
+  const text = document.createElement("p");
+  text.className = "comment-posted-text";
+  appendContent(text, comment.content, comment.mentions || []);
+
+  const metadata = document.createElement("div");
+  metadata.className = "comment-posted-metadata";
+
+  const time = document.createElement("time");
+  time.className = "comment-posted-time";
+  time.textContent = "Created: " + formatDate(comment.created);
+
+  metadata.appendChild(time);
+  el.append(author, text, metadata);
+  return el;
+}
+
+function appendComment(comment) {
+  if (document.getElementById("comment-" + comment.id)) {
+    return;
+  }
+
+  const empty = document.getElementById("no-comments");
+  if (empty) {
+    empty.remove();
+  }
+
+  const parent = comment.parent_id && document.getElementById("comment-" + comment.parent_id);
+  if (!parent) {
+    commentsList.prepend(renderComment(comment));
+    return;
+  }
+
+  let replies = parent.querySelector(":scope > .comment-replies");
+  if (!replies) {
+    replies = document.createElement("div");
+    replies.className = "comment-replies";
+    parent.appendChild(replies);
+  }
+  replies.appendChild(renderComment(comment));
+}
+
+if (unreadBadge && window.EventSource) {
+  let url = "/user/events";
+  if (commentsList) {
+    url += "?post_id=" + commentsList.dataset.postId;
+  }
+
+  const source = new EventSource(url);
+
+  source.addEventListener("notification", function() {
+    setUnread(Number(unreadBadge.textContent) + 1);
+  });
+
+  source.addEventListener("unread", function(e) {
+    setUnread(JSON.parse(e.data).count);
+  });
+
+  source.addEventListener("comment", function(e) {
+    if (commentsList) {
+      appendComment(JSON.parse(e.data));
+    }
+  });
+}